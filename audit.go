@@ -0,0 +1,72 @@
+package sflags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AuditShorthands makes ParseStruct report the short flags whose clusters are
+// ambiguous (see ShorthandWarnings), to the warning handler (see WarningHandler).
+func AuditShorthands() OptFunc {
+	return func(opt *opts) { opt.auditShorts = true }
+}
+
+// ShorthandWarnings returns warnings about the short flags taking a value, when
+// other short flags exist: since the letters following them in a cluster are read
+// as their value, -rf sets -r to "f" instead of setting both -r and -f. Flags only
+// optionally taking a value, like booleans, can be clustered in any order.
+func ShorthandWarnings(flags []*Flag) []Warning {
+	var valued, shorts []string
+
+	for _, flag := range flags {
+		if flag.Short == "" {
+			continue
+		}
+
+		shorts = append(shorts, flag.Short)
+
+		if !takesValue(flag) {
+			continue
+		}
+
+		valued = append(valued, flag.Short)
+	}
+
+	sort.Strings(shorts)
+
+	var warnings []Warning
+
+	for _, short := range valued {
+		var others []string
+
+		for _, other := range shorts {
+			if other != short {
+				others = append(others, "-"+other)
+			}
+		}
+
+		if len(others) == 0 {
+			continue
+		}
+
+		warnings = append(warnings, Warning{
+			Kind: WarningAmbiguous,
+			Flag: short,
+			Message: fmt.Sprintf("short flag -%s takes a value: in clusters like -%s%s, "+
+				"the letters after it are its value, not the flags %s",
+				short, short, others[0][1:], strings.Join(others, ", ")),
+		})
+	}
+
+	return warnings
+}
+
+// takesValue returns true if a flag requires a value when given.
+func takesValue(flag *Flag) bool {
+	if boolFlag, casted := flag.Value.(BoolFlag); casted && boolFlag.IsBoolFlag() {
+		return false
+	}
+
+	return len(flag.OptionalValue) == 0
+}
@@ -0,0 +1,38 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditCfg struct {
+	Recursive bool   `short:"r"`
+	Force     bool   `short:"f"`
+	Output    string `short:"o"`
+	Level     int    `short:"l" optional:"yes" optional-value:"1"`
+	Name      string `long:"name"`
+}
+
+func TestShorthandWarnings(t *testing.T) {
+	var warnings []Warning
+
+	_, err := ParseStruct(&auditCfg{}, AuditShorthands(), WarningHandler(func(w Warning) {
+		warnings = append(warnings, w)
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningAmbiguous, warnings[0].Kind)
+	assert.Equal(t, "o", warnings[0].Flag)
+	assert.Equal(t, "short flag -o takes a value: in clusters like -of, "+
+		"the letters after it are its value, not the flags -f, -l, -r", warnings[0].Message)
+
+	// Without other short flags, clusters are not possible.
+	flags, err := ParseStruct(&struct {
+		Output string `short:"o"`
+	}{})
+	require.NoError(t, err)
+	assert.Empty(t, ShorthandWarnings(flags))
+}
@@ -0,0 +1,142 @@
+package sflags
+
+import "reflect"
+
+// fieldKey identifies a struct field by its address and type, since
+// a struct and its first field share the same address.
+type fieldKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// Changed returns the names of the flags, as returned when parsing a struct,
+// which have been set on the command line, as opposed to the ones left to
+// their default values. Flags are returned in the order of the struct fields,
+// once even when they have been set through an alias or their --no-<flag>
+// form. Values set from profiles of defaults are not considered as changed.
+func Changed(flags []*Flag) []string {
+	var changed []string
+
+	seen := map[*changedValue]bool{}
+
+	for _, flag := range flags {
+		value, found := parsedValue(flag.Value)
+		if !found || seen[value] {
+			continue
+		}
+
+		seen[value] = true
+
+		if value.changed {
+			changed = append(changed, value.name)
+		}
+	}
+
+	return changed
+}
+
+// changedValue records the struct field it is bound to as changed, once set.
+type changedValue struct {
+	Value
+	name  string
+	field reflect.Value
+	flag  *Flag
+
+	// changed is true once the value has been set.
+	changed bool
+
+	// def is a copy of the field when the flag was generated,
+	// that is, its default value, to be restored by Reset.
+	def reflect.Value
+
+	// profile is the value wrapping this one, if
+	// the flag can be set from profiles of defaults.
+	profile *profiledValue
+}
+
+// Unset marks the flag of a value as not given anymore, as Reset does for
+// all the flags of a struct: it is not changed (see Changed), and can be set
+// from a profile again. Its struct field is left as it is, for generators
+// restoring defaults of their own. Returns false if the value has not been
+// generated by sflags.
+func Unset(val Value) bool {
+	value, found := parsedValue(val)
+	if !found {
+		return false
+	}
+
+	value.unset()
+
+	return true
+}
+
+// unset forgets that the value has been set, on the command line or from a profile.
+func (v *changedValue) unset() {
+	v.changed = false
+
+	if v.profile != nil {
+		v.profile.explicit = false
+		v.profile.profiled = false
+	}
+}
+
+// String returns the inner value as a string, or an empty string for
+// the zero values built by the flag package to print default values.
+func (v *changedValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+// trackChanges wraps a flag value so as to record when it is set.
+func trackChanges(val Value, flag *Flag, field reflect.Value) Value {
+	if !field.CanAddr() {
+		return val
+	}
+
+	return &changedValue{Value: val, name: flagName(flag), field: field, flag: flag, def: cloneValue(field)}
+}
+
+// unwrapChanged returns the value wrapped by a changedValue, if any.
+func unwrapChanged(val Value) Value {
+	if changed, ok := val.(*changedValue); ok {
+		return changed.Value
+	}
+
+	return val
+}
+
+// Get returns the inner value if it implements Getter, or nil.
+func (v *changedValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+
+	return nil
+}
+
+func (v *changedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+// Set sets the value, and records the field as changed if successful.
+func (v *changedValue) Set(val string) error {
+	if err := v.Value.Set(val); err != nil {
+		return err
+	}
+
+	// Invalid values collected instead of returned are not changes.
+	if collected, ok := v.Value.(*collectedValue); ok && collected.failed {
+		return nil
+	}
+
+	v.changed = true
+
+	return nil
+}
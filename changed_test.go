@@ -0,0 +1,77 @@
+package sflags
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanged(t *testing.T) {
+	cfg := &struct {
+		Host  string `long:"host"`
+		Port  int    `long:"port"`
+		Cache bool   `long:"cache" negatable:"true"`
+		Log   struct {
+			Level string `long:"level"`
+			Debug bool   `long:"debug"`
+		} `flag:"log"`
+		Run struct {
+			Dry bool `long:"dry"`
+		} `command:"run"`
+	}{Port: 80}
+
+	flags, err := ParseStruct(cfg, Profiles(map[string]interface{}{
+		"prod": map[string]string{"host": "example.com"},
+	}))
+	require.NoError(t, err)
+
+	byName := map[string]*Flag{}
+	for _, flag := range flags {
+		byName[flag.Name] = flag
+	}
+
+	assert.Empty(t, Changed(flags))
+
+	require.NoError(t, byName["profile"].Value.Set("prod"))
+	assert.Equal(t, "example.com", cfg.Host)
+	assert.Empty(t, Changed(flags), "profile values are not changes")
+
+	require.NoError(t, byName["log-level"].Value.Set("debug"))
+	require.NoError(t, byName["port"].Value.Set("80"))
+	require.NoError(t, byName["no-cache"].Value.Set("true"))
+	assert.Error(t, byName["log-debug"].Value.Set("maybe"))
+
+	assert.Equal(t, []string{"port", "cache", "log-level"}, Changed(flags))
+
+	// Parsing the struct again forgets about previous changes.
+	flags, err = ParseStruct(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, Changed(flags))
+
+	assert.Nil(t, Changed(nil))
+}
+
+func TestChangedPrintDefaults(t *testing.T) {
+	cfg := &struct {
+		Name  string `flag:"name"`
+		Count int    `flag:"count"`
+	}{Name: "server"}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(out)
+
+	for _, f := range flags {
+		fs.Var(f.Value, f.Name, f.Usage)
+	}
+
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), "(default server)")
+	assert.NotContains(t, out.String(), "panic")
+}
@@ -0,0 +1,191 @@
+// Command gendesc generates flag descriptions from the doc comments of struct fields.
+//
+// It parses the Go package in the current directory (or the one given as argument),
+// and for each struct type, registers the doc comment of its fields as their flag
+// usage, so that help text can live as normal Go comments instead of long tags:
+//
+//	//go:generate go run github.com/octago/sflags/cmd/gendesc
+//
+// Fields which already have a description tag are skipped.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const tmpl = `// Code generated by "gendesc"; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags"
+)
+
+func init() {
+{{- range .Types}}
+	sflags.RegisterDescriptions(reflect.TypeOf({{.Name}}{}), map[string]string{
+	{{- range .Fields}}
+		{{quote .Name}}: {{quote .Description}},
+	{{- end}}
+	})
+{{- end}}
+}
+`
+
+type field struct {
+	Name        string
+	Description string
+}
+
+type structType struct {
+	Name   string
+	Fields []field
+}
+
+func main() {
+	types := flag.String("types", "", "comma-separated list of struct types (default all)")
+	output := flag.String("output", "sflags_descriptions.go", "output file name")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	pkgName, structs, err := parseDir(dir, filter(*types))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(structs) == 0 {
+		log.Fatalf("no documented struct fields found in %s", dir)
+	}
+
+	src, err := generate(pkgName, structs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// filter returns the set of type names to generate, or nil for all of them.
+func filter(types string) map[string]bool {
+	if types == "" {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for _, name := range strings.Split(types, ",") {
+		names[strings.TrimSpace(name)] = true
+	}
+
+	return names
+}
+
+// parseDir parses the non-test files of a package, and returns its
+// name along with the documented fields of its struct types.
+func parseDir(dir string, names map[string]bool) (string, []structType, error) {
+	notTest := func(info fs.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }
+
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, notTest, parser.ParseComments)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+
+	var (
+		pkgName string
+		structs []structType
+	)
+
+	for name, pkg := range pkgs {
+		pkgName = name
+
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				spec, ok := node.(*ast.TypeSpec)
+				if !ok || (names != nil && !names[spec.Name.Name]) {
+					return true
+				}
+
+				// Generic types cannot be instantiated without type arguments.
+				if spec.TypeParams != nil {
+					return false
+				}
+
+				if typ, ok := spec.Type.(*ast.StructType); ok {
+					if fields := documented(typ); len(fields) > 0 {
+						structs = append(structs, structType{Name: spec.Name.Name, Fields: fields})
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+
+	return pkgName, structs, nil
+}
+
+// documented returns the exported fields of a struct having a doc
+// comment, unless they are not tagged or already have a description.
+func documented(typ *ast.StructType) []field {
+	var fields []field
+
+	for _, astField := range typ.Fields.List {
+		if astField.Doc == nil || astField.Tag == nil {
+			continue
+		}
+
+		tag, err := strconv.Unquote(astField.Tag.Value)
+		if err != nil || strings.Contains(tag, `desc:"`) || strings.Contains(tag, `description:"`) {
+			continue
+		}
+
+		desc := strings.Join(strings.Fields(astField.Doc.Text()), " ")
+
+		for _, name := range astField.Names {
+			if name.IsExported() {
+				fields = append(fields, field{Name: name.Name, Description: desc})
+			}
+		}
+	}
+
+	return fields
+}
+
+// generate returns the formatted source registering the descriptions.
+func generate(pkgName string, structs []structType) ([]byte, error) {
+	t := template.Must(template.New("gendesc").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(tmpl))
+
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, map[string]interface{}{"Package": pkgName, "Types": structs}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
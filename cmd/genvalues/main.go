@@ -201,6 +201,7 @@ func (v *{{.|SliceValueName}}) IsCumulative() bool {
 // -- {{ MapValueName $value . }}
 type {{ MapValueName $value . }} struct {
 	value *map[{{.}}]{{$value.Type}}
+	delimiter string
 }
 
 var _ RepeatableFlag = (*{{MapValueName $value .}})(nil)
@@ -215,10 +216,10 @@ func new{{MapValueName $value . | Title}}(m *map[{{.}}]{{$value.Type}}) *{{MapVa
 }
 
 func (v *{{MapValueName $value .}}) Set(s string) error {
-	ss := strings.Split(s, ":")
-    if len(ss) < 2 {
-        return errors.New("invalid map flag syntax, use -map=key1:val1")
-    }
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
+	}
 
 	{{ $kindVal := KindValue . }}
 
@@ -285,6 +286,10 @@ func (v *{{MapValueName $value .}}) Type() string { return "map[{{.}}]{{$value.T
 func (v *{{MapValueName $value .}}) IsCumulative() bool {
 	return true
 }
+
+func (v *{{MapValueName $value .}}) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
 {{end}}
 {{end}}
 
@@ -0,0 +1,103 @@
+package sflags
+
+import "fmt"
+
+// CollectErrors makes the values of flags record the errors of their Set method
+// in errs, instead of returning them, so that the flag library goes on parsing
+// the command line and all invalid flags can be reported at once (see Errors).
+// The caller must check errs once the command line has been parsed.
+func CollectErrors(errs *Errors) OptFunc {
+	return func(opt *opts) { opt.errors = errs }
+}
+
+// Collector returns the list in which a flag value generated by sflags records
+// the errors of its Set method (see CollectErrors), or nil if it returns them.
+// Generators find with it the errors to report for the flags of a command.
+func Collector(val Value) *Errors {
+	if collected := collectedBy(val); collected != nil {
+		return collected.errs
+	}
+
+	return nil
+}
+
+// collectedValue records the errors of a flag value in a list of errors.
+type collectedValue struct {
+	Value
+	flag *Flag
+	errs *Errors
+
+	// failed is true when the last error has been collected,
+	// so that the flag is not considered as changed.
+	failed bool
+}
+
+func (v *collectedValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+// Set sets the value, recording its error, with the word (redacted
+// for secret flags) and flag name, like flag libraries would print it.
+func (v *collectedValue) Set(val string) error {
+	err := v.Value.Set(val)
+
+	v.failed = err != nil
+	if err == nil {
+		return nil
+	}
+
+	word := fmt.Sprintf("%q", val)
+	if v.flag.Secret {
+		word = `"********"`
+	}
+
+	name := "--" + v.flag.Name
+	if v.flag.Name == "" {
+		name = "-" + v.flag.Short
+	}
+
+	*v.errs = append(*v.errs, fmt.Errorf("invalid argument %s for %q flag: %w", word, name, err))
+
+	return nil
+}
+
+// collectedBy returns the value collecting the errors of a flag value, if any.
+func collectedBy(val Value) *collectedValue {
+	for {
+		switch value := val.(type) {
+		case *collectedValue:
+			return value
+		case *changedValue:
+			val = value.Value
+		case *boolFlagValue:
+			val = value.Value
+		case *profiledValue:
+			val = value.Value
+		case *negatedValue:
+			val = value.Value
+		default:
+			return nil
+		}
+	}
+}
+
+// Get returns the inner value if it implements Getter, or nil.
+func (v *collectedValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+
+	return nil
+}
+
+func (v *collectedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
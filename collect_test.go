@@ -0,0 +1,70 @@
+package sflags
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type collectCfg struct {
+	Port     int    `long:"port"`
+	Format   string `long:"format" choices:"json yaml"`
+	Password string `long:"password" secret:"true" validate:"min=8"`
+}
+
+func TestCollectErrors(t *testing.T) {
+	var errs Errors
+
+	cfg := &collectCfg{}
+	flags, err := ParseStruct(cfg, CollectErrors(&errs))
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	// Invalid values do not fail, but are recorded.
+	assert.NoError(t, flags[0].Value.Set("http"))
+	assert.NoError(t, flags[1].Value.Set("xml"))
+	assert.NoError(t, flags[2].Value.Set("short"))
+	assert.NoError(t, flags[0].Value.Set("8080"))
+	assert.Equal(t, 8080, cfg.Port)
+
+	require.Len(t, errs, 3)
+	assert.ErrorContains(t, errs[0], `invalid argument "http" for "--port" flag`)
+	assert.ErrorContains(t, errs[1], `invalid argument "xml" for "--format" flag`)
+	assert.ErrorContains(t, errs[2], `invalid argument "********" for "--password" flag`)
+	assert.NotContains(t, errs.Error(), "short")
+
+	// Nor are they changes.
+	assert.Equal(t, []string{"port"}, Changed(flags))
+
+	// The list is found from the values of the flags.
+	assert.Same(t, &errs, Collector(flags[1].Value))
+	assert.Nil(t, Collector(&stringValue{}))
+
+	// The list matches any of its errors.
+	var convErr *ConvertError
+	assert.True(t, errors.As(errs, &convErr))
+	assert.Equal(t, "http", convErr.Word)
+	assert.True(t, errors.Is(errs, strconv.ErrSyntax))
+
+	assert.Nil(t, Errors(nil).Err())
+	assert.Equal(t, errs[0], errs[:1].Err())
+	assert.Equal(t, errs, errs.Err())
+}
+
+func TestFlagSetCollected(t *testing.T) {
+	var errs Errors
+
+	cfg := &collectCfg{}
+	flags, err := ParseStruct(cfg, CollectErrors(&errs))
+	require.NoError(t, err)
+
+	// Errors are returned by the flag, and not collected.
+	assert.ErrorIs(t, flags[0].Set("http"), strconv.ErrSyntax)
+	assert.Empty(t, errs)
+
+	require.NoError(t, flags[0].Set("8080"))
+	assert.Equal(t, 8080, cfg.Port)
+}
@@ -1,6 +1,7 @@
 package sflags
 
 import (
+	"context"
 	"reflect"
 )
 
@@ -15,12 +16,32 @@ type Commander interface {
 	Execute(args []string) (err error)
 }
 
+// ContextCommander is an optional interface for commands honoring the
+// cancellation and deadlines of the context they are executed with. Generators
+// supporting contexts call ExecuteContext instead of Execute, which commands
+// must still implement, for generators (or callers) without contexts.
+type ContextCommander interface {
+	Commander
+	ExecuteContext(ctx context.Context, args []string) error
+}
+
 // IsCommand checks both tags and implementations on a pointer to a struct,
 // initializing the value itself if it's nil (useful for callers).
+// Fields of interface types are commands if they hold a pointer to a
+// command struct, assigned before parsing (like plugin implementations).
 func IsCommand(val reflect.Value) (reflect.Value, bool, Commander) {
 	// Initialize if needed
 	var ptrval reflect.Value
 
+	// Interfaces hold the command, which cannot be initialized.
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() || val.Elem().Kind() != reflect.Ptr || val.Elem().IsNil() {
+			return val, false, nil
+		}
+
+		val = val.Elem()
+	}
+
 	// We just want to get interface, even if nil
 	if val.Kind() == reflect.Ptr {
 		ptrval = val
@@ -41,3 +62,78 @@ func IsCommand(val reflect.Value) (reflect.Value, bool, Commander) {
 
 	return ptrval, true, cmd
 }
+
+// PreRunner is an optional interface for commands preparing their execution,
+// like opening connections or checking combinations of flags. PreRun is called
+// with the same arguments as Execute, once the command line has been parsed
+// and checked: Execute is not called if PreRun returns an error.
+type PreRunner interface {
+	PreRun(args []string) error
+}
+
+// PersistentPreRunner is an optional interface for parent commands running some
+// setup (logging, configuration, authentication) before any of their subcommands
+// is executed, or themselves. PersistentPreRun is called with the arguments of the
+// executed command, after the persistent pre-runs of its own parent commands.
+type PersistentPreRunner interface {
+	PersistentPreRun(args []string) error
+}
+
+// PostRunner is an optional interface for commands cleaning up after their
+// execution. PostRun is called after Execute, with its error, even if it failed.
+type PostRunner interface {
+	PostRun(err error)
+}
+
+// DefaultCommander is an optional interface for commands with subcommands, running
+// one of them when invoked without any: DefaultCommand returns its name. This is
+// an alternative to tagging the subcommand field with `default:"true"`.
+type DefaultCommander interface {
+	DefaultCommand() string
+}
+
+// ArgValidator is an optional interface for commands whose positional arguments
+// depend on values previously parsed onto them (eg. a --profile flag selecting the
+// set of valid targets). ValidateArg is called with the name of each positional
+// argument and each word given to it, once the command flags and the preceding
+// positionals have been parsed onto the command struct.
+type ArgValidator interface {
+	ValidateArg(name, word string) error
+}
+
+// ContextSetter is an optional interface for commands needing session-scoped
+// dependencies (user identity, connections, loggers, etc), which are injected
+// by the command generators right before the command is executed.
+type ContextSetter interface {
+	// SetContext is called with the session value bound to
+	// the command tree, before Execute is called.
+	SetContext(ctx interface{})
+}
+
+// Defaulter is an optional interface for commands and groups of options whose
+// default values are only known at runtime (eg. the hostname or the current user).
+// SetDefaults is called before the flags of the struct are generated, so that its
+// defaults are shown in help output, and kept when their flags are not given.
+type Defaulter interface {
+	SetDefaults()
+}
+
+// ApplyDefaults calls the SetDefaults method of a struct implementing Defaulter,
+// given either as a pointer to the struct or an addressable struct value.
+func ApplyDefaults(val reflect.Value) {
+	if val.Kind() != reflect.Ptr {
+		if !val.CanAddr() {
+			return
+		}
+
+		val = val.Addr()
+	}
+
+	if val.IsNil() || !val.CanInterface() {
+		return
+	}
+
+	if defaulter, ok := val.Interface().(Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+}
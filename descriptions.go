@@ -0,0 +1,40 @@
+package sflags
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	descriptions   = map[reflect.Type]map[string]string{}
+	descriptionsMu sync.RWMutex
+)
+
+// RegisterDescriptions registers the descriptions of the fields of a struct
+// type, by field name. They are used as the usage of flags which have no
+// description tag. This function is mostly called from code generated with
+// the cmd/gendesc tool, which extracts the doc comments of struct fields:
+//
+//	//go:generate go run github.com/octago/sflags/cmd/gendesc
+func RegisterDescriptions(typ reflect.Type, descs map[string]string) {
+	descriptionsMu.Lock()
+	defer descriptionsMu.Unlock()
+
+	if descriptions[typ] == nil {
+		descriptions[typ] = map[string]string{}
+	}
+
+	for field, desc := range descs {
+		descriptions[typ][field] = desc
+	}
+}
+
+// Description returns the description registered for a field of a struct type.
+func Description(typ reflect.Type, field string) (string, bool) {
+	descriptionsMu.RLock()
+	defer descriptionsMu.RUnlock()
+
+	desc, found := descriptions[typ][field]
+
+	return desc, found
+}
@@ -0,0 +1,45 @@
+package sflags
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describedGroup struct {
+	Level int `flag:"level"`
+}
+
+type describedCfg struct {
+	Addr   string         `flag:"addr"`
+	Name   string         `flag:"name" desc:"from tag"`
+	Nested describedGroup `flag:"nested"`
+}
+
+func TestRegisterDescriptions(t *testing.T) {
+	RegisterDescriptions(reflect.TypeOf(describedCfg{}), map[string]string{
+		"Addr": "address to listen on",
+		"Name": "from comment",
+	})
+	RegisterDescriptions(reflect.TypeOf(describedGroup{}), map[string]string{
+		"Level": "verbosity level",
+	})
+
+	desc, found := Description(reflect.TypeOf(describedCfg{}), "Addr")
+	assert.True(t, found)
+	assert.Equal(t, "address to listen on", desc)
+
+	_, found = Description(reflect.TypeOf(describedCfg{}), "Nested")
+	assert.False(t, found)
+
+	flags, err := ParseStruct(&describedCfg{})
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	assert.Equal(t, "address to listen on", flags[0].Usage)
+	assert.Equal(t, "from tag", flags[1].Usage)
+	assert.Equal(t, "nested-level", flags[2].Name)
+	assert.Equal(t, "verbosity level", flags[2].Usage)
+}
@@ -0,0 +1,130 @@
+package sflags
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/octago/sflags/internal/convert"
+)
+
+// Change is a field whose value differs between two instances of a struct.
+type Change struct {
+	Flag  string // Name of the flag of the field.
+	Field string // Path of the field in the struct, like HTTP.Port.
+	Old   string // Value of the field in the first struct.
+	New   string // Value of the field in the second struct.
+}
+
+// Diff compares two instances (or pointers to instances) of the same tagged
+// struct, and returns the fields whose values differ, in the order of the struct
+// fields and with the names of their flags, eg. to show configuration changes
+// before applying them. Nested groups of options are compared recursively, the
+// values of secret options are redacted, and subcommands are not compared.
+// It returns nil if the values are not structs of the same type.
+func Diff(a, b interface{}, optFuncs ...OptFunc) []Change {
+	aVal := reflect.Indirect(reflect.ValueOf(a))
+	bVal := reflect.Indirect(reflect.ValueOf(b))
+
+	if aVal.Kind() != reflect.Struct || aVal.Type() != bVal.Type() {
+		return nil
+	}
+
+	return diffFields(aVal, bVal, "", defOpts().apply(optFuncs...))
+}
+
+// diffFields returns the changed fields of two structs of the same type.
+func diffFields(aVal, bVal reflect.Value, path string, opt opts) []Change {
+	var changes []Change
+
+	for i := 0; i < aVal.NumField(); i++ {
+		field := aVal.Type().Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		flag, mtag := parseFlagTag(field, opt)
+		if flag == nil {
+			continue
+		}
+
+		if _, isCmd := mtag.Get("command"); isCmd {
+			continue
+		}
+
+		aField, bField := aVal.Field(i), bVal.Field(i)
+
+		// Recurse into groups of options, with their prefix.
+		if isGroup(field.Type) {
+			changes = append(changes, diffGroup(aField, bField, field, flag, path, opt)...)
+
+			continue
+		}
+
+		if reflect.DeepEqual(aField.Interface(), bField.Interface()) {
+			continue
+		}
+
+		change := Change{
+			Flag:  flagName(flag),
+			Field: path + field.Name,
+			Old:   flag.DisplayValue(valueString(aField)),
+			New:   flag.DisplayValue(valueString(bField)),
+		}
+
+		if flag.Secret {
+			change.Old, change.New = redacted(change.Old), redacted(change.New)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// diffGroup returns the changed fields of a group of options,
+// one of the two being possibly a nil pointer (thus all zero).
+func diffGroup(aField, bField reflect.Value, field reflect.StructField, flag *Flag, path string, opt opts) []Change {
+	aInner, bInner := reflect.Indirect(aField), reflect.Indirect(bField)
+	if !aInner.IsValid() {
+		aInner = reflect.New(aField.Type().Elem()).Elem()
+	}
+
+	if !bInner.IsValid() {
+		bInner = reflect.New(bField.Type().Elem()).Elem()
+	}
+
+	groupOpt := opt
+	if !field.Anonymous || !opt.flatten {
+		groupOpt.prefix = flag.Name + opt.flagDivider
+	}
+
+	return diffFields(aInner, bInner, path+field.Name+".", groupOpt)
+}
+
+// redacted hides the value of a secret option, unless it is empty.
+func redacted(val string) string {
+	if val == "" {
+		return ""
+	}
+
+	return "********"
+}
+
+// isGroup returns true if fields of a type are groups of options,
+// as opposed to struct values (like time.Time) parsed from a word.
+func isGroup(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || isStdlib(typ) || convert.HasParser(typ) {
+		return false
+	}
+
+	switch reflect.New(typ).Interface().(type) {
+	case Value, encoding.TextUnmarshaler:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,49 @@
+package sflags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type diffHTTP struct {
+	Host    string        `long:"host"`
+	Timeout time.Duration `long:"timeout"`
+	Token   string        `long:"token" secret:"true"`
+}
+
+type diffCfg struct {
+	HTTP    diffHTTP  `group:"http" flag:"http"`
+	Proxy   *diffHTTP `group:"proxy" flag:"proxy"`
+	Tags    []string  `long:"tag"`
+	Since   time.Time `long:"since"`
+	Verbose bool      `long:"verbose"`
+	Skipped int       `flag:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	before := &diffCfg{
+		HTTP: diffHTTP{Host: "localhost", Timeout: time.Second, Token: "old"},
+		Tags: []string{"a"},
+	}
+
+	after := *before
+	after.HTTP.Timeout = 5 * time.Second
+	after.HTTP.Token = "new"
+	after.Proxy = &diffHTTP{Host: "proxy"}
+	after.Tags = []string{"a", "b"}
+	after.Since = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	after.Skipped = 1
+
+	assert.Equal(t, []Change{
+		{Flag: "http-timeout", Field: "HTTP.Timeout", Old: "1s", New: "5s"},
+		{Flag: "http-token", Field: "HTTP.Token", Old: "********", New: "********"},
+		{Flag: "proxy-host", Field: "Proxy.Host", Old: "", New: "proxy"},
+		{Flag: "tag", Field: "Tags", Old: "[a]", New: "[a b]"},
+		{Flag: "since", Field: "Since", Old: "0001-01-01 00:00:00 +0000 UTC", New: "2024-01-02 00:00:00 +0000 UTC"},
+	}, Diff(before, &after))
+
+	assert.Empty(t, Diff(before, before))
+	assert.Nil(t, Diff(before, &struct{}{}))
+}
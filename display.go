@@ -0,0 +1,113 @@
+package sflags
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DisplayFunc transforms the value of a flag (as text) before it is displayed,
+// like in help output, console settings or configuration diffs: the value of
+// the struct field itself is never modified.
+type DisplayFunc func(value string) string
+
+var (
+	displays = map[string]DisplayFunc{
+		"basename":       displayBasename,
+		"duration-human": displayDuration,
+		"mask":           displayMask,
+	}
+	displaysMu sync.RWMutex
+)
+
+// RegisterDisplay registers a display function, used by the flags whose
+// fields are tagged with `display:"name"`. Built-in display functions are:
+//   - basename: the last element of paths.
+//   - duration-human: durations without their zero units, like 1d2h or 1m30s.
+//   - mask: all characters but the last four replaced with *.
+func RegisterDisplay(name string, display DisplayFunc) {
+	displaysMu.Lock()
+	defer displaysMu.Unlock()
+
+	displays[name] = display
+}
+
+// DisplayValue returns a value of the flag as it should be displayed,
+// transformed by the display function set by its `display` tag, if any.
+func (f *Flag) DisplayValue(value string) string {
+	if f.Display == "" {
+		return value
+	}
+
+	displaysMu.RLock()
+	display, found := displays[f.Display]
+	displaysMu.RUnlock()
+
+	if !found {
+		return value
+	}
+
+	return display(value)
+}
+
+// displayBasename returns the last element of a path.
+func displayBasename(value string) string {
+	if value == "" {
+		return value
+	}
+
+	return filepath.Base(value)
+}
+
+// displayDuration returns a duration without its zero units, and with days.
+func displayDuration(value string) string {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return value
+	}
+
+	sign := ""
+	if duration < 0 {
+		sign, duration = "-", -duration
+	}
+
+	if duration < time.Second {
+		return value
+	}
+
+	var human strings.Builder
+
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	}
+
+	for _, u := range units {
+		if count := duration / u.unit; count > 0 {
+			fmt.Fprintf(&human, "%d%s", count, u.suffix)
+			duration -= count * u.unit
+		}
+	}
+
+	if duration > 0 {
+		human.WriteString(duration.String())
+	}
+
+	return sign + human.String()
+}
+
+// displayMask replaces all characters of a value but the last four with *.
+func displayMask(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
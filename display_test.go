@@ -0,0 +1,54 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayFuncs(t *testing.T) {
+	tests := []struct {
+		display  string
+		value    string
+		expected string
+	}{
+		{"basename", "/etc/app/config.yaml", "config.yaml"},
+		{"basename", "", ""},
+		{"duration-human", "1h30m0s", "1h30m"},
+		{"duration-human", "50h0m0s", "2d2h"},
+		{"duration-human", "-1m30s", "-1m30s"},
+		{"duration-human", "1.5s", "1.5s"},
+		{"duration-human", "250ms", "250ms"},
+		{"duration-human", "off", "off"},
+		{"mask", "s3cr3t-t0k3n", "********0k3n"},
+		{"mask", "abc", "***"},
+		{"unknown", "value", "value"},
+		{"", "value", "value"},
+	}
+
+	for _, test := range tests {
+		flag := &Flag{Display: test.display}
+		assert.Equal(t, test.expected, flag.DisplayValue(test.value), "%s(%s)", test.display, test.value)
+	}
+}
+
+func TestDisplayTag(t *testing.T) {
+	type config struct {
+		Config string `long:"config" display:"basename"`
+		Token  string `long:"token" display:"upper"`
+	}
+
+	RegisterDisplay("upper", func(value string) string { return "<" + value + ">" })
+
+	cfg := &config{Config: "/etc/app/config.yaml", Token: "abc"}
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "config.yaml", flags[0].DefValue)
+	assert.Equal(t, "<abc>", flags[1].DefValue)
+	assert.Equal(t, "/etc/app/config.yaml", cfg.Config, "values are not modified")
+
+	changes := Diff(cfg, &config{Config: "/tmp/other.yaml", Token: "abc"})
+	assert.Equal(t, []Change{{Flag: "config", Field: "Config", Old: "config.yaml", New: "other.yaml"}}, changes)
+}
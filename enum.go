@@ -0,0 +1,48 @@
+package sflags
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags/internal/validation"
+)
+
+// RegisterEnum declares the values allowed for a string type T, with optional
+// descriptions. Flags and positionals of this type (eg. type Level string)
+// only accept those values, show the enum name as their type in help, and
+// are completed with the values and their descriptions.
+func RegisterEnum[T ~string](name string, values ...Choice) {
+	validation.RegisterEnum(reflect.TypeOf((*T)(nil)).Elem(), name, values)
+}
+
+// enumValue is the value of a flag of an enum type, named in help.
+type enumValue struct {
+	Value
+	name string
+}
+
+func (v *enumValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+func (v *enumValue) Type() string { return v.name }
+
+func (v *enumValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+
+	return nil
+}
+
+// parseEnum names the value of a field if its type is a registered enum.
+func parseEnum(val Value, value reflect.Value) Value {
+	if name, isEnum := validation.EnumName(value.Type()); isEnum && name != "" {
+		return &enumValue{Value: val, name: name}
+	}
+
+	return val
+}
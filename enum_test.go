@@ -0,0 +1,45 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logLevel string
+
+func init() {
+	RegisterEnum[logLevel]("level",
+		Choice{Value: "debug", Description: "everything"},
+		Choice{Value: "info"},
+		Choice{Value: "error", Description: "only errors"},
+	)
+}
+
+func TestRegisterEnum(t *testing.T) {
+	cfg := &struct {
+		Level    logLevel  `long:"level"`
+		Fallback *logLevel `long:"fallback"`
+	}{Level: "info"}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	assert.Equal(t, "level", flags[0].Value.Type())
+	assert.Equal(t, "info", flags[0].DefValue)
+	assert.Equal(t, "level", flags[1].Value.Type())
+
+	require.NoError(t, flags[0].Value.Set("debug"))
+	assert.Equal(t, logLevel("debug"), cfg.Level)
+	assert.EqualError(t, flags[0].Value.Set("trace"), `invalid choice "trace": must be one of debug, info, error`)
+	assert.Equal(t, logLevel("debug"), cfg.Level)
+
+	require.NoError(t, flags[1].Value.Set("error"))
+	require.NotNil(t, cfg.Fallback)
+	assert.Equal(t, logLevel("error"), *cfg.Fallback)
+
+	cfg.Level = "warn"
+	assert.ErrorContains(t, Validate(cfg), `invalid choice "warn"`)
+}
@@ -3,6 +3,9 @@ package sflags
 import (
 	"errors"
 	"fmt"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/scan"
 )
 
 var (
@@ -27,8 +30,39 @@ var (
 	// ErrShortNameTooLong indicates that a short flag name was specified,
 	// longer than one character.
 	ErrShortNameTooLong = errors.New("short names can only be 1 character long")
+
+	// ErrRequired indicates that a conditionally required field was not set.
+	ErrRequired = errors.New("required flag")
+
+	// ErrInvalidValue indicates a field value refused by its validators or choices.
+	ErrInvalidValue = errors.New("invalid value")
+
+	// ErrUnknownFlag indicates that no flag of a parsed struct has a given name.
+	ErrUnknownFlag = errors.New("unknown flag")
+
+	// ErrUntagged indicates an exported field without tags, when all of them
+	// must be either tagged as options or explicitly excluded (see RequireTags).
+	ErrUntagged = errors.New("untagged field")
+
+	// ErrUnknownGenerator indicates that no generator is registered with a given
+	// name, as the package of the generator is not imported (see Generate).
+	ErrUnknownGenerator = errors.New("unknown generator")
 )
 
+// ConvertError is returned when a word cannot be converted to the type of a field,
+// either by the Set method of the flag values, or when parsing positional arguments.
+// Note that pflag-based generators flatten the errors of flag values into strings.
+type ConvertError = convert.ConvertError
+
+// PanicError is returned when scanning a struct panics, like when setting an
+// unexported embedded struct: it names the struct type and the path of the field.
+type PanicError = scan.PanicError
+
+// Errors is a list of errors returned by the generators when several flags or
+// positional arguments are invalid, so that they are all reported at once.
+// errors.Is and errors.As match any of the errors in the list.
+type Errors = convert.Errors
+
 // simple wrapper for errors.
 func newError(err error, msg string) error {
 	return fmt.Errorf("%w: %s", err, msg)
@@ -13,7 +13,13 @@ func main() {
 	//
 
 	rootData := &Command{}
-	rootCmd := gcobra.Parse(rootData)
+	rootCmd, err := gcobra.ParseE(rootData)
+	if err != nil {
+		fmt.Println(err)
+
+		return
+	}
+
 	rootCmd.SilenceUsage = true
 	rootCmd.Short = "A local command demonstrating a few reflags features"
 	rootCmd.Long = "A longer help string used in detail help/usage output"
@@ -0,0 +1,39 @@
+package sflags
+
+import (
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// expandedValue reads the values given as @path from
+// files, for fields tagged with `expand-file:"true"`.
+type expandedValue struct {
+	Value
+	tag tag.MultiTag
+}
+
+func (v *expandedValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+func (v *expandedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+// Set sets the contents of the file named by the value, if of the form @path.
+func (v *expandedValue) Set(val string) error {
+	expanded, err := convert.ExpandFile(val, v.tag)
+	if err != nil {
+		return err
+	}
+
+	return v.Value.Set(expanded)
+}
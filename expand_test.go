@@ -0,0 +1,46 @@
+package sflags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStruct_ExpandFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("s3cr3t\n"), 0o600))
+
+	cfg := &struct {
+		Token  string   `long:"token" expand-file:"true"`
+		Hosts  []string `long:"hosts" expand-file:"true"`
+		Plain  string   `long:"plain"`
+		Secret string   `long:"secret" expand-file:"true" secret:"true"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 4)
+
+	require.NoError(t, flags[0].Value.Set("@"+path))
+	assert.Equal(t, "s3cr3t", cfg.Token)
+
+	require.NoError(t, flags[0].Value.Set("@@token"))
+	assert.Equal(t, "@token", cfg.Token)
+
+	err = flags[0].Value.Set("@" + path + ".missing")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(path, []byte("a.example.com,b.example.com\n"), 0o600))
+	require.NoError(t, flags[1].Value.Set("@"+path))
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, cfg.Hosts)
+
+	require.NoError(t, flags[2].Value.Set("@"+path))
+	assert.Equal(t, "@"+path, cfg.Plain)
+
+	require.NoError(t, flags[3].Value.Set("@"+path))
+	assert.Equal(t, "a.example.com,b.example.com", cfg.Secret)
+	assert.Equal(t, secretRedacted, flags[3].Value.String())
+}
@@ -0,0 +1,64 @@
+package sflags
+
+// Filter selects some of the flags parsed from a struct, like when generators
+// route the flags of a struct to several flag sets (see FilterFlags).
+type Filter func(flag *Flag) bool
+
+// FilterFlags returns the flags matching all filters.
+func FilterFlags(flags []*Flag, filters ...Filter) []*Flag {
+	if len(filters) == 0 {
+		return flags
+	}
+
+	var filtered []*Flag
+
+flags:
+	for _, flag := range flags {
+		for _, filter := range filters {
+			if !filter(flag) {
+				continue flags
+			}
+		}
+
+		filtered = append(filtered, flag)
+	}
+
+	return filtered
+}
+
+// Where selects the flags whose description matches a predicate.
+func Where(predicate func(info FlagInfo) bool) Filter {
+	return func(flag *Flag) bool { return predicate(flag.Info()) }
+}
+
+// InGroup selects the flags declared in one of the groups of options
+// with the given titles, or the flags without group if none is given.
+func InGroup(titles ...string) Filter {
+	return func(flag *Flag) bool {
+		if len(titles) == 0 {
+			return flag.Group == ""
+		}
+
+		for _, title := range titles {
+			if flag.Group == title {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Tagged selects the flags whose struct fields have a tag (or inherit it
+// from their group), with the given value, or with any value if empty.
+func Tagged(key, value string) Filter {
+	return func(flag *Flag) bool {
+		if flag.tag == nil {
+			return false
+		}
+
+		tagValue, isSet := flag.tag.Get(key)
+
+		return isSet && (value == "" || tagValue == value)
+	}
+}
@@ -0,0 +1,45 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type filterCfg struct {
+	Verbose bool `long:"verbose" scope:"global"`
+	Server  struct {
+		Host string `long:"host"`
+		Port int    `long:"port" scope:"server"`
+	} `group:"server" flag:"server"`
+	Client struct {
+		Timeout int `long:"timeout" hidden:"true"`
+	} `group:"client" flag:"client"`
+}
+
+func TestFilterFlags(t *testing.T) {
+	flags, err := ParseStruct(&filterCfg{})
+	require.NoError(t, err)
+
+	names := func(flags []*Flag) (names []string) {
+		for _, flag := range flags {
+			names = append(names, flag.Name)
+		}
+
+		return names
+	}
+
+	assert.Equal(t, names(flags), names(FilterFlags(flags)))
+	assert.Equal(t, []string{"verbose"}, names(FilterFlags(flags, InGroup())))
+	assert.Equal(t, []string{"server-host", "server-port", "client-timeout"},
+		names(FilterFlags(flags, InGroup("server", "client"))))
+
+	assert.Equal(t, []string{"verbose", "server-port"}, names(FilterFlags(flags, Tagged("scope", ""))))
+	assert.Equal(t, []string{"server-port"}, names(FilterFlags(flags, Tagged("scope", "server"))))
+
+	visible := Where(func(info FlagInfo) bool { return !info.Hidden })
+	assert.Equal(t, []string{"server-port"},
+		names(FilterFlags(flags, visible, InGroup("server", "client"), Tagged("scope", ""))))
+	assert.Equal(t, []string{"verbose", "server-host", "server-port"}, names(FilterFlags(flags, visible)))
+}
@@ -1,6 +1,12 @@
 // Package sflags helps to generate flags by parsing structure
 package sflags
 
+import (
+	"errors"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
 // Flag structure might be used by cli/flag libraries for their flag generation.
 type Flag struct {
 	Name       string // name as it appears on command line
@@ -12,6 +18,19 @@ type Flag struct {
 	Hidden     bool
 	Deprecated bool
 
+	// If non empty, the message printed when a deprecated flag is
+	// used, like "use --new-flag instead". It is set along with
+	// Deprecated by the `deprecated:"message"` tag.
+	DeprecatedMsg string
+
+	// If true, the option is also available to the subcommands of its
+	// command, for generators supporting it. Set by the `persistent` tag.
+	Persistent bool
+
+	// If true, the value of the option is sensitive, and frontends
+	// (forms, prompts, help) should avoid displaying it in clear.
+	Secret bool
+
 	// If true, the option _must_ be specified on the command line. If the
 	// option is not specified, the parser will generate an ErrRequired type
 	// error.
@@ -20,6 +39,36 @@ type Flag struct {
 	// If non empty, only a certain set of values is allowed for an option.
 	Choices []string
 
+	// If non empty, the name of a group of mutually exclusive options:
+	// only one of them can be set at once. Set by the `xor` tag.
+	Exclusive string
+
+	// If non empty, the name of a group of options which must be set
+	// together: if one of them is, all of them must be. Set by the
+	// `required-with` tag.
+	RequiredWith string
+
+	// If non empty, an example of value for the option (eg. "10s"), shown
+	// in help and suggested in completions. Set by the `example-value` tag.
+	Example string
+
+	// If non empty, the name of the function transforming the values of
+	// the option when they are displayed (see RegisterDisplay), like in
+	// help output. Set by the `display` tag.
+	Display string
+
+	// If non empty, the title of the group of options declaring the
+	// option, as given by the `group` or `options` tag of the group.
+	Group string
+
+	// The validators checking the values of the option, as given by its
+	// `validate` tag (eg. "min=1"), possibly inherited from its group.
+	Validators []string
+
+	// The tags of the struct field of the option, including
+	// those inherited from its group, used to filter flags.
+	tag *tag.MultiTag
+
 	// The optional value of the option. The optional value is used when
 	// the option flag is marked as having an OptionalArgument. This means
 	// that when the flag is specified, but no option argument is given,
@@ -27,3 +76,49 @@ type Flag struct {
 	// OptionalValue. This is only valid for non-boolean options.
 	OptionalValue []string
 }
+
+// HelpUsage returns the help message of the flag, followed by its example
+// value if any, as generators should show it in help output.
+func (f *Flag) HelpUsage() string {
+	if f.Example == "" {
+		return f.Usage
+	}
+
+	if f.Usage == "" {
+		return "(e.g. " + f.Example + ")"
+	}
+
+	return f.Usage + " (e.g. " + f.Example + ")"
+}
+
+// Set sets the value of the flag, like when given on the command line, but returns
+// its error even when the errors of the flag are collected (see CollectErrors).
+func (f *Flag) Set(value string) error {
+	collected := collectedBy(f.Value)
+	if collected == nil {
+		return f.Value.Set(value)
+	}
+
+	count := len(*collected.errs)
+	if err := f.Value.Set(value); err != nil {
+		return err
+	}
+
+	if len(*collected.errs) == count {
+		return nil
+	}
+
+	err := (*collected.errs)[count]
+	*collected.errs = (*collected.errs)[:count]
+
+	return errors.Unwrap(err)
+}
+
+// flagName returns the long name of a flag, or its short name if it has none.
+func flagName(flag *Flag) string {
+	if flag.Name != "" {
+		return flag.Name
+	}
+
+	return flag.Short
+}
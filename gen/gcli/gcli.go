@@ -7,17 +7,19 @@ import (
 
 // GenerateTo takes a list of sflag.Flag,
 // that are parsed from some config structure, and put it to dst.
+// Flags are built from their description (see sflags.Flag.Info).
 func GenerateTo(src []*sflags.Flag, dst *[]cli.Flag) {
 	for _, srcFlag := range src {
-		name := srcFlag.Name
-		if srcFlag.Short != "" {
-			name += ", " + srcFlag.Short
+		info := srcFlag.Info()
+		name := info.Name
+		if info.Short != "" {
+			name += ", " + info.Short
 		}
 		*dst = append(*dst, &cli.GenericFlag{
 			Name:   name,
-			EnvVar: srcFlag.EnvName,
-			Hidden: srcFlag.Hidden,
-			Usage:  srcFlag.Usage,
+			EnvVar: info.EnvName,
+			Hidden: info.Hidden,
+			Usage:  info.Usage,
 			Value:  srcFlag.Value,
 		})
 	}
@@ -34,6 +36,13 @@ func ParseTo(cfg interface{}, dst *[]cli.Flag, optFuncs ...sflags.OptFunc) error
 	return nil
 }
 
+// The generator is registered as "urfave", for sflags.Generate.
+func init() {
+	sflags.RegisterGenerator("urfave", func(data interface{}, optFuncs ...sflags.OptFunc) (interface{}, error) {
+		return Parse(data, optFuncs...)
+	})
+}
+
 // Parse parses cfg, that is a pointer to some structure,
 // puts it to the new flag.FlagSet and returns it.
 func Parse(cfg interface{}, optFuncs ...sflags.OptFunc) ([]cli.Flag, error) {
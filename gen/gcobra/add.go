@@ -0,0 +1,61 @@
+package gcobra
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/scan"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// AddCommand adds a subcommand built from data, a pointer to a command struct,
+// to a command of an existing tree, like when loading plugins or commands
+// received from a server. Its tags, flags and subcommands are scanned as they
+// would be by Parse, and any error doing so is returned: the command is then
+// not added. Use gcomp.AddCommand to also generate the completions of the command.
+// The settings of the tree applying to subcommands (WithStrictArgs, etc) are
+// inherited, but not those given as options only (WithTracer, WithProfiles),
+// which must be given again in opts.
+func AddCommand(parent *cobra.Command, data interface{}, name string, opts ...Option) (err error) {
+	defer scan.Catch(data, &err)
+
+	if parent == nil || data == nil {
+		return ErrObjectIsNil
+	}
+
+	val := reflect.ValueOf(data)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return ErrNotPointerToStruct
+	}
+
+	impl, isCmd := data.(sflags.Commander)
+	if !isCmd {
+		return ErrNotCommander
+	}
+
+	for _, sub := range parent.Commands() {
+		if sub.Name() == name || sub.HasAlias(name) {
+			return newError(ErrCommandExists, name)
+		}
+	}
+
+	mtag := tag.NewMultiTag(fmt.Sprintf("command:%q", name))
+	if err := mtag.Parse(); err != nil {
+		return err
+	}
+
+	settings := newOptions(opts...)
+
+	subc, err := subcommand(parent, &settings, name, mtag, nil, val, impl)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	addCommand(parent, subc, &settings)
+	customize(subc, data)
+
+	return nil
+}
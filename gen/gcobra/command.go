@@ -1,59 +1,196 @@
 package gcobra
 
 import (
-	"os"
+	"context"
+	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/octago/sflags"
+	"github.com/octago/sflags/gen/gpflag"
+	"github.com/octago/sflags/internal/platform"
 	"github.com/octago/sflags/internal/scan"
 	"github.com/octago/sflags/internal/tag"
 )
 
+// The generator is registered as "cobra", for sflags.Generate:
+// the sflags options are not used, as commands have their own.
+func init() {
+	sflags.RegisterGenerator("cobra", func(data interface{}, _ ...sflags.OptFunc) (interface{}, error) {
+		return ParseE(data)
+	})
+}
+
 // Parse returns a root cobra Command to be used directly as an entry-point.
 // The data interface parameter can be nil, or arbitrarily:
 // - A simple group of options to bind at the local, root level
 // - A struct containing substructs for postional parameters, and other with options.
-func Parse(data interface{}) *cobra.Command {
+// Options configure the whole tree of commands (see Option).
+// It returns nil if data cannot be scanned: use ParseE to get the error.
+func Parse(data interface{}, opts ...Option) *cobra.Command {
+	cmd, err := ParseE(data, opts...)
+	if err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// ParseE is like Parse, but returns the error of the scan of data (an invalid
+// tag, a subcommand not implementing sflags.Commander, etc) instead of a nil
+// command. Errors of subcommand branches are only returned when executing them.
+func ParseE(data interface{}, opts ...Option) (*cobra.Command, error) {
+	settings := newOptions(opts...)
+	if settings.version == "" {
+		settings.version = versionTag(data)
+	}
+
 	// The command is empty, so that the returned command can be
 	// directly ran as a root application command, with calls like
 	// cmd.Execute(), or cobra.CheckErr(cmd.Execute())
 	cmd := &cobra.Command{
-		Use:         os.Args[0], // By default, the command is the name of the binary. TODO: change this
-		Annotations: map[string]string{},
+		Use:          settings.name, // By default, the command is the name of the binary.
+		Version:      settings.version,
+		SilenceUsage: settings.silence,
+		Annotations:  map[string]string{},
+
+		SuggestionsMinimumDistance: settings.suggestions,
+	}
+
+	// Commands might be executed on fresh instances of their structs.
+	if settings.fresh {
+		cmd.Annotations[freshAnnotation] = "true"
+	}
+
+	// Commands might refuse leftover arguments,
+	// or stop parsing flags at their first argument.
+	if settings.strictArgs {
+		cmd.Annotations[strictArgsAnnotation] = "true"
+	}
+
+	if settings.posix {
+		cmd.Annotations[posixAnnotation] = "true"
+	}
+
+	// And their executions might be recovered from panics.
+	if settings.crashes {
+		cmd.Annotations[crashAnnotation] = settings.crashDir
 	}
 
 	// A command always accepts embedded
 	// subcommand struct fields, so scan them.
-	scanner := scanCommand(cmd, nil)
+	scanned := &commandScan{settings: &settings}
+	scanner := scanCommand(cmd, scanned, nil, data)
+
+	// Dynamic defaults must be set before flags are generated.
+	sflags.ApplyDefaults(reflect.ValueOf(data))
 
 	// Scan the struct recursively, for both
 	// arg/option groups and subcommands
-	if err := scan.Type(data, scanner); err != nil {
-		return nil
+	if data != nil {
+		if settings.strict {
+			if err := sflags.CheckTags(data); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := scan.Type(data, scanner); err != nil {
+			return nil, err
+		}
 	}
 
+	// Leftover words might be bound to a field or refused,
+	// and those after a double dash passed through verbatim.
+	setRemaining(cmd, scanned)
+	setStrictArgs(cmd, scanned)
+	setPassthrough(cmd, scanned)
+	setPosix(cmd)
+
+	// Its flags might be given the defaults of a profile.
+	setProfiles(cmd, settings.profiles)
+
 	// NOTE: should handle remote exec here
 
 	// Sane defaults for working both in CLI and in closed-loop applications.
 	cmd.TraverseChildren = true
 
+	// Runtimes like js/wasm might start the program without any argument,
+	// which cobra would index: executions then default to no arguments.
+	if !platform.HasArgs() {
+		cmd.SetArgs([]string{})
+	}
+
+	// Values of secret flags never appear in errors, and
+	// all invalid flags are reported along with parsing errors.
+	cmd.SetFlagErrorFunc(flagError(settings.tracer))
+
+	// Long help might be paged, and links to docs added, for all commands.
+	setHelp(cmd, settings)
+
+	// Their subcommands might be listed as declared, rather than sorted.
+	setCommandOrder(cmd, settings)
+
 	// Subcommands optional or not
 	if cmd.HasSubCommands() {
 		cmd.RunE = func(cmd *cobra.Command, args []string) error {
-			return nil
+			return collectedErrors(cmd, unknownCommand(cmd, args))
 		}
-	} else if _, isCmd, impl := sflags.IsCommand(reflect.ValueOf(data)); isCmd {
-		setRuns(cmd, impl)
+	} else if impl, isCmd := data.(sflags.Commander); isCmd {
+		setRuns(cmd, impl, scanned)
+	} else {
+		cmd.RunE = helpRun
 	}
 
-	return cmd
+	// Or one of them might be run when none is given.
+	setDefaultCommand(cmd, data)
+
+	// The root might run some setup before any command.
+	setPersistentRuns(cmd, data, &settings)
+
+	// The version and build metadata might be printed by a command.
+	if settings.versionCmd {
+		addVersionCommand(cmd, settings.buildInfo)
+	}
+
+	// Groups of commands might be titled and ordered programmatically.
+	setGroups(cmd, settings.groups)
+
+	// The root struct might set what sflags does not.
+	customize(cmd, data)
+
+	// The flags and arguments of the tree might be completed.
+	if settings.completions != nil {
+		if err := settings.completions.Complete(cmd, data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Once all commands are set, choose what they print on errors.
+	setUsagePolicy(cmd, settings.usage)
+
+	return cmd, nil
+}
+
+// commandScan holds what the scan of a command finds for its arguments,
+// bound once all of its fields (and those of its groups) are scanned, and
+// the settings of its tree given to its subcommands.
+type commandScan struct {
+	settings    *options      // The settings of the tree.
+	remaining   reflect.Value // Receives the words left by its positionals.
+	passthrough reflect.Value // Receives the words after a double dash.
+
+	// args are the words left by the last parsing of the command.
+	args []string
 }
 
 // scan is in charge of building a recursive scanner, working on a
 // given struct field at a time, checking for arguments, subcommands and option groups.
-func scanCommand(cmd *cobra.Command, group *cobra.Group) scan.Handler {
+// The data is the struct being scanned, to which positionals have read access.
+func scanCommand(cmd *cobra.Command, scanned *commandScan, group *cobra.Group, data interface{}) scan.Handler {
+	scanned.settings.scans[cmd] = scanned
+
 	handler := func(val reflect.Value, sfield *reflect.StructField) (bool, error) {
 		// Parse the tag or die tryin. We should find one, or we're not interested.
 		mtag, none, err := tag.GetFieldTag(*sfield)
@@ -63,36 +200,51 @@ func scanCommand(cmd *cobra.Command, group *cobra.Group) scan.Handler {
 
 		// If the field is marked as -one or more- positional arguments, we
 		// return either on a successful scan of them, or with an error doing so.
-		if found, err := positionals(cmd, mtag, val); found || err != nil {
+		if found, err := positionals(cmd, scanned, mtag, val, data); found || err != nil {
+			return found, err
+		}
+
+		// Or it might receive the arguments left by the positionals and flags,
+		if found, err := remaining(scanned, mtag, val); found || err != nil {
+			return found, err
+		}
+
+		// or those given after a double dash.
+		if found, err := passthrough(scanned, mtag, val); found || err != nil {
 			return found, err
 		}
 
 		// Else, if the field is marked as a subcommand, we either return on
 		// a successful scan of the subcommand, or with an error doing so.
-		if found, err := command(cmd, group, mtag, val); found || err != nil {
+		if found, err := command(cmd, scanned, group, mtag, val); found || err != nil {
 			return found, err
 		}
 
 		// Else, if the field is a struct group of options
-		if found, err := flagsGroup(cmd, val, sfield); found || err != nil {
+		if found, err := flagsGroup(cmd, scanned, group, val, sfield, data); found || err != nil {
 			return found, err
 		}
 
 		// Else, try scanning the field as a simple option flag
-		return flagScan(cmd)(val, sfield)
+		return flagScan(cmd, scanned, data)(val, sfield)
 	}
 
 	return handler
 }
 
 // command finds if a field is marked as a subcommand, and if yes, scans it.
-func command(cmd *cobra.Command, grp *cobra.Group, tag tag.MultiTag, val reflect.Value) (bool, error) {
+func command(cmd *cobra.Command, scanned *commandScan, grp *cobra.Group, tag tag.MultiTag, val reflect.Value) (bool, error) {
 	// Parse the command name on struct tag...
 	name, _ := tag.Get("command")
 	if len(name) == 0 {
 		return false, nil
 	}
 
+	// Commands declared as interfaces might not be implemented.
+	if val.Kind() == reflect.Interface && val.IsNil() {
+		return true, nil
+	}
+
 	// ... and check the field implements at least the Commander interface
 	val, implements, cmdType := sflags.IsCommand(val)
 	if !implements && len(name) != 0 && cmdType == nil {
@@ -101,18 +253,11 @@ func command(cmd *cobra.Command, grp *cobra.Group, tag tag.MultiTag, val reflect
 		return false, nil // Skip to next field
 	}
 
-	// Always populate the maximum amount of information
-	// in the new subcommand, so that when it scans recursively,
-	// we can have a more granular context.
-	subc := newCommand(name, tag, grp)
-
-	// Bind the various pre/run/post implementations of our command.
-	setRuns(subc, cmdType)
-
-	// Scan the struct recursively, for both arg/option groups and subcommands
-	scanner := scanCommand(subc, grp)
-	if err := scan.Type(val.Interface(), scanner); err != nil {
-		return true, err
+	// An invalid branch of commands only fails when one of its commands is
+	// executed, so that it does not prevent unrelated commands from running.
+	subc, err := subcommand(cmd, scanned.settings, name, tag, grp, val, cmdType)
+	if err != nil {
+		failRuns(subc, fmt.Errorf("%s: %w", name, err))
 	}
 
 	// If we have more than one subcommands and that we are NOT
@@ -120,16 +265,81 @@ func command(cmd *cobra.Command, grp *cobra.Group, tag tag.MultiTag, val reflect
 	// function, so that help printing can behave accordingly.
 	if _, isSet := tag.Get("subcommands-optional"); !isSet {
 		if len(subc.Commands()) > 0 {
-			cmd.RunE = nil
+			cmd.PreRunE = nil
+			cmd.RunE = helpRun
 		}
 	}
 
-	// And bind this subcommand back to us
-	cmd.AddCommand(subc)
+	// And bind this subcommand back to us, in its group if any.
+	addCommand(cmd, subc, scanned.settings)
+
+	if subc.Group != "" {
+		addGroup(cmd, &cobra.Group{Group: subc.Group})
+	}
+
+	// The command struct might set what sflags does not,
+	// unless its branch has failed and must only return the error.
+	if err == nil {
+		customize(subc, val.Interface())
+	}
 
 	return true, nil
 }
 
+// subcommand builds a command from its struct, returning the error of its scan.
+// The command is not added to its parent, from which it inherits some settings,
+// the others being those of its tree (its tracer, etc).
+func subcommand(parent *cobra.Command, settings *options, name string, mtag tag.MultiTag, grp *cobra.Group, val reflect.Value, impl sflags.Commander) (*cobra.Command, error) {
+	// Always populate the maximum amount of information
+	// in the new subcommand, so that when it scans recursively,
+	// we can have a more granular context.
+	subc := newCommand(name, mtag, grp)
+	inheritAnnotations(parent, subc)
+
+	// Bind the various pre/run/post implementations of our command.
+	scanned := &commandScan{settings: settings}
+	setRuns(subc, impl, scanned)
+	setPersistentRuns(subc, val.Interface(), settings)
+
+	// Dynamic defaults must be set before flags are generated.
+	sflags.ApplyDefaults(val)
+
+	// Scan the struct recursively, for both arg/option groups and subcommands.
+	scanner := scanCommand(subc, scanned, grp, val.Interface())
+	if err := scan.Type(val.Interface(), scanner); err != nil {
+		return subc, err
+	}
+
+	// Leftover words might be bound to a field or refused,
+	// and those after a double dash passed through verbatim.
+	setRemaining(subc, scanned)
+	setStrictArgs(subc, scanned)
+	setPassthrough(subc, scanned)
+	setPosix(subc)
+
+	// Its flags might be given the defaults of a profile.
+	setProfiles(subc, settings.profiles)
+
+	// One of the subcommands might be run when none is given.
+	setDefaultCommand(subc, val.Interface())
+
+	return subc, nil
+}
+
+// inheritAnnotations gives a command the settings of its parent
+// applying to its subcommands as well (strict arguments, etc).
+func inheritAnnotations(parent, cmd *cobra.Command) {
+	if parent == nil {
+		return
+	}
+
+	for _, annotation := range []string{strictArgsAnnotation, posixAnnotation} {
+		if parent.Annotations[annotation] != "" {
+			cmd.Annotations[annotation] = "true"
+		}
+	}
+}
+
 // builds a quick command template based on what has been specified through tags, and in context.
 func newCommand(name string, mtag tag.MultiTag, parent *cobra.Group) *cobra.Command {
 	subc := &cobra.Command{
@@ -140,8 +350,48 @@ func newCommand(name string, mtag tag.MultiTag, parent *cobra.Group) *cobra.Comm
 	subc.Short, _ = mtag.Get("description")
 	subc.Long, _ = mtag.Get("long-description")
 	subc.Aliases = mtag.GetMany("alias")
+	subc.SuggestFor = mtag.GetMany("suggest-for")
 	_, subc.Hidden = mtag.Get("hidden")
 
+	// Metadata for tools (doc generators, telemetry, etc) is kept as is,
+	// although the annotations set by sflags below take precedence.
+	for key, value := range tag.Annotations(mtag) {
+		subc.Annotations[key] = value
+	}
+
+	// The command might be run when its parent is invoked alone.
+	if isDefault, _ := mtag.Get("default"); !isStringFalsy(isDefault) {
+		subc.Annotations[defaultAnnotation] = "true"
+	}
+
+	// The command might refuse leftover arguments, like its subcommands.
+	if strict, _ := mtag.Get("strict-args"); !isStringFalsy(strict) {
+		subc.Annotations[strictArgsAnnotation] = "true"
+	}
+
+	// Or stop parsing flags at its first argument, like its subcommands.
+	if posix, _ := mtag.Get("posix"); !isStringFalsy(posix) {
+		subc.Annotations[posixAnnotation] = "true"
+	}
+
+	// Its mistyped subcommands and flags might be suggested from further away.
+	if distance, isSet := mtag.Get("suggestions-distance"); isSet {
+		subc.SuggestionsMinimumDistance, _ = strconv.Atoi(distance)
+	}
+
+	// The documentation of the command is linked at the end of its help.
+	if url, isSet := mtag.Get("docs-url"); isSet {
+		subc.Annotations[docsAnnotation] = url
+	}
+
+	// Deprecated commands print their message when used.
+	if msg, deprecated := tag.Deprecation(mtag); deprecated {
+		subc.Deprecated = msg
+		if subc.Deprecated == "" {
+			subc.Deprecated = "it will be removed in a future version"
+		}
+	}
+
 	// Grouping the command ----------
 
 	// - Either inherited from the group within which we are parsed.
@@ -154,26 +404,168 @@ func newCommand(name string, mtag tag.MultiTag, parent *cobra.Group) *cobra.Comm
 		subc.Group = group
 	}
 
-	// TODO: here inherit from struct marked group, with commands and options.
-
 	// TODO: namespace tags on commands ?
 
 	return subc
 }
 
-// setRuns binds the various pre/run/post implementations to a cobra command.
-func setRuns(cmd *cobra.Command, impl sflags.Commander) {
+// setRuns binds the various pre/run/post implementations to a cobra command,
+// given the words left by its parsing, and traced by the tracer of its tree.
+func setRuns(cmd *cobra.Command, impl sflags.Commander, scanned *commandScan) {
 	// No implementation means that this command
 	// requires subcommands by default.
 	if impl == nil {
 		return
 	}
 
+	// The command to execute, built by the pre-run,
+	// and the span of its execution, with its context.
+	var (
+		run  sflags.Commander
+		ctx  context.Context
+		span Span
+	)
+
+	// Pre-run: check the parsed values and build the command.
+	cmd.PreRunE = func(c *cobra.Command, args []string) (err error) {
+		ctx, span = startSpan(c, scanned.settings.tracer)
+		span.Stage(StageValidate)
+
+		run, err = prepare(c, impl)
+
+		if preRunner, ok := run.(sflags.PreRunner); ok && err == nil {
+			err = preRunner.PreRun(scanned.args)
+		}
+
+		if err != nil {
+			span.End(err)
+		}
+
+		// The parsed struct might be left untouched by the execution.
+		if err != nil && c.Root().Annotations[freshAnnotation] != "" {
+			sflags.Reset(impl, commandFlags(c))
+		}
+
+		return err
+	}
+
 	// Main run
 	cmd.RunE = func(c *cobra.Command, args []string) error {
-		retargs := getRemainingArgs(c)
+		retargs := scanned.args
 		cmd.SetArgs(retargs)
 
-		return impl.Execute(retargs)
+		if c.Root().Annotations[freshAnnotation] != "" {
+			defer sflags.Reset(impl, commandFlags(c))
+		}
+
+		span.Stage(StageExecute)
+		err := guard(c, func() error { return execute(ctx, run, retargs) })
+
+		// Cobra skips post-runs when the run fails, so call it here.
+		if postRunner, ok := run.(sflags.PostRunner); ok {
+			postRunner.PostRun(err)
+		}
+
+		span.End(err)
+
+		return err
+	}
+}
+
+// execute runs a command, with the context of its cobra command (or of its
+// parents, see startSpan) if any and if it implements sflags.ContextCommander.
+func execute(ctx context.Context, run sflags.Commander, args []string) error {
+	ctxRunner, ok := run.(sflags.ContextCommander)
+	if !ok || ctx == nil {
+		return run.Execute(args)
+	}
+
+	return ctxRunner.ExecuteContext(ctx, args)
+}
+
+// setPersistentRuns binds the persistent pre-run of a command
+// struct, if it implements sflags.PersistentPreRunner, given the
+// words left by the command executed, found in the tree settings.
+func setPersistentRuns(cmd *cobra.Command, data interface{}, settings *options) {
+	runner, ok := data.(sflags.PersistentPreRunner)
+	if !ok {
+		return
+	}
+
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		// Cobra only runs the closest persistent pre-run, so chain those of parents.
+		if err := parentPreRuns(cmd.Parent(), c, args); err != nil {
+			return err
+		}
+
+		return runner.PersistentPreRun(settings.argsOf(c))
+	}
+}
+
+// parentPreRuns runs the closest persistent pre-run of a command and its parents.
+func parentPreRuns(parent, cmd *cobra.Command, args []string) error {
+	for ; parent != nil; parent = parent.Parent() {
+		if parent.PersistentPreRunE != nil {
+			return parent.PersistentPreRunE(cmd, args)
+		}
+
+		if parent.PersistentPreRun != nil {
+			parent.PersistentPreRun(cmd, args)
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// prepare checks the values parsed onto a command struct, and returns the
+// command to execute: a fresh instance of the struct if the command tree
+// requires it, or one built by its constructor, given its dependencies.
+func prepare(c *cobra.Command, impl sflags.Commander) (sflags.Commander, error) {
+	parsed := impl
+	if c.Root().Annotations[freshAnnotation] != "" {
+		parsed = freshInstance(impl)
+	}
+
+	// Report all invalid flags at once, if not done with the arguments.
+	if err := collectedErrors(c, nil); err != nil {
+		return nil, err
+	}
+
+	// Some flags cannot be used together, and some must be.
+	if err := gpflag.CheckGroups(c.Flags()); err != nil {
+		return nil, err
+	}
+
+	// Fields might be required depending on other parsed values.
+	if err := sflags.CheckRequired(parsed); err != nil {
+		return nil, err
+	}
+
+	// Build the command with its dependencies, if it has a constructor.
+	run, err := construct(c, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject any session-scoped dependencies.
+	setSession(c, run)
+
+	return run, nil
+}
+
+// failRuns makes a command, and all of its subcommands, return an error when
+// executed, without parsing their flags and arguments: their scan has failed.
+func failRuns(cmd *cobra.Command, err error) {
+	cmd.DisableFlagParsing = true
+	cmd.Args = cobra.ArbitraryArgs
+	cmd.PreRunE = nil
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		return err
+	}
+
+	for _, subc := range cmd.Commands() {
+		failRuns(subc, err)
 	}
 }
@@ -1,9 +1,21 @@
 package gcobra
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/octago/sflags"
 )
 
 // Test only partially ported from github.com/jessevdk/go-flags, since we are
@@ -74,6 +86,209 @@ func TestParseCommand(t *testing.T) {
 	// test.NotNil(cmd, "The command parser should have returned a command")
 }
 
+// TestParseE checks that scan errors are returned to the caller.
+func TestParseE(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	invalid := struct {
+		Command struct{} `command:"invalid"`
+	}{}
+
+	cmd, err := ParseE(&invalid)
+	test.Nil(cmd)
+	test.ErrorIs(err, ErrNotCommander)
+	test.Nil(Parse(&invalid))
+
+	_, err = ParseE(invalid)
+	test.EqualError(err, "object must be a pointer to struct or interface")
+
+	cmd, err = ParseE(nil, WithName("app"))
+	test.Nil(err)
+	test.Equal("app", cmd.Name())
+}
+
+// TestParseOptions checks the settings of the root command given as options.
+func TestParseOptions(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command testCommand `command:"cmd"`
+	}{}
+
+	root := Parse(&opts)
+	test.Equal(filepath.Base(os.Args[0]), root.Use)
+	test.False(root.SilenceUsage)
+
+	root = Parse(&opts, WithName("app"), WithVersion("1.2.3"), WithSilenceUsage())
+	test.True(root.SilenceUsage)
+
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetArgs([]string{"--version"})
+	test.Nil(root.Execute())
+	test.Equal("app version 1.2.3\n", out.String())
+}
+
+// TestParseCommandSorting checks that subcommands are listed in the help
+// as declared when sorting is disabled, without affecting other trees.
+func TestParseCommandSorting(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Zeta  testCommand `command:"zeta" description:"last by name"`
+		Alpha testCommand `command:"alpha" description:"first by name"`
+	}{}
+
+	help := func(settings ...Option) string {
+		out := &bytes.Buffer{}
+		root := Parse(&opts, settings...)
+		root.SetOut(out)
+		root.SetArgs([]string{"--help"})
+		test.Nil(root.Execute())
+
+		return out.String()
+	}
+
+	declared := help(WithName("app"), WithCommandSorting(false))
+	test.Less(strings.Index(declared, "zeta"), strings.Index(declared, "alpha"))
+	test.Less(strings.Index(declared, "alpha"), strings.Index(declared, "help"))
+
+	sorted := help(WithName("app"))
+	test.Less(strings.Index(sorted, "alpha"), strings.Index(sorted, "zeta"))
+	test.True(cobra.EnableCommandSorting)
+}
+
+// TestParseVersion checks the version declared by a tag, and the version command.
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		_       struct{}    `version:"0.9.0"`
+		Command testCommand `command:"cmd"`
+	}{}
+
+	root := Parse(&opts, WithName("app"), WithVersionCommand(map[string]string{
+		"commit": "abc123",
+		"date":   "2024-01-02",
+	}))
+	test.Equal("0.9.0", root.Version)
+
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetArgs([]string{"version"})
+	test.Nil(root.Execute())
+	test.Equal("app version 0.9.0\ncommit: abc123\ndate: 2024-01-02\n", out.String())
+
+	root = Parse(&opts, WithVersion("1.0.0"))
+	test.Equal("1.0.0", root.Version)
+	test.False(hasVersionCommand(root))
+}
+
+// customCommand sets fields of its cobra command not modeled by sflags.
+type customCommand struct {
+	testCommand
+}
+
+func (c *customCommand) Customize(cmd *cobra.Command) {
+	cmd.SuggestFor = []string{"kustom"}
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.Short = "parent: " + cmd.Parent().Use
+}
+
+// customRoot customizes the root command.
+type customRoot struct {
+	Custom customCommand `command:"custom" description:"overridden"`
+}
+
+func (r *customRoot) Customize(cmd *cobra.Command) {
+	cmd.SilenceErrors = true
+}
+
+// TestCommandCustomizer checks that commands can customize their cobra command.
+func TestCommandCustomizer(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	root := Parse(&customRoot{}, WithName("app"), WithUsagePolicy(ErrorWithHint))
+	test.True(root.SilenceErrors)
+
+	cmd, _, err := root.Find([]string{"custom"})
+	test.Nil(err)
+	test.Equal([]string{"kustom"}, cmd.SuggestFor)
+	test.Equal("parent: app", cmd.Short)
+
+	// Custom argument validators are used, and still hinted.
+	root.SetArgs([]string{"custom"})
+	err = root.Execute()
+	test.ErrorContains(err, "accepts 1 arg(s), received 0")
+	test.ErrorContains(err, "See 'app custom --help'.")
+}
+
+// panicOptions is an unexported group of options, which cannot be scanned.
+type panicOptions struct {
+	Port int `long:"port"`
+}
+
+// TestParsePanic checks that panics raised while scanning are returned as errors.
+func TestParsePanic(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command      testCommand `command:"cmd"`
+		panicOptions `group:"server"`
+	}{}
+
+	root, err := ParseE(&opts)
+	test.Nil(root)
+
+	var perr *sflags.PanicError
+	test.ErrorAs(err, &perr)
+	test.Equal([]string{"panicOptions"}, perr.Path)
+}
+
+// TestParseRequiredTags checks that untagged fields fail the scan, when required.
+func TestParseRequiredTags(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command testCommand `command:"cmd"`
+		Cache   map[string]string
+		Skipped string `flag:"-"`
+	}{}
+
+	_, err := ParseE(&opts)
+	test.Nil(err)
+
+	root, err := ParseE(&opts, WithRequiredTags())
+	test.Nil(root)
+	test.ErrorIs(err, sflags.ErrUntagged)
+	test.ErrorContains(err, "Cache")
+	test.NotContains(err.Error(), "Skipped")
+}
+
+func hasVersionCommand(root *cobra.Command) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == "version" {
+			return true
+		}
+	}
+
+	return false
+}
+
 // TestCommandInline checks that a command embedded in a struct
 // will correctly get detected and initialized at exec time.
 func TestCommandInline(t *testing.T) {
@@ -219,6 +434,34 @@ func TestCommandFlagPersistentFail(t *testing.T) {
 	pt.ErrorContains(err, "unknown shorthand flag: 'p' in -p")
 }
 
+// TestCommandFlagInheritedTags checks that options inherit the tags of
+// their groups (like persistent or hidden), unless they have their own.
+func TestCommandFlagInheritedTags(t *testing.T) {
+	t.Parallel()
+
+	cmdData := struct {
+		Opts struct {
+			Value bool `short:"v" long:"version"`
+			Local bool `long:"local" persistent:"false"`
+		} `group:"options" persistent:"true" hidden:"true"`
+
+		Command testCommand `command:"cmd"`
+	}{}
+
+	root := newCommandWithArgs(&cmdData, []string{"cmd", "-v"})
+	_, err := root.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.True(cmdData.Opts.Value)
+
+	pt.NotNil(root.PersistentFlags().Lookup("version"))
+	pt.True(root.PersistentFlags().Lookup("version").Hidden)
+	pt.Nil(root.PersistentFlags().Lookup("local"))
+	pt.NotNil(root.LocalNonPersistentFlags().Lookup("local"))
+	pt.True(root.Flags().Lookup("local").Hidden)
+}
+
 // TestCommandFlagOverrideParent checks that when child commands declare
 // one or more flags that are named identically to some parents', the words
 // passed in will indeed parse their values on those childs' flags, not the
@@ -313,3 +556,1604 @@ func TestSubcommandsOptional(t *testing.T) {
 func TestCommandPassAfterNonOptionWithPositional(t *testing.T) {
 	t.Log("TODO: TestCommandPassAfterNonOptionWithPositional not written")
 }
+
+//
+// Session injection ----------------------------------------------------------- //
+//
+
+// sessionCommand records the session value injected before execution.
+type sessionCommand struct {
+	session interface{}
+}
+
+func (s *sessionCommand) SetContext(ctx interface{}) { s.session = ctx }
+
+func (s *sessionCommand) Execute(args []string) error { return nil }
+
+// TestCommandSessionInjection checks that commands implementing the
+// sflags.ContextSetter interface are given the session value bound
+// to the context with which the command tree is executed.
+func TestCommandSessionInjection(t *testing.T) {
+	t.Parallel()
+
+	opts := struct {
+		Command sessionCommand `command:"cmd"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"cmd"})
+	ctx := WithSession(context.Background(), "alice")
+	cmd, err := root.ExecuteContextC(ctx)
+
+	test := assert.New(t)
+	test.Nil(err, "Command should have exited successfully")
+	test.Equal("cmd", cmd.Name())
+	test.Equal("alice", opts.Command.session)
+
+	// Without a session, nothing is injected.
+	opts.Command.session = nil
+	root = newCommandWithArgs(&opts, []string{"cmd"})
+	_, err = root.ExecuteC()
+
+	test.Nil(err, "Command should have exited successfully")
+	test.Nil(opts.Command.session)
+}
+
+// service is a dependency given to commands by their constructor.
+type service struct {
+	name     string
+	executed []string
+}
+
+// injectedCommand is a command built by a registered constructor.
+type injectedCommand struct {
+	Target string `long:"target"`
+	Region string
+
+	svc *service
+}
+
+func (c *injectedCommand) Execute(args []string) error {
+	c.svc.executed = append(c.svc.executed, c.Target+c.Region)
+
+	return nil
+}
+
+// TestCommandConstructor checks that commands with a registered constructor
+// are built with the session dependencies right before being executed, and
+// that they are given the values parsed from the command line.
+func TestCommandConstructor(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+	test.Nil(RegisterConstructor(func(svc *service) *injectedCommand {
+		return &injectedCommand{svc: svc}
+	}))
+
+	opts := struct {
+		Command injectedCommand `command:"cmd"`
+	}{}
+
+	// Untagged fields are given to the built command as well.
+	opts.Command.Region = "-eu"
+
+	svc := &service{name: "api"}
+	root := newCommandWithArgs(&opts, []string{"cmd", "--target", "prod"})
+	_, err := root.ExecuteContextC(WithSession(context.Background(), svc))
+
+	test.Nil(err, "Command should have exited successfully")
+	test.Equal([]string{"prod-eu"}, svc.executed)
+	test.Nil(opts.Command.svc, "The parsed command should not be executed")
+
+	// Invalid constructors
+	test.ErrorIs(RegisterConstructor(nil), ErrInvalidConstructor)
+	test.ErrorIs(RegisterConstructor((func(svc *service) *injectedCommand)(nil)), ErrInvalidConstructor)
+	test.ErrorIs(RegisterConstructor("constructor"), ErrInvalidConstructor)
+	test.ErrorIs(RegisterConstructor(func(svc *service) service { return *svc }), ErrInvalidConstructor)
+	test.ErrorIs(RegisterConstructor(func(svc *service) (*injectedCommand, string) {
+		return nil, ""
+	}), ErrInvalidConstructor)
+}
+
+// serverCommand has a flag required only in some modes.
+type serverCommand struct {
+	Mode string `long:"mode"`
+	Port int    `long:"port" required-if:"mode=server"`
+}
+
+func (*serverCommand) Execute(args []string) error { return nil }
+
+// TestCommandRequiredIf checks that conditionally required
+// flags are checked against the other flags before execution.
+func TestCommandRequiredIf(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command serverCommand `command:"serve"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"serve", "--mode", "client"})
+	_, err := root.ExecuteC()
+	test.Nil(err)
+
+	root = newCommandWithArgs(&opts, []string{"serve", "--mode", "server"})
+	_, err = root.ExecuteC()
+	test.ErrorIs(err, sflags.ErrRequired)
+
+	root = newCommandWithArgs(&opts, []string{"serve", "--mode", "server", "--port", "80"})
+	_, err = root.ExecuteC()
+	test.Nil(err)
+}
+
+// verboseCommand counts the occurrences of its verbose flag.
+type verboseCommand struct {
+	Verbose int `short:"v" long:"verbose" type:"counter"`
+}
+
+func (*verboseCommand) Execute(args []string) error { return nil }
+
+// TestCommandCounterFlag checks that int fields with a counter
+// type are incremented on each occurrence of their flag.
+func TestCommandCounterFlag(t *testing.T) {
+	t.Parallel()
+
+	opts := struct {
+		Command verboseCommand `command:"run"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"run", "-vv", "--verbose"})
+	_, err := root.ExecuteC()
+
+	test := assert.New(t)
+	test.Nil(err)
+	test.Equal(3, opts.Command.Verbose)
+}
+
+// cacheCommand has a boolean flag with a --no-cache form.
+type cacheCommand struct {
+	Cache bool `long:"cache" negatable:"true"`
+}
+
+func (*cacheCommand) Execute(args []string) error { return nil }
+
+// TestCommandNegatableFlag checks that negatable boolean flags
+// have a hidden --no-<flag> form, setting the opposite value.
+func TestCommandNegatableFlag(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command cacheCommand `command:"build"`
+	}{}
+	opts.Command.Cache = true
+
+	root := newCommandWithArgs(&opts, []string{"build", "--no-cache"})
+	cmd, err := root.ExecuteC()
+	test.Nil(err)
+	test.False(opts.Command.Cache)
+
+	test.True(cmd.Flags().Lookup("no-cache").Hidden)
+	test.False(cmd.Flags().Lookup("cache").Hidden)
+
+	root = newCommandWithArgs(&opts, []string{"build", "--cache"})
+	_, err = root.ExecuteC()
+	test.Nil(err)
+	test.True(opts.Command.Cache)
+}
+
+// colorCommand has a flag with an alias.
+type colorCommand struct {
+	Color string `long:"color" alias:"colour"`
+}
+
+func (*colorCommand) Execute(args []string) error { return nil }
+
+// TestCommandFlagAlias checks that flag aliases are hidden,
+// and set the same field as the flag they are an alias of.
+func TestCommandFlagAlias(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command colorCommand `command:"print"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"print", "--colour", "never"})
+	cmd, err := root.ExecuteC()
+	test.Nil(err)
+	test.Equal("never", opts.Command.Color)
+
+	test.True(cmd.Flags().Lookup("colour").Hidden)
+	test.False(cmd.Flags().Lookup("color").Hidden)
+}
+
+// TestCommandChangedFlags checks that the flags set on the command
+// line are distinguished from the ones left to their default values.
+func TestCommandChangedFlags(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command colorCommand `command:"print"`
+		Verbose bool         `long:"verbose"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"print", "--color", ""})
+	cmd, err := root.ExecuteC()
+	test.Nil(err)
+
+	test.Equal([]string{"color"}, sflags.Changed(commandFlags(cmd)))
+	test.Empty(sflags.Changed(commandFlags(root)))
+}
+
+// TestCommandDeprecated checks that commands can be marked deprecated.
+func TestCommandDeprecated(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Old   colorCommand `command:"paint" deprecated:"use print instead"`
+		Older colorCommand `command:"draw" deprecated:"true"`
+		New   colorCommand `command:"print"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"paint"})
+	cmd, err := root.ExecuteC()
+	test.Nil(err)
+	test.Equal("use print instead", cmd.Deprecated)
+
+	for _, sub := range root.Commands() {
+		switch sub.Name() {
+		case "draw":
+			test.NotEmpty(sub.Deprecated)
+		case "print":
+			test.Empty(sub.Deprecated)
+		}
+	}
+}
+
+func TestCommandSecretFlag(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Password string `long:"password" secret:"true" choices:"hunter2"`
+	}{}
+
+	cmd := newCommandWithArgs(&opts, []string{"--password=s3cr3t"})
+	err := cmd.Execute()
+	test.Error(err)
+	test.NotContains(err.Error(), "s3cr3t")
+	test.Contains(err.Error(), "********")
+}
+
+type brokenCommand struct {
+	Color  string   `long:"color"`
+	Nested struct{} `command:"nested"`
+}
+
+func (*brokenCommand) Execute(args []string) error { return nil }
+
+// TestCommandInvalidBranch checks that an invalid branch of subcommands
+// only fails when executed, and doesn't prevent other commands from running.
+func TestCommandInvalidBranch(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Paint  colorCommand  `command:"paint"`
+		Broken brokenCommand `command:"broken"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"paint", "--color", "red"})
+	test.NotNil(root)
+
+	_, err := root.ExecuteC()
+	test.Nil(err)
+	test.Equal("red", opts.Paint.Color)
+
+	root = newCommandWithArgs(&opts, []string{"broken", "--color", "blue"})
+	_, err = root.ExecuteC()
+	test.ErrorIs(err, ErrNotCommander)
+	test.Empty(opts.Broken.Color)
+}
+
+type lookupCommand struct {
+	Color  string `long:"color"`
+	Server struct {
+		Port int `long:"port"`
+	} `group:"server" namespace:"server" namespace-delimiter:"."`
+
+	flags map[string]interface{}
+	cmd   *cobra.Command
+}
+
+func (c *lookupCommand) Execute(args []string) error {
+	for _, name := range []string{"color", "port", "verbose", "missing"} {
+		if _, value, found := sflags.Lookup(c.cmd, name); found {
+			c.flags[name] = value
+		}
+	}
+
+	return nil
+}
+
+// TestCommandLookup checks that flags are resolved through
+// the local, group and persistent parent scopes of a command.
+func TestCommandLookup(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Global struct {
+			Verbose bool `long:"verbose"`
+		} `group:"global" persistent:"true"`
+		Paint lookupCommand `command:"paint"`
+	}{}
+	opts.Paint.flags = map[string]interface{}{}
+
+	root := newCommandWithArgs(&opts, []string{"--verbose", "paint", "--color", "red", "--server.port", "80"})
+	opts.Paint.cmd, _, _ = root.Find([]string{"paint"})
+
+	_, err := root.ExecuteC()
+	test.Nil(err)
+	test.Equal(map[string]interface{}{"color": "red", "port": 80, "verbose": true}, opts.Paint.flags)
+}
+
+// TestCommandHelpPager checks that the help of commands is
+// printed as usual when paged, but not written to a terminal.
+func TestCommandHelpPager(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Paint colorCommand `command:"paint" description:"paint things"`
+	}{}
+
+	help := func(cmd *cobra.Command) string {
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"paint", "--help"})
+		test.Nil(cmd.Execute())
+
+		return out.String()
+	}
+
+	plain := help(Parse(&opts))
+	paged := help(Parse(&opts, WithPager()))
+
+	test.Contains(plain, "paint things")
+	test.Contains(plain, "--color")
+	test.Equal(plain, paged)
+}
+
+// TestCommandUsagePolicy checks what the commands
+// print on parsing errors, depending on the policy.
+func TestCommandUsagePolicy(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Paint colorCommand `command:"paint"`
+	}{}
+
+	fail := func(cmd *cobra.Command, args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+
+		return out.String(), err
+	}
+
+	out, err := fail(Parse(&opts), "paint", "--unknown")
+	test.Error(err)
+	test.Contains(out, "Usage:")
+
+	out, err = fail(Parse(&opts, WithUsagePolicy(ErrorOnly)), "paint", "--unknown")
+	test.Error(err)
+	test.Contains(out, "unknown flag: --unknown")
+	test.NotContains(out, "Usage:")
+	test.NotContains(out, "--help'")
+
+	out, err = fail(Parse(&opts, WithUsagePolicy(ErrorWithHint)), "paint", "--unknown")
+	test.Error(err)
+	test.Contains(out, "unknown flag: --unknown")
+	test.Contains(out, " paint --help'.")
+	test.NotContains(out, "Usage:")
+}
+
+// defaultedCommand has defaults only known at runtime.
+type defaultedCommand struct {
+	Host  string `long:"host"`
+	Login struct {
+		User string `long:"user"`
+	} `group:"login"`
+}
+
+func (c *defaultedCommand) SetDefaults() {
+	c.Host = "localhost"
+	c.Login.User = "root"
+}
+
+func (*defaultedCommand) Execute(args []string) error { return nil }
+
+// TestCommandDefaulter checks that dynamic defaults are
+// shown in help, and kept when their flags are not given.
+func TestCommandDefaulter(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Connect defaultedCommand `command:"connect"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"connect", "--user", "admin"})
+	connect, _, _ := root.Find([]string{"connect"})
+
+	test.Equal("localhost", connect.Flags().Lookup("host").DefValue)
+	test.Equal("root", connect.Flags().Lookup("user").DefValue)
+
+	test.Nil(root.Execute())
+	test.Equal("localhost", opts.Connect.Host)
+	test.Equal("admin", opts.Connect.Login.User)
+}
+
+// TestCommandHelpLinks checks that the help of commands links to their
+// documentation, with hyperlinks only when the output supports them.
+func TestCommandHelpLinks(t *testing.T) {
+	opts := struct {
+		Paint colorCommand `command:"paint" description:"paint things, see https://example.com/colors." docs-url:"https://example.com/paint"`
+	}{}
+
+	help := func() string {
+		out := &bytes.Buffer{}
+		cmd := Parse(&opts)
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"paint", "--help"})
+		assert.Nil(t, cmd.Execute())
+
+		return out.String()
+	}
+
+	t.Setenv("FORCE_HYPERLINK", "0")
+
+	plain := help()
+	assert.Contains(t, plain, "paint things, see https://example.com/colors.")
+	assert.Contains(t, plain, "More info: https://example.com/paint\n")
+	assert.NotContains(t, plain, "\x1b]8;;")
+
+	t.Setenv("FORCE_HYPERLINK", "1")
+
+	linked := help()
+	assert.Contains(t, linked, "see \x1b]8;;https://example.com/colors\x1b\\https://example.com/colors\x1b]8;;\x1b\\.")
+	assert.Contains(t, linked, "More info: \x1b]8;;https://example.com/paint\x1b\\")
+}
+
+// TestCommandGraph checks the graph exports of a command tree.
+func TestCommandGraph(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Verbose bool `long:"verbose"`
+		Core    struct {
+			Paint colorCommand `command:"paint"`
+			Print colorCommand `command:"print"`
+		} `commands:"core" description:"Core \"commands\""`
+		Lookup lookupCommand `command:"lookup"`
+	}{}
+
+	root := Parse(&opts)
+	root.Use = "app"
+
+	dot := &bytes.Buffer{}
+	test.Nil(WriteDOT(dot, root))
+	test.Equal(`digraph "app" {
+	node [shape=box];
+	"app" [label="app\n1 flag"];
+	"app lookup" [label="lookup\n2 flags"];
+	"app" -> "app lookup";
+	subgraph "cluster_0" {
+		label="Core \"commands\"";
+		"app paint" [label="paint\n1 flag"];
+		"app print" [label="print\n1 flag"];
+	}
+	"app" -> "app paint";
+	"app" -> "app print";
+}
+`, dot.String())
+
+	mermaid := &bytes.Buffer{}
+	test.Nil(WriteMermaid(mermaid, root))
+	test.Equal(`graph TD
+	n0["app<br/>1 flag"]
+	n1["lookup<br/>2 flags"]
+	n0 --> n1
+	subgraph g0 ["Core #quot;commands#quot;"]
+		n2["paint<br/>1 flag"]
+		n3["print<br/>1 flag"]
+	end
+	n0 --> n2
+	n0 --> n3
+`, mermaid.String())
+}
+
+// outputCommand has two sets of mutually exclusive flags,
+// and a group of flags required together.
+type outputCommand struct {
+	Format struct {
+		JSON bool `long:"json"`
+		YAML bool `long:"yaml"`
+	} `group:"format" exclusive:"true"`
+	Quiet   bool `long:"quiet" xor:"output"`
+	Verbose bool `long:"verbose" xor:"output"`
+	Color   bool `long:"color"`
+	Login   struct {
+		User     string `long:"user"`
+		Password string `long:"password"`
+	} `group:"login" all-or-none:"true"`
+}
+
+func (*outputCommand) Execute(args []string) error { return nil }
+
+// TestCommandFlagGroups checks that flags of an exclusive group, or tagged
+// with the same xor group, cannot be set together, and that flags of an
+// all-or-none group must be.
+func TestCommandFlagGroups(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command outputCommand `command:"show"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"show", "--quiet", "--json", "--color"})
+	_, err := root.ExecuteC()
+	test.Nil(err)
+
+	root = newCommandWithArgs(&opts, []string{"show", "--quiet", "--verbose"})
+	_, err = root.ExecuteC()
+	test.EqualError(err, "if any flags in the group [quiet verbose] are set none of the others can be; [quiet verbose] were all set")
+
+	root = newCommandWithArgs(&opts, []string{"show", "--json", "--yaml"})
+	_, err = root.ExecuteC()
+	test.ErrorContains(err, "[json yaml] were all set")
+
+	root = newCommandWithArgs(&opts, []string{"show", "--user", "alice"})
+	_, err = root.ExecuteC()
+	test.EqualError(err, "if any flags in the group [password user] are set they must all be set; missing [password]")
+
+	root = newCommandWithArgs(&opts, []string{"show", "--user", "alice", "--password", "secret"})
+	_, err = root.ExecuteC()
+	test.Nil(err)
+}
+
+// deployCommand has several required flags.
+type deployCommand struct {
+	Env     string `long:"env" required:"true"`
+	Version string `long:"version" required:"true"`
+	Target  struct {
+		Region string `long:"region" required:"true"`
+	} `group:"target"`
+	DryRun bool `long:"dry-run"`
+}
+
+func (*deployCommand) Execute(args []string) error { return nil }
+
+// TestCommandRequiredFlags checks that all missing required
+// flags are reported at once, including those of groups.
+func TestCommandRequiredFlags(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command deployCommand `command:"deploy"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"deploy", "--version", "1.2"})
+	_, err := root.ExecuteC()
+	test.EqualError(err, `required flag(s) "env", "region" not set`)
+
+	root = newCommandWithArgs(&opts, []string{"deploy", "--env", "prod", "--version", "1.2", "--region", "eu"})
+	_, err = root.ExecuteC()
+	test.Nil(err)
+}
+
+// scaleCommand has flags and arguments which can all be invalid.
+type scaleCommand struct {
+	Replicas int    `long:"replicas"`
+	Mode     string `long:"mode" choices:"fast safe"`
+	Args     struct {
+		Service string
+		Timeout time.Duration
+	} `positional-args:"yes"`
+}
+
+func (*scaleCommand) Execute(args []string) error { return nil }
+
+// TestCommandErrors checks that all invalid flags and
+// arguments are reported at once, as sflags.Errors.
+func TestCommandErrors(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Zone    int          `long:"zone" persistent:"true"`
+		Command scaleCommand `command:"scale"`
+	}{}
+
+	root := newCommandWithArgs(&opts, []string{"scale", "--replicas", "many", "--mode", "slow", "web", "soon"})
+	_, err := root.ExecuteC()
+
+	var errs sflags.Errors
+	test.True(errors.As(err, &errs))
+	test.Len(errs, 3)
+	test.ErrorContains(err, `invalid argument "many" for "--replicas" flag`)
+	test.ErrorContains(err, `invalid argument "slow" for "--mode" flag`)
+	test.ErrorContains(err, "invalid argument for `Timeout`")
+
+	// Invalid flags are also reported along with parsing errors.
+	root = newCommandWithArgs(&opts, []string{"scale", "--replicas", "many", "--unknown"})
+	_, err = root.ExecuteC()
+	test.ErrorContains(err, `invalid argument "many" for "--replicas" flag`)
+	test.ErrorContains(err, "unknown flag: --unknown")
+
+	// Along with those of the persistent flags of their parents.
+	root = newCommandWithArgs(&opts, []string{"scale", "--zone", "west", "--replicas", "many"})
+	_, err = root.ExecuteC()
+	test.True(errors.As(err, &errs))
+	test.Len(errs, 2)
+	test.ErrorContains(err, `invalid argument "west" for "--zone" flag`)
+
+	// And are not reported again by the same command tree.
+	root.SetArgs([]string{"scale", "--replicas", "2", "web", "1s"})
+	_, err = root.ExecuteC()
+	test.Nil(err)
+}
+
+// tallyCommand records the state it is executed with in tallies.
+type tallyCommand struct {
+	Tags  []string `long:"tag"`
+	count int
+}
+
+var tallies []string
+
+func (c *tallyCommand) Execute(args []string) error {
+	c.count++
+	tallies = append(tallies, fmt.Sprintf("%d %v", c.count, c.Tags))
+
+	return nil
+}
+
+// TestCommandFreshInstances checks that commands are executed on new
+// instances of their structs, and that the parsed ones are reset.
+func TestCommandFreshInstances(t *testing.T) {
+	test := assert.New(t)
+
+	opts := struct {
+		Command tallyCommand `command:"tally"`
+	}{}
+
+	root := Parse(&opts, WithName("app"), WithFreshInstances())
+	root.SilenceErrors = true
+
+	root.SetArgs([]string{"tally", "--tag", "a", "--tag", "b"})
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"tally"})
+	test.Nil(root.Execute())
+
+	test.Equal([]string{"1 [a b]", "1 []"}, tallies)
+	test.Empty(opts.Command.Tags)
+	test.Zero(opts.Command.count)
+}
+
+// lifecycleCommand records the steps of its execution.
+type lifecycleCommand struct {
+	Abort bool `long:"abort"`
+	Fail  bool `long:"fail"`
+	steps []string
+}
+
+func (c *lifecycleCommand) PreRun(args []string) error {
+	c.steps = append(c.steps, fmt.Sprintf("pre %v", args))
+	if c.Abort {
+		return errors.New("aborted")
+	}
+
+	return nil
+}
+
+func (c *lifecycleCommand) Execute(args []string) error {
+	c.steps = append(c.steps, "execute")
+	if c.Fail {
+		return errors.New("failed")
+	}
+
+	return nil
+}
+
+func (c *lifecycleCommand) PostRun(err error) {
+	c.steps = append(c.steps, fmt.Sprintf("post %v", err))
+}
+
+// TestCommandPrePostRun checks that commands are prepared and cleaned up.
+func TestCommandPrePostRun(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command lifecycleCommand `command:"run"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"run"})
+	test.Nil(root.Execute())
+	test.Equal([]string{"pre []", "execute", "post <nil>"}, opts.Command.steps)
+
+	opts.Command.steps = nil
+	root.SetArgs([]string{"run", "--fail"})
+	test.EqualError(root.Execute(), "failed")
+	test.Equal([]string{"pre []", "execute", "post failed"}, opts.Command.steps)
+
+	opts.Command.steps = nil
+	root.SetArgs([]string{"run", "--abort"})
+	test.EqualError(root.Execute(), "aborted")
+	test.Equal([]string{"pre []"}, opts.Command.steps)
+}
+
+var setups []string
+
+// setupRoot runs some setup before all commands.
+type setupRoot struct {
+	Verbose bool        `short:"v"`
+	Remote  setupRemote `command:"remote"`
+}
+
+func (r *setupRoot) PersistentPreRun(args []string) error {
+	setups = append(setups, fmt.Sprintf("root %v", r.Verbose))
+
+	return nil
+}
+
+// setupRemote runs some setup before its subcommands, and fails if asked.
+type setupRemote struct {
+	Offline bool             `long:"offline"`
+	Add     tallyCommand     `command:"add"`
+	Run     lifecycleCommand `command:"run"`
+}
+
+func (r *setupRemote) Execute(args []string) error { return nil }
+
+func (r *setupRemote) PersistentPreRun(args []string) error {
+	setups = append(setups, "remote")
+	if r.Offline {
+		return errors.New("offline")
+	}
+
+	return nil
+}
+
+// TestCommandPersistentPreRun checks that the persistent pre-runs of parent
+// commands are all called, from the root, before executing a subcommand.
+func TestCommandPersistentPreRun(t *testing.T) {
+	test := assert.New(t)
+
+	opts := &setupRoot{}
+	root := Parse(opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"-v", "remote", "run"})
+	test.Nil(root.Execute())
+	test.Equal([]string{"root true", "remote"}, setups)
+	test.Equal([]string{"pre []", "execute", "post <nil>"}, opts.Remote.Run.steps)
+
+	setups = nil
+	root.SetArgs([]string{"remote", "--offline", "add"})
+	test.EqualError(root.Execute(), "offline")
+	test.Equal([]string{"root true", "remote"}, setups)
+}
+
+// contextCommand records whether it is executed with a context.
+type contextCommand struct {
+	executed string
+}
+
+func (c *contextCommand) Execute(args []string) error {
+	c.executed = "without context"
+
+	return nil
+}
+
+func (c *contextCommand) ExecuteContext(ctx context.Context, args []string) error {
+	c.executed = "with context"
+
+	return ctx.Err()
+}
+
+// TestCommandExecuteContext checks that commands are given the command context.
+func TestCommandExecuteContext(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command contextCommand `command:"run"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"run"})
+	test.Nil(root.Execute())
+	test.Equal("with context", opts.Command.executed)
+
+	// Cobra keeps the first context given to subcommands.
+	root = Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	root.SetArgs([]string{"run"})
+	test.ErrorIs(root.ExecuteContext(ctx), context.Canceled)
+}
+
+// listCommand records the arguments it is executed with.
+type listCommand struct {
+	Args struct {
+		Filter string
+	} `positional-args:"yes"`
+	executed []string
+}
+
+func (c *listCommand) Execute(args []string) error {
+	c.executed = append(c.executed, fmt.Sprintf("%q %v", c.Args.Filter, args))
+
+	return nil
+}
+
+// remotesCommand runs its show subcommand by default.
+type remotesCommand struct {
+	Show listCommand `command:"show"`
+	Add  testCommand `command:"add"`
+}
+
+func (c *remotesCommand) Execute(args []string) error { return nil }
+
+func (c *remotesCommand) DefaultCommand() string { return "show" }
+
+// TestCommandDefault checks that commands invoked without subcommands run their default one.
+func TestCommandDefault(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		List    listCommand    `command:"list" default:"true"`
+		Add     testCommand    `command:"add"`
+		Remotes remotesCommand `command:"remotes"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{})
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"web", "extra"})
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"list", "db"})
+	test.Nil(root.Execute())
+	test.Equal([]string{`"" []`, `"web" [extra]`, `"db" []`}, opts.List.executed)
+
+	root.SetArgs([]string{"remotes", "origin"})
+	test.Nil(root.Execute())
+	test.Equal([]string{`"origin" []`}, opts.Remotes.Show.executed)
+}
+
+// TestCommandInterface checks that commands declared as interfaces are
+// scanned from their implementation, and skipped when they have none.
+func TestCommandInterface(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	plugin := &lifecycleCommand{}
+	opts := struct {
+		Plugin  sflags.Commander `command:"plugin"`
+		Missing sflags.Commander `command:"missing"`
+	}{Plugin: plugin}
+
+	root, err := ParseE(&opts, WithName("app"))
+	test.Nil(err)
+	test.Len(root.Commands(), 1)
+
+	root.SilenceErrors = true
+	root.SetArgs([]string{"plugin", "--fail"})
+	test.EqualError(root.Execute(), "failed")
+	test.True(plugin.Fail)
+	test.Equal([]string{"pre []", "execute", "post failed"}, plugin.steps)
+}
+
+// TestAddCommand checks that commands can be added to an existing tree.
+func TestAddCommand(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Command testCommand `command:"cmd"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	plugin := &lifecycleCommand{}
+	test.Nil(AddCommand(root, plugin, "plugin"))
+	test.ErrorIs(AddCommand(root, &lifecycleCommand{}, "cmd"), ErrCommandExists)
+	test.ErrorIs(AddCommand(root, &struct{}{}, "empty"), ErrNotCommander)
+
+	root.SetArgs([]string{"plugin", "--abort"})
+	test.EqualError(root.Execute(), "aborted")
+	test.True(plugin.Abort)
+
+	// Commands failing to be scanned are not added.
+	invalid := &struct {
+		testCommand
+		Sub struct{} `command:"sub"`
+	}{}
+	test.ErrorIs(AddCommand(root, invalid, "invalid"), ErrNotCommander)
+
+	for _, cmd := range root.Commands() {
+		test.NotEqual("invalid", cmd.Name())
+	}
+}
+
+// execCommand passes its remaining arguments to another program.
+type execCommand struct {
+	Verbose bool `short:"v"`
+
+	Positional struct {
+		Program string
+	} `positional-args:"yes"`
+
+	Args []string `remaining:"true"`
+}
+
+func (*execCommand) Execute(args []string) error { return nil }
+
+// passCommand has no positionals, but remaining arguments.
+type passCommand struct {
+	Rest []string `remaining:"yes"`
+}
+
+func (*passCommand) Execute(args []string) error { return nil }
+
+// badRemainingCommand binds its remaining arguments to a string.
+type badRemainingCommand struct {
+	Rest string `remaining:"true"`
+}
+
+func (*badRemainingCommand) Execute(args []string) error { return nil }
+
+// TestCommandRemainingField checks that the arguments left by the flags
+// and positionals of a command are bound to its `remaining` field.
+func TestCommandRemainingField(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Exec execCommand `command:"exec"`
+		Run  passCommand `command:"run"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	exec, _, _ := root.Find([]string{"exec"})
+	test.Nil(exec.Flags().Lookup("args"))
+
+	root.SetArgs([]string{"exec", "-v", "ls", "--", "-la", "my dir"})
+	test.Nil(root.Execute())
+	test.True(opts.Exec.Verbose)
+	test.Equal("ls", opts.Exec.Positional.Program)
+	test.Equal([]string{"-la", "my dir"}, opts.Exec.Args)
+
+	// The words are not stored in the metadata of the command.
+	test.Empty(exec.Annotations)
+
+	// Commands without positionals accept any arguments.
+	root.SetArgs([]string{"run", "a", "b"})
+	test.Nil(root.Execute())
+	test.Equal([]string{"a", "b"}, opts.Run.Rest)
+
+	badOpts := struct {
+		Exec badRemainingCommand `command:"exec"`
+	}{}
+
+	root = Parse(&badOpts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"exec"})
+	test.ErrorIs(root.Execute(), ErrInvalidTag)
+}
+
+// recordTracer records the spans of command executions.
+type recordTracer struct {
+	spans []*recordSpan
+}
+
+type recordSpan struct {
+	name   string
+	attrs  map[string]string
+	stages []string
+	err    error
+	ended  bool
+}
+
+type spanKey struct{}
+
+func (t *recordTracer) Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span) {
+	span := &recordSpan{name: name, attrs: attrs}
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *recordSpan) Stage(name string) { s.stages = append(s.stages, name) }
+func (s *recordSpan) End(err error)     { s.err, s.ended = err, true }
+
+// tracedCommand checks that it is given the context of its span.
+type tracedCommand struct {
+	User     string `long:"user"`
+	Password string `long:"password" secret:"true"`
+	Port     int    `long:"port"`
+}
+
+func (c *tracedCommand) Execute(args []string) error { return nil }
+
+func (c *tracedCommand) ExecuteContext(ctx context.Context, args []string) error {
+	if ctx.Value(spanKey{}) == nil {
+		return errors.New("no span in context")
+	}
+
+	return nil
+}
+
+// TestCommandTracer checks that command executions are traced, with redacted flags.
+func TestCommandTracer(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Login tracedCommand `command:"login"`
+	}{}
+
+	tracer := &recordTracer{}
+
+	root := Parse(&opts, WithName("app"), WithTracer(tracer))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"login", "--user", "admin", "--password=hunter2"})
+	test.Nil(root.Execute())
+	test.Len(tracer.spans, 1)
+
+	span := tracer.spans[0]
+	test.Equal("app login", span.name)
+	test.Equal(map[string]string{"flag.user": "admin", "flag.password": "********"}, span.attrs)
+	test.Equal([]string{StageValidate, StageExecute}, span.stages)
+	test.True(span.ended)
+	test.Nil(span.err)
+
+	root.SetArgs([]string{"login", "--port", "http"})
+	test.Error(root.Execute())
+	test.Len(tracer.spans, 2)
+	test.Error(tracer.spans[1].err)
+	test.True(tracer.spans[1].ended)
+
+	// Commands added to the tree are traced by the tracer given to them.
+	test.Nil(AddCommand(root, &tracedCommand{}, "relogin", WithTracer(tracer)))
+	root.SetArgs([]string{"relogin", "--user", "guest"})
+	test.Nil(root.Execute())
+	test.Len(tracer.spans, 3)
+	test.Equal("app relogin", tracer.spans[2].name)
+
+	// Unlike the commands of other trees.
+	other := Parse(&struct {
+		Login loginCommand `command:"login"`
+	}{}, WithName("app"))
+	other.SetArgs([]string{"login"})
+	test.Nil(other.Execute())
+	test.Len(tracer.spans, 3)
+}
+
+// wrapCommand runs another program with the words after a double dash.
+type wrapCommand struct {
+	Dry bool `long:"dry"`
+
+	Positional struct {
+		Target string
+	} `positional-args:"yes"`
+
+	Command []string `passthrough-args:"true"`
+	args    []string
+}
+
+func (c *wrapCommand) Execute(args []string) error {
+	c.args = args
+
+	return nil
+}
+
+// TestCommandPassthroughField checks that the words after a double
+// dash are bound to the passthrough field, and nothing else.
+func TestCommandPassthroughField(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Run wrapCommand `command:"run"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"run", "--dry", "local", "extra", "--", "ls", "--dry", "-la"})
+	test.Nil(root.Execute())
+	test.True(opts.Run.Dry)
+	test.Equal("local", opts.Run.Positional.Target)
+	test.Equal([]string{"extra"}, opts.Run.args)
+	test.Equal([]string{"ls", "--dry", "-la"}, opts.Run.Command)
+
+	root.SetArgs([]string{"run", "remote"})
+	test.Nil(root.Execute())
+	test.Empty(opts.Run.Command)
+}
+
+// crashCommand panics when executed.
+type crashCommand struct{}
+
+func (*crashCommand) Execute(args []string) error { panic("boom") }
+
+// TestCommandCrashReports checks that panics of commands are recovered, and reported.
+func TestCommandCrashReports(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Crash crashCommand `command:"crash"`
+	}{}
+
+	dir := t.TempDir()
+	out := &bytes.Buffer{}
+
+	root := Parse(&opts, WithName("app"), WithVersion("v1.2.0"), WithCrashReports(dir))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetErr(out)
+
+	root.SetArgs([]string{"crash"})
+	err := root.Execute()
+
+	var crash *CrashError
+	test.ErrorAs(err, &crash)
+	test.Equal("app crash", crash.Command)
+	test.Equal("boom", crash.Value)
+	test.Contains(out.String(), "version: v1.2.0")
+	test.Contains(out.String(), "crashCommand")
+
+	report, err := os.ReadFile(crash.File)
+	test.Nil(err)
+	test.Equal(filepath.Dir(crash.File), dir)
+	test.Contains(string(report), "panic:   boom")
+}
+
+// strictRemote has a subcommand wanting leftover arguments.
+type strictRemote struct {
+	Exec execCommand `command:"exec"`
+}
+
+func (*strictRemote) Execute(args []string) error { return nil }
+
+// TestCommandStrictArgs checks that strict commands fail on leftover arguments.
+func TestCommandStrictArgs(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		List   listCommand    `command:"list" strict-args:"true"`
+		Lax    listCommand    `command:"lax"`
+		Prune  sessionCommand `command:"prune" strict-args:"true"`
+		Remote strictRemote   `command:"remote" strict-args:"true"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"list", "main"})
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"list", "main", "dev"})
+	err := root.Execute()
+	test.ErrorIs(err, ErrUnexpectedArg)
+	test.EqualError(err, "unexpected argument: dev")
+
+	root.SetArgs([]string{"lax", "main", "dev"})
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"prune", "all"})
+	test.ErrorIs(root.Execute(), ErrUnexpectedArg)
+
+	// Subcommands are strict too, unless they want leftover arguments.
+	root.SetArgs([]string{"remote", "exec", "ls", "--", "-la"})
+	test.Nil(root.Execute())
+	test.Equal([]string{"-la"}, opts.Remote.Exec.Args)
+
+	// Or all commands of the tree.
+	lax := struct {
+		Lax listCommand `command:"lax"`
+	}{}
+
+	root = Parse(&lax, WithName("app"), WithStrictArgs())
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"lax", "main", "dev"})
+	test.ErrorIs(root.Execute(), ErrUnexpectedArg)
+}
+
+// regionCommand has a flag with choices.
+type regionCommand struct {
+	Region string `long:"region" choices:"eu us"`
+}
+
+func (*regionCommand) Execute(args []string) error { return nil }
+
+// TestCommandNativeCompletions checks that flags with choices are completed by cobra.
+func TestCommandNativeCompletions(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Deploy regionCommand `command:"deploy"`
+	}{}
+
+	root := Parse(&opts, WithName("app"), WithCompletions(NativeCompletions()))
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+
+	root.SetArgs([]string{cobra.ShellCompRequestCmd, "deploy", "--region", ""})
+	test.Nil(root.Execute())
+	test.Contains(out.String(), "eu\nus\n")
+}
+
+// TestCommandPosixParsing checks that POSIX commands stop parsing flags at their first argument.
+func TestCommandPosixParsing(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Run  execCommand `command:"run" posix:"true"`
+		Exec execCommand `command:"exec"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"run", "-v", "ls", "-v", "-la"})
+	test.Nil(root.Execute())
+	test.True(opts.Run.Verbose)
+	test.Equal("ls", opts.Run.Positional.Program)
+	test.Equal([]string{"-v", "-la"}, opts.Run.Args)
+
+	root.SetArgs([]string{"exec", "ls", "-la"})
+	test.Error(root.Execute())
+
+	// Or all commands of the tree.
+	all := struct {
+		Exec execCommand `command:"exec"`
+	}{}
+
+	root = Parse(&all, WithName("app"), WithPosixParsing())
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"exec", "ls", "-la"})
+	test.Nil(root.Execute())
+	test.Equal([]string{"-la"}, all.Exec.Args)
+}
+
+// TestCommandAnnotations checks that commands carry the annotations of their tags.
+func TestCommandAnnotations(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Deploy regionCommand `command:"deploy" annotations:"team:infra,beta" annotation:"docs:deploy.md"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+
+	deploy, _, err := root.Find([]string{"deploy"})
+	test.Nil(err)
+	test.Equal("infra", deploy.Annotations["team"])
+	test.Equal("true", deploy.Annotations["beta"])
+	test.Equal("deploy.md", deploy.Annotations["docs"])
+}
+
+// TestCommandWithoutProcessArgs checks that command trees can be generated and executed
+// on runtimes starting programs without arguments, like js/wasm. Not parallel, as it
+// changes os.Args.
+func TestCommandWithoutProcessArgs(t *testing.T) {
+	test := assert.New(t)
+
+	args := os.Args
+	os.Args = nil
+
+	defer func() { os.Args = args }()
+
+	opts := struct {
+		Run execCommand `command:"run"`
+	}{}
+
+	root := Parse(&opts)
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetOut(io.Discard)
+
+	test.Equal("app", root.Use)
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"run", "-v", "ls"})
+	test.Nil(root.Execute())
+	test.True(opts.Run.Verbose)
+}
+
+// TestCommandGroups checks that groups of commands are inherited by nested structs,
+// and titled and ordered by those declared programmatically.
+func TestCommandGroups(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	type groupedCommands struct {
+		Core struct {
+			Paint colorCommand `command:"paint"`
+			Extra struct {
+				Print colorCommand `command:"print"`
+			} `commands:""`
+		} `commands:"core" description:"Core commands"`
+		Remote struct {
+			Lookup lookupCommand `command:"lookup"`
+		} `commands:"remote"`
+		Tag colorCommand `command:"tag" group:"remote"`
+	}
+
+	groups := func(cmd *cobra.Command) (ids, titles []string) {
+		for _, group := range cmd.Groups() {
+			ids = append(ids, group.Group)
+			titles = append(titles, group.Title)
+		}
+
+		return ids, titles
+	}
+
+	opts := groupedCommands{}
+	root := Parse(&opts)
+
+	ids, titles := groups(root)
+	test.Equal([]string{"core", "remote"}, ids)
+	test.Equal([]string{"Core commands", ""}, titles)
+
+	for name, group := range map[string]string{"paint": "core", "print": "core", "lookup": "remote", "tag": "remote"} {
+		sub, _, err := root.Find([]string{name})
+		test.Nil(err)
+		test.Equal(group, sub.Group, name)
+	}
+
+	// Groups of options are not groups of commands.
+	lookup, _, _ := root.Find([]string{"lookup"})
+	test.Empty(lookup.Groups())
+
+	// Declared groups come first, with their titles.
+	opts = groupedCommands{}
+	root = Parse(&opts, WithGroups(&cobra.Group{Group: "remote", Title: "Remote commands"}))
+
+	ids, titles = groups(root)
+	test.Equal([]string{"remote", "core"}, ids)
+	test.Equal([]string{"Remote commands", "Core commands"}, titles)
+}
+
+// loginCommand has a secret flag.
+type loginCommand struct {
+	User     string `long:"user"`
+	Password string `long:"password" secret:"true"`
+	Port     int    `long:"port"`
+}
+
+func (*loginCommand) Execute(args []string) error { return nil }
+
+// TestCommandSnapshot checks that the values of a command tree are restored
+// in another one, apart from those of secret flags.
+func TestCommandSnapshot(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	type snapshotCommands struct {
+		Hosts  []string     `long:"hosts" persistent:"true"`
+		Run    execCommand  `command:"run"`
+		Remote loginCommand `command:"remote"`
+	}
+
+	opts := snapshotCommands{}
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"--hosts", "a,b", "run", "-v", "ls"})
+	test.Nil(root.Execute())
+
+	root.SetArgs([]string{"remote", "--user", "admin", "--password=hunter2", "--port", "22"})
+	test.Nil(root.Execute())
+
+	data, err := Snapshot(root, &opts)
+	test.Nil(err)
+	test.NotContains(string(data), "hunter2")
+
+	restored := snapshotCommands{}
+	resumed := Parse(&restored, WithName("app"))
+	test.Nil(Restore(resumed, &restored, data))
+
+	test.Equal([]string{"a", "b"}, restored.Hosts)
+	test.True(restored.Run.Verbose)
+	test.Equal("ls", restored.Run.Positional.Program)
+	test.Equal("admin", restored.Remote.User)
+	test.Equal(22, restored.Remote.Port)
+	test.Empty(restored.Remote.Password)
+
+	remote, _, _ := resumed.Find([]string{"remote"})
+	test.True(remote.Flags().Lookup("port").Changed)
+
+	test.Error(Restore(resumed, &restored, []byte("{")))
+}
+
+// TestCommandSuggestions checks that mistyped commands and flags are suggested.
+func TestCommandSuggestions(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Paint  colorCommand `command:"paint" suggest-for:"draw"`
+		Lookup colorCommand `command:"lookup" suggestions-distance:"3"`
+	}{}
+
+	root := Parse(&opts, WithName("app"))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.SetOut(io.Discard)
+
+	root.SetArgs([]string{"pain"})
+	test.ErrorContains(root.Execute(), "Did you mean this?\n\tpaint")
+
+	root.SetArgs([]string{"draw"})
+	test.ErrorContains(root.Execute(), "Did you mean this?\n\tpaint")
+
+	root.SetArgs([]string{"paint", "--colr", "red"})
+	test.EqualError(root.Execute(), "unknown flag: --colr, did you mean --color?")
+
+	root.SetArgs([]string{"lookup", "--colrxx", "red"})
+	test.EqualError(root.Execute(), "unknown flag: --colrxx, did you mean --color?")
+
+	root.SetArgs([]string{"paint", "--colrxx", "red"})
+	test.EqualError(root.Execute(), "unknown flag: --colrxx")
+
+	root = Parse(&opts, WithName("app"), WithSuggestions(3))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"paint", "--colrxx", "red"})
+	test.EqualError(root.Execute(), "unknown flag: --colrxx, did you mean --color?")
+}
+
+// rolloutCommand has flags in a group, parsed together.
+type rolloutCommand struct {
+	Opts struct {
+		Region string `long:"region"`
+		Nodes  int    `long:"nodes"`
+	} `group:"deploy options"`
+}
+
+func (*rolloutCommand) Execute(args []string) error { return nil }
+
+// TestCommandProfiles checks that commands with flags can select a profile of defaults.
+func TestCommandProfiles(t *testing.T) {
+	t.Parallel()
+
+	test := assert.New(t)
+
+	opts := struct {
+		Serve  serverCommand  `command:"serve"`
+		Deploy rolloutCommand `command:"deploy"`
+		Root   bool           `long:"root"`
+	}{}
+
+	profiles := map[string]interface{}{
+		"dev":  map[string]interface{}{"mode": "server", "port": 8080},
+		"prod": map[string]interface{}{"region": "eu", "nodes": 3},
+	}
+
+	root := Parse(&opts, WithName("app"), WithProfiles(profiles))
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	root.SetArgs([]string{"serve", "--profile", "dev"})
+	test.Nil(root.Execute())
+	test.Equal("server", opts.Serve.Mode)
+	test.Equal(8080, opts.Serve.Port)
+
+	// Explicit values override those of the profile, whatever their order.
+	root.SetArgs([]string{"serve", "--port", "9090", "--profile", "dev"})
+	test.Nil(root.Execute())
+	test.Equal(9090, opts.Serve.Port)
+
+	// Grouped flags are profiled too, but only once per command.
+	root.SetArgs([]string{"deploy", "--profile", "prod"})
+	test.Nil(root.Execute())
+	test.Equal("eu", opts.Deploy.Opts.Region)
+	test.Equal(3, opts.Deploy.Opts.Nodes)
+
+	root.SetArgs([]string{"deploy", "--profile", "dev"})
+	test.ErrorContains(root.Execute(), `no flag named "mode"`)
+
+	// Profiles are not inherited by commands added to the tree.
+	AddCommand(root, &serverCommand{}, "server")
+
+	server, _, err := root.Find([]string{"server"})
+	test.Nil(err)
+	test.Nil(server.Flags().Lookup("profile"))
+}
@@ -0,0 +1,78 @@
+package gcobra
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags"
+)
+
+// CompletionBackend generates the completions of the flags and arguments of a
+// command tree, given its root command and struct. Backends are the carapace one
+// (see gcomp.Backend), the native one of cobra (NativeCompletions), or none: the
+// default, with which cobra only completes commands and flag names. As this
+// package does not depend on carapace, binaries not importing gcomp do not either.
+type CompletionBackend interface {
+	Complete(cmd *cobra.Command, data interface{}) error
+}
+
+// WithCompletions generates the completions of the command tree with backend.
+func WithCompletions(backend CompletionBackend) Option {
+	return func(opts *options) { opts.completions = backend }
+}
+
+// NativeCompletions returns the completion backend using the completion
+// functions of cobra: flags with choices (`choices` tag, enums, etc) are
+// completed with them, and other flags and arguments by the shell.
+func NativeCompletions() CompletionBackend {
+	return nativeCompletions{}
+}
+
+type nativeCompletions struct{}
+
+func (nativeCompletions) Complete(cmd *cobra.Command, data interface{}) error {
+	if data == nil {
+		return nil
+	}
+
+	model, err := sflags.Inspect(data)
+	if err != nil {
+		return err
+	}
+
+	nativeComplete(cmd, model)
+
+	return nil
+}
+
+// nativeComplete registers the completions of the flags of a command, and of its subcommands.
+func nativeComplete(cmd *cobra.Command, model *sflags.Model) {
+	for _, flag := range modelFlags(model.Flags, model.Groups) {
+		if len(flag.Choices) == 0 || cmd.Flags().Lookup(flag.Name) == nil {
+			continue
+		}
+
+		choices := flag.Choices
+		_ = cmd.RegisterFlagCompletionFunc(flag.Name, func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return choices, cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+
+	for _, sub := range model.Commands {
+		for _, subc := range cmd.Commands() {
+			if subc.Name() == sub.Name {
+				nativeComplete(subc, sub)
+			}
+		}
+	}
+}
+
+// modelFlags returns the flags of a command, including those of its groups of options.
+func modelFlags(flags []*sflags.Flag, groups []*sflags.Group) []*sflags.Flag {
+	all := append([]*sflags.Flag(nil), flags...)
+
+	for _, group := range groups {
+		all = append(all, modelFlags(group.Flags, group.Groups)...)
+	}
+
+	return all
+}
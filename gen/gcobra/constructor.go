@@ -0,0 +1,135 @@
+package gcobra
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// ErrInvalidConstructor is returned when registering a command constructor
+// which is not a function of the form func(deps D) *C or func(deps D) (*C, error).
+var ErrInvalidConstructor = errors.New("invalid command constructor")
+
+var (
+	constructors   = map[reflect.Type]reflect.Value{}
+	constructorsMu sync.RWMutex
+
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+	commanderType = reflect.TypeOf((*sflags.Commander)(nil)).Elem()
+)
+
+// RegisterConstructor registers a constructor function for a command type, so
+// that command structs can be given their clients/services without resorting to
+// package-level globals. The constructor must be of the form func(deps D) *C, or
+// func(deps D) (*C, error), where *C implements sflags.Commander.
+//
+// The command struct is still scanned for flags/positionals as usual, but right
+// before executing it, the constructor is called with the session value bound to
+// the command context (see WithSession), or with the zero value of D if none.
+// All values parsed from the command line onto the struct tagged fields are then
+// copied onto the constructed command, which is the one eventually executed.
+func RegisterConstructor(ctor interface{}) error {
+	ctorVal := reflect.ValueOf(ctor)
+	if !ctorVal.IsValid() {
+		return newError(ErrInvalidConstructor, "nil constructor")
+	}
+
+	ctorType := ctorVal.Type()
+
+	if ctorType.Kind() != reflect.Func || ctorVal.IsNil() || ctorType.NumIn() != 1 ||
+		ctorType.NumOut() < 1 || ctorType.NumOut() > 2 {
+		return newError(ErrInvalidConstructor, ctorType.String())
+	}
+
+	cmdType := ctorType.Out(0)
+	if cmdType.Kind() != reflect.Ptr || cmdType.Elem().Kind() != reflect.Struct ||
+		!cmdType.Implements(commanderType) {
+		return newError(ErrInvalidConstructor,
+			fmt.Sprintf("%s does not return a pointer to a command struct", ctorType))
+	}
+
+	if ctorType.NumOut() == 2 && ctorType.Out(1) != errorType {
+		return newError(ErrInvalidConstructor,
+			fmt.Sprintf("second return value of %s must be an error", ctorType))
+	}
+
+	constructorsMu.Lock()
+	constructors[cmdType] = ctorVal
+	constructorsMu.Unlock()
+
+	return nil
+}
+
+// construct returns the command to execute: if a constructor is registered for
+// the parsed command type, a new command is built and given the parsed values.
+func construct(cmd *cobra.Command, parsed sflags.Commander) (sflags.Commander, error) {
+	parsedVal := reflect.ValueOf(parsed)
+
+	constructorsMu.RLock()
+	ctor, found := constructors[parsedVal.Type()]
+	constructorsMu.RUnlock()
+
+	if !found {
+		return parsed, nil
+	}
+
+	// Get the dependencies to be passed to the constructor.
+	depsType := ctor.Type().In(0)
+	deps := reflect.New(depsType).Elem()
+
+	if session := Session(cmd.Context()); session != nil {
+		sessionVal := reflect.ValueOf(session)
+		if !sessionVal.Type().AssignableTo(depsType) {
+			return nil, fmt.Errorf("%w: session value of type %s cannot be passed to %s",
+				ErrInvalidConstructor, sessionVal.Type(), ctor.Type())
+		}
+
+		deps.Set(sessionVal)
+	}
+
+	results := ctor.Call([]reflect.Value{deps})
+	if len(results) == 2 && !results[1].IsNil() {
+		err, _ := results[1].Interface().(error)
+
+		return nil, err
+	}
+
+	built := results[0]
+	if built.IsNil() {
+		return nil, fmt.Errorf("%w: %s returned a nil command", ErrInvalidConstructor, ctor.Type())
+	}
+
+	copyParsedFields(parsedVal.Elem(), built.Elem())
+
+	impl, _ := built.Interface().(sflags.Commander)
+
+	return impl, nil
+}
+
+// copyParsedFields copies the values of the exported fields of a struct (its
+// flags, positionals, groups and subcommands, and any field set along them) to
+// another one, apart from those never scanned, or skipped with the no-flag tag
+// or `flag:"-"`.
+func copyParsedFields(src, dst reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
+		if field.PkgPath != "" || tag.Ignored(field.Type) {
+			continue
+		}
+
+		if field.Tag != "" {
+			mtag, skip, err := tag.GetFieldTag(field)
+			if name, _ := mtag.Get("flag"); skip || err != nil || name == "-" {
+				continue
+			}
+		}
+
+		dst.Field(i).Set(src.Field(i))
+	}
+}
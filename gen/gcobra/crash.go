@@ -0,0 +1,88 @@
+package gcobra
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// crashAnnotation is the root command annotation enabling crash reports,
+// holding the directory in which they are written.
+const crashAnnotation = "crash-reports"
+
+// WithCrashReports recovers the panics of the commands of the tree when they
+// are executed: a crash report (command, version, panic and stack) is printed
+// on their error output, and written to a file in dir (the temporary directory
+// if empty), and the execution returns a *CrashError instead of crashing.
+func WithCrashReports(dir string) Option {
+	return func(opts *options) {
+		opts.crashes = true
+		opts.crashDir = dir
+	}
+}
+
+// CrashError is returned by commands having panicked when executed, with crash reports.
+type CrashError struct {
+	Command string      // Path of the command, like "app remote add"
+	Version string      // Version of the root command
+	Value   interface{} // Value given to panic
+	Stack   []byte      // Stack of the panicking goroutine
+	File    string      // Path to the crash report, if it could be written
+}
+
+func (e *CrashError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%s: panic: %v", e.Command, e.Value)
+	}
+
+	return fmt.Sprintf("%s: panic: %v (crash report written to %s)", e.Command, e.Value, e.File)
+}
+
+// guard runs fn, recovering its panics as a *CrashError
+// if the command tree of cmd reports crashes.
+func guard(cmd *cobra.Command, fn func() error) (err error) {
+	dir, enabled := cmd.Root().Annotations[crashAnnotation]
+	if !enabled {
+		return fn()
+	}
+
+	defer func() {
+		if value := recover(); value != nil {
+			err = crashReport(cmd, dir, value, debug.Stack())
+		}
+	}()
+
+	return fn()
+}
+
+// crashReport prints and writes the report of a command panic, and returns it as an error.
+func crashReport(cmd *cobra.Command, dir string, value interface{}, stack []byte) *CrashError {
+	crash := &CrashError{
+		Command: cmd.CommandPath(),
+		Version: cmd.Root().Version,
+		Value:   value,
+		Stack:   stack,
+	}
+
+	report := &bytes.Buffer{}
+	fmt.Fprintf(report, "command: %s\n", crash.Command)
+	fmt.Fprintf(report, "version: %s\n", crash.Version)
+	fmt.Fprintf(report, "time:    %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(report, "panic:   %v\n\n", crash.Value)
+	report.Write(crash.Stack)
+
+	if file, err := os.CreateTemp(dir, cmd.Root().Name()+"-crash-*.log"); err == nil {
+		if _, err = file.Write(report.Bytes()); err == nil {
+			crash.File = file.Name()
+		}
+		file.Close()
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", report.Bytes())
+
+	return crash
+}
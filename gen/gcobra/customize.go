@@ -0,0 +1,18 @@
+package gcobra
+
+import "github.com/spf13/cobra"
+
+// Customizer is implemented by command structs setting fields of their generated
+// cobra command which sflags does not model, like SilenceErrors, SuggestFor or
+// custom Args validators. Customize is called once the command is built, with
+// its flags, arguments and subcommands, and bound to its parent command.
+type Customizer interface {
+	Customize(cmd *cobra.Command)
+}
+
+// customize passes a command to its struct, if it implements Customizer.
+func customize(cmd *cobra.Command, data interface{}) {
+	if customizer, ok := data.(Customizer); ok {
+		customizer.Customize(cmd)
+	}
+}
@@ -0,0 +1,72 @@
+package gcobra
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags"
+)
+
+const defaultAnnotation = "default-command"
+
+// setDefaultCommand makes a command with subcommands run one of them when invoked
+// without any: the one named by the command struct, if it implements
+// sflags.DefaultCommander, or else the one tagged with `default:"true"`.
+func setDefaultCommand(cmd *cobra.Command, data interface{}) {
+	name := ""
+	if defaulter, ok := data.(sflags.DefaultCommander); ok {
+		name = defaulter.DefaultCommand()
+	}
+
+	var child *cobra.Command
+
+	for _, sub := range cmd.Commands() {
+		if (name != "" && sub.Name() == name) || (name == "" && sub.Annotations[defaultAnnotation] != "") {
+			child = sub
+
+			break
+		}
+	}
+
+	if child == nil {
+		return
+	}
+
+	// The words of the command are the arguments of its default subcommand.
+	validate := child.Args
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		if validate == nil {
+			return nil
+		}
+
+		return validate(child, args)
+	}
+
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		return runDefault(child, args)
+	}
+}
+
+// runDefault runs the pre/run/post implementations of a default subcommand.
+// Only the persistent pre-runs of its parents are run, by cobra, and its flags
+// keep their values: they cannot be given along with those of its parent.
+func runDefault(cmd *cobra.Command, args []string) error {
+	if cmd.RunE == nil {
+		return cmd.Help()
+	}
+
+	if cmd.PreRunE != nil {
+		if err := cmd.PreRunE(cmd, args); err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.RunE(cmd, args); err != nil {
+		return err
+	}
+
+	if cmd.PostRunE != nil {
+		return cmd.PostRunE(cmd, args)
+	}
+
+	return nil
+}
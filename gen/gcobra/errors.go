@@ -3,6 +3,13 @@ package gcobra
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
 )
 
 var (
@@ -29,9 +36,119 @@ var (
 	ErrShortNameTooLong = errors.New("short names can only be 1 character long")
 
 	ErrRequired = errors.New("required argument")
+
+	// ErrCommandExists is returned when adding a command with the name
+	// (or an alias) of an existing command of its parent.
+	ErrCommandExists = errors.New("command already exists")
+
+	// ErrUnexpectedArg is returned by strict commands given
+	// more arguments than their positionals can hold.
+	ErrUnexpectedArg = errors.New("unexpected argument")
 )
 
 // simple wrapper for errors.
 func newError(err error, msg string) error {
 	return fmt.Errorf("%w: %s", err, msg)
 }
+
+// collector returns the list in which the flags of a command record the
+// errors of their values, so that all invalid flags are reported at once.
+// The list is shared by all the flags of the command, and found on them.
+func collector(cmd *cobra.Command) *sflags.Errors {
+	if errs := flagErrors(cmd); errs != nil {
+		return errs
+	}
+
+	return &sflags.Errors{}
+}
+
+// flagErrors returns the list of errors of the flags of a command (not
+// those of the persistent flags of its parents, merged with them), if any.
+func flagErrors(cmd *cobra.Command) (errs *sflags.Errors) {
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if errs == nil {
+			errs = sflags.Collector(flag.Value)
+		}
+	})
+
+	return errs
+}
+
+// collectedErrors returns, and forgets, the errors recorded by the flags of a
+// command and of its parents (whose persistent flags are parsed along), with
+// err if not nil. It returns nil without errors, or the only one if alone.
+func collectedErrors(cmd *cobra.Command, err error) error {
+	var all sflags.Errors
+
+	for parent := cmd; parent != nil; parent = parent.Parent() {
+		if errs := flagErrors(parent); errs != nil {
+			all = append(all, *errs...)
+			*errs = nil
+		}
+	}
+
+	var list sflags.Errors
+	if errors.As(err, &list) {
+		return append(all, list...).Err()
+	} else if err != nil {
+		all = append(all, err)
+	}
+
+	return all.Err()
+}
+
+// flagError returns the flag error function of commands traced by tracer:
+// parsing stops on errors like unknown flags, which are reported along with
+// the invalid flag values, and the flags closest to them.
+func flagError(tracer Tracer) func(*cobra.Command, error) error {
+	return func(cmd *cobra.Command, err error) error {
+		err = collectedErrors(cmd, suggestFlags(cmd, redactSecrets(cmd, err)))
+
+		// Traced commands have a span for their failed parsing.
+		_, span := startSpan(cmd, tracer)
+		span.Stage(StageParse)
+		span.End(err)
+
+		return err
+	}
+}
+
+// helpRun is the run of commands without implementation, or requiring a
+// subcommand: they print their help, unless some of their flags are invalid,
+// or they are given an unknown subcommand.
+func helpRun(cmd *cobra.Command, args []string) error {
+	if err := collectedErrors(cmd, unknownCommand(cmd, args)); err != nil {
+		return err
+	}
+
+	return cmd.Help()
+}
+
+// invalidArgument matches the errors of pflag for invalid flag values.
+var invalidArgument = regexp.MustCompile(`^invalid argument (".*") for "(?:-., )?--([^"]+)" flag: `)
+
+// redactSecrets is the flag error function of commands, which
+// hides the values of secret flags from parsing errors.
+func redactSecrets(cmd *cobra.Command, err error) error {
+	match := invalidArgument.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	flag := cmd.Flags().Lookup(match[2])
+	if flag == nil || !hasAnnotation(flag, "secret") {
+		return err
+	}
+
+	return errors.New(strings.Replace(err.Error(), match[1], `"********"`, 1))
+}
+
+func hasAnnotation(flag *pflag.Flag, annotation string) bool {
+	for _, annot := range flag.Annotations["sflags"] {
+		if annot == annotation {
+			return true
+		}
+	}
+
+	return false
+}
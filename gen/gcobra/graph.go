@@ -0,0 +1,183 @@
+package gcobra
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// WriteDOT writes the tree of commands under cmd as a graphviz DOT digraph.
+// Each command is a node labelled with its name and number of flags, and
+// the subcommands belonging to a group are clustered under its title.
+// Hidden, deprecated and help commands are not included.
+func WriteDOT(out io.Writer, cmd *cobra.Command) error {
+	graph := &strings.Builder{}
+
+	fmt.Fprintf(graph, "digraph %q {\n", cmd.Name())
+	fmt.Fprintln(graph, "\tnode [shape=box];")
+
+	fmt.Fprintf(graph, "\t%q [label=%q];\n", cmd.CommandPath(), graphLabel(cmd, "\n"))
+
+	var clusters int
+
+	walkGraph(cmd, func(parent *cobra.Command, group string, children []*cobra.Command) {
+		indent := "\t"
+		if group != "" {
+			fmt.Fprintf(graph, "\tsubgraph \"cluster_%d\" {\n", clusters)
+			fmt.Fprintf(graph, "\t\tlabel=%q;\n", groupTitle(parent, group))
+			indent = "\t\t"
+			clusters++
+		}
+
+		for _, child := range children {
+			fmt.Fprintf(graph, "%s%q [label=%q];\n", indent, child.CommandPath(), graphLabel(child, "\n"))
+		}
+
+		if group != "" {
+			fmt.Fprintln(graph, "\t}")
+		}
+
+		for _, child := range children {
+			fmt.Fprintf(graph, "\t%q -> %q;\n", parent.CommandPath(), child.CommandPath())
+		}
+	})
+
+	fmt.Fprintln(graph, "}")
+
+	_, err := io.WriteString(out, graph.String())
+
+	return err
+}
+
+// WriteMermaid writes the tree of commands under cmd as a mermaid flowchart,
+// with the same nodes and clusters as WriteDOT.
+func WriteMermaid(out io.Writer, cmd *cobra.Command) error {
+	graph := &strings.Builder{}
+	nodes := map[*cobra.Command]string{}
+
+	node := func(c *cobra.Command) string {
+		if _, found := nodes[c]; !found {
+			nodes[c] = fmt.Sprintf("n%d", len(nodes))
+		}
+
+		return nodes[c]
+	}
+
+	fmt.Fprintln(graph, "graph TD")
+
+	fmt.Fprintf(graph, "\t%s[\"%s\"]\n", node(cmd), mermaidEscape(graphLabel(cmd, "<br/>")))
+
+	var clusters int
+
+	walkGraph(cmd, func(parent *cobra.Command, group string, children []*cobra.Command) {
+		indent := "\t"
+		if group != "" {
+			fmt.Fprintf(graph, "\tsubgraph g%d [\"%s\"]\n", clusters, mermaidEscape(groupTitle(parent, group)))
+			indent = "\t\t"
+			clusters++
+		}
+
+		for _, child := range children {
+			fmt.Fprintf(graph, "%s%s[\"%s\"]\n", indent, node(child), mermaidEscape(graphLabel(child, "<br/>")))
+		}
+
+		if group != "" {
+			fmt.Fprintln(graph, "\tend")
+		}
+
+		for _, child := range children {
+			fmt.Fprintf(graph, "\t%s --> %s\n", node(parent), node(child))
+		}
+	})
+
+	_, err := io.WriteString(out, graph.String())
+
+	return err
+}
+
+// walkGraph calls visit for each group of available subcommands of a command,
+// the ungrouped ones first (having an empty group), then the others in the
+// order of the groups of the command, and then recursively for each of them.
+func walkGraph(cmd *cobra.Command, visit func(parent *cobra.Command, group string, children []*cobra.Command)) {
+	var groups []string
+
+	children := map[string][]*cobra.Command{}
+
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() {
+			continue
+		}
+
+		if _, found := children[sub.Group]; !found {
+			groups = append(groups, sub.Group)
+		}
+
+		children[sub.Group] = append(children[sub.Group], sub)
+	}
+
+	// Groups of commands unknown to their parent come last.
+	order := map[string]int{"": -1}
+	for i, group := range cmd.Groups() {
+		order[group.Group] = i
+	}
+
+	rank := func(group string) int {
+		if i, found := order[group]; found {
+			return i
+		}
+
+		return len(order)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return rank(groups[i]) < rank(groups[j])
+	})
+
+	for _, group := range groups {
+		visit(cmd, group, children[group])
+	}
+
+	for _, group := range groups {
+		for _, sub := range children[group] {
+			walkGraph(sub, visit)
+		}
+	}
+}
+
+// graphLabel returns the name of a command and
+// its number of flags, on lines separated by sep.
+func graphLabel(cmd *cobra.Command, sep string) string {
+	var count int
+
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden && flag.Name != "help" {
+			count++
+		}
+	})
+
+	if count == 1 {
+		return cmd.Name() + sep + "1 flag"
+	}
+
+	return fmt.Sprintf("%s%s%d flags", cmd.Name(), sep, count)
+}
+
+// groupTitle returns the title of a group of subcommands, or its name if untitled.
+func groupTitle(cmd *cobra.Command, name string) string {
+	for _, group := range cmd.Groups() {
+		if group.Group == name && group.Title != "" {
+			return group.Title
+		}
+	}
+
+	return name
+}
+
+// mermaidEscape escapes the quotes of a mermaid label.
+func mermaidEscape(label string) string {
+	return strings.ReplaceAll(label, `"`, "#quot;")
+}
@@ -2,8 +2,10 @@ package gcobra
 
 import (
 	"reflect"
+	"sort"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/octago/sflags"
 	"github.com/octago/sflags/gen/gpflag"
@@ -13,16 +15,40 @@ import (
 
 // flagScan builds a small struct field handler so that we can scan
 // it as an option and add it to our current command flags.
-func flagScan(cmd *cobra.Command) scan.Handler {
+func flagScan(cmd *cobra.Command, scanned *commandScan, data interface{}) scan.Handler {
 	flagScanner := func(val reflect.Value, sfield *reflect.StructField) (bool, error) {
 		// Parse a single field, returning one or more generic Flags
-		flags, found := sflags.ParseField(val, *sfield)
+		flags, found := sflags.ParseField(val, *sfield,
+			sflags.Stdin(commandInput{cmd}),
+			sflags.CollectErrors(collector(cmd)),
+			sflags.Profiles(scanned.settings.profiles),
+			secretPrompt(cmd),
+		)
 		if !found {
 			return false, nil
 		}
 
-		// Put these flags into the command's flagset.
-		gpflag.GenerateTo(flags, cmd.Flags())
+		// The description might have been generated from the field comments.
+		if len(flags) == 1 && flags[0].Usage == "" && data != nil {
+			owner := reflect.Indirect(reflect.ValueOf(data)).Type()
+			flags[0].Usage, _ = sflags.Description(owner, sfield.Name)
+		}
+
+		// Put these flags into the command's flagsets.
+		var local, persistent []*sflags.Flag
+
+		for _, flag := range flags {
+			if flag.Persistent {
+				persistent = append(persistent, flag)
+			} else {
+				local = append(local, flag)
+			}
+		}
+
+		gpflag.GenerateTo(local, cmd.Flags())
+		gpflag.GenerateTo(persistent, cmd.PersistentFlags())
+		markRequired(cmd.Flags())
+		markRequired(cmd.PersistentFlags())
 
 		return true, nil
 	}
@@ -31,7 +57,9 @@ func flagScan(cmd *cobra.Command) scan.Handler {
 }
 
 // flagsGroup finds if a field is marked as a subgroup of options, and if yes, scans it recursively.
-func flagsGroup(cmd *cobra.Command, val reflect.Value, sfield *reflect.StructField) (bool, error) {
+// The group is that of the commands of the struct being scanned, inherited by those of untitled
+// groups of commands, like `commands:""`.
+func flagsGroup(cmd *cobra.Command, scanned *commandScan, group *cobra.Group, val reflect.Value, sfield *reflect.StructField, data interface{}) (bool, error) {
 	mtag, skip, err := tag.GetFieldTag(*sfield)
 	if err != nil {
 		return true, err
@@ -39,10 +67,8 @@ func flagsGroup(cmd *cobra.Command, val reflect.Value, sfield *reflect.StructFie
 		return false, nil
 	}
 
-	var groupName string
-
 	legacyGroup, legacyIsSet := mtag.Get("group")
-	optionsGroup, optionsIsSet := mtag.Get("options")
+	_, optionsIsSet := mtag.Get("options")
 	commandGroup, commandsIsSet := mtag.Get("commands")
 	description, _ := mtag.Get("description")
 
@@ -62,39 +88,27 @@ func flagsGroup(cmd *cobra.Command, val reflect.Value, sfield *reflect.StructFie
 		ptrval = val.Addr()
 	}
 
-	// then settle on the name of the group, and the type of
-	// scan we must launch on it thereof.
-	if legacyIsSet {
-		groupName = legacyGroup
-	} else if optionsIsSet {
-		groupName = optionsGroup
-	}
-
-	// A group of options ("group" is the legacy name)
+	// A group of options ("group" is the legacy name), which is
+	// not a group of commands, although they share the tag.
 	if legacyIsSet && legacyGroup != "" {
-		cmd.AddGroup(&cobra.Group{
-			Group: groupName,
-			Title: description,
-		})
-
-		err := addFlagSet(cmd, mtag, ptrval.Interface())
+		err := addFlagSet(cmd, scanned, mtag, ptrval.Interface())
 
 		return true, err
 	}
 
 	// Or a group of commands and options
 	if commandsIsSet {
-		var group *cobra.Group
 		if !isStringFalsy(commandGroup) {
-			group = &cobra.Group{
+			group = addGroup(cmd, &cobra.Group{
 				Group: commandGroup,
 				Title: description,
-			}
-			cmd.AddGroup(group)
+			})
 		}
 
 		// Parse for commands
-		scannerCommand := scanCommand(cmd, group)
+		sflags.ApplyDefaults(ptrval)
+
+		scannerCommand := scanCommand(cmd, scanned, group, data)
 		err := scan.Type(ptrval.Interface(), scannerCommand)
 
 		return true, err
@@ -104,8 +118,80 @@ func flagsGroup(cmd *cobra.Command, val reflect.Value, sfield *reflect.StructFie
 	return false, nil
 }
 
+// WithGroups declares groups of commands, by their ID (the name given in the
+// `commands` or `group` tags) and title, in the order in which they are listed
+// in help and graphs, before the groups only found in tags. Groups declared
+// here are titled and ordered wherever they are used in the command tree,
+// their titles replacing those given in the `description` tags.
+//
+//	gcobra.Parse(&data, gcobra.WithGroups(
+//	    &cobra.Group{Group: "core", Title: "Core commands"},
+//	    &cobra.Group{Group: "remote", Title: "Remote commands"},
+//	))
+func WithGroups(groups ...*cobra.Group) Option {
+	return func(opts *options) { opts.groups = append(opts.groups, groups...) }
+}
+
+// addGroup adds a group of commands to cmd, unless it already has one with the
+// same ID, which is then titled if it is not yet. Returns the group of cmd.
+func addGroup(cmd *cobra.Command, group *cobra.Group) *cobra.Group {
+	for _, existing := range cmd.Groups() {
+		if existing.Group == group.Group {
+			if existing.Title == "" {
+				existing.Title = group.Title
+			}
+
+			return existing
+		}
+	}
+
+	cmd.AddGroup(group)
+
+	return group
+}
+
+// setGroups titles and orders the groups of commands of a
+// command tree, with those declared with WithGroups.
+func setGroups(cmd *cobra.Command, declared []*cobra.Group) {
+	if len(declared) == 0 {
+		return
+	}
+
+	order := map[string]int{}
+
+	for i, group := range declared {
+		if _, found := order[group.Group]; !found {
+			order[group.Group] = i
+		}
+	}
+
+	groups := cmd.Groups()
+
+	for _, group := range groups {
+		if i, found := order[group.Group]; found && declared[i].Title != "" {
+			group.Title = declared[i].Title
+		}
+	}
+
+	// Declared groups come first, in their order, then the others as found.
+	sort.SliceStable(groups, func(i, j int) bool {
+		first, firstDeclared := order[groups[i].Group]
+		second, secondDeclared := order[groups[j].Group]
+
+		if firstDeclared && secondDeclared {
+			return first < second
+		}
+
+		return firstDeclared && !secondDeclared
+	})
+
+	for _, sub := range cmd.Commands() {
+		setGroups(sub, declared)
+	}
+}
+
 // addFlagSet scans a struct (potentially nested) for flag sets to bind to the command.
-func addFlagSet(cmd *cobra.Command, mtag tag.MultiTag, data interface{}) error {
+func addFlagSet(cmd *cobra.Command, scanned *commandScan, mtag tag.MultiTag, data interface{}) error {
 	var flagOpts []sflags.OptFunc
 
 	// New change, in order to easily propagate parent namespaces
@@ -122,25 +208,101 @@ func addFlagSet(cmd *cobra.Command, mtag tag.MultiTag, data interface{}) error {
 		flagOpts = append(flagOpts, sflags.EnvPrefix(envNamespace))
 	}
 
-	// Create a new set of flags in which we will put our options
-	flags, err := gpflag.Parse(data, flagOpts...)
+	// Options inherit the tags of their group (hidden, persistent, etc).
+	flagOpts = append(flagOpts, sflags.InheritTags(mtag))
+
+	// Values given as the stdin placeholder are read from the input of the command,
+	// and invalid values are reported with those of the other flags and arguments.
+	flagOpts = append(flagOpts, sflags.Stdin(commandInput{cmd}), sflags.CollectErrors(collector(cmd)), secretPrompt(cmd))
+
+	// The profiles of the tree are selected by a flag of the command (see setProfiles).
+	flagOpts = append(flagOpts, sflags.Profiles(scanned.settings.profiles))
+
+	parsed, err := sflags.ParseStruct(data, flagOpts...)
 	if err != nil {
 		return err
 	}
 
+	// Create a new set of flags in which we will put our options
+	flags := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	gpflag.GenerateTo(parsed, flags, boundFlag)
+
 	// hidden, _ := mtag.Get("hidden")
 	flags.SetInterspersed(true)
 
-	persistent, _ := mtag.Get("persistent")
-	if persistent != "" {
-		cmd.PersistentFlags().AddFlagSet(flags)
-	} else {
-		cmd.Flags().AddFlagSet(flags)
+	// Namespaced flags can still be looked up by their own name.
+	if namespace != "" {
+		flags.VisitAll(func(flag *pflag.Flag) {
+			flag.Annotations[namespaceAnnotation] = []string{namespace + delim}
+		})
 	}
 
+	// Options of an exclusive group cannot be used together,
+	// and options of an all-or-none group must be.
+	group, _ := mtag.Get("group")
+	if exclusive, _ := mtag.Get("exclusive"); !isStringFalsy(exclusive) {
+		gpflag.MarkExclusive(flags, group)
+	}
+	if allOrNone, _ := mtag.Get("all-or-none"); !isStringFalsy(allOrNone) {
+		gpflag.MarkRequiredTogether(flags, group)
+	}
+
+	markRequired(flags)
+
+	addFlags(cmd, flags)
+
 	return nil
 }
 
+// boundFlag selects the flags bound to struct fields, rather than
+// those added by sflags, like the flag selecting a profile.
+func boundFlag(flag *sflags.Flag) bool {
+	_, _, bound := sflags.Field(flag.Value)
+
+	return bound
+}
+
+// setProfiles adds to a command, once scanned, the flag selecting one of the
+// profiles of defaults of its tree, applied to its flags (if it has some).
+func setProfiles(cmd *cobra.Command, profiles map[string]interface{}) {
+	flags := commandFlags(cmd)
+	if len(profiles) == 0 || len(flags) == 0 {
+		return
+	}
+
+	profile := sflags.ProfileFlag(flags, sflags.Profiles(profiles))
+	if cmd.Flags().Lookup(profile.Name) == nil {
+		gpflag.GenerateTo([]*sflags.Flag{profile}, cmd.Flags())
+	}
+}
+
+// addFlags adds a set of flags to the command, as persistent
+// flags for the options which are, and as local flags otherwise.
+func addFlags(cmd *cobra.Command, flags *pflag.FlagSet) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		dst := cmd.Flags()
+		if hasAnnotation(flag, "persistent") {
+			dst = cmd.PersistentFlags()
+		}
+
+		if dst.Lookup(flag.Name) == nil {
+			dst.AddFlag(flag)
+		}
+	})
+}
+
+// markRequired marks the flags of required options as required by cobra,
+// which reports all the missing ones at once, before running the command.
+func markRequired(flags *pflag.FlagSet) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		for _, annot := range flag.Annotations["sflags"] {
+			if annot == "required" {
+				_ = cobra.MarkFlagRequired(flags, flag.Name)
+			}
+		}
+	})
+}
+
 func isStringFalsy(s string) bool {
 	return s == "" || s == "false" || s == "no" || s == "0"
 }
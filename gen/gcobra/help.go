@@ -0,0 +1,50 @@
+package gcobra
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags/internal/term"
+)
+
+// docsAnnotation is the command annotation holding the URL of its documentation.
+const docsAnnotation = "docs-url"
+
+// setHelp makes the help of a command and of its subcommands end with
+// a link to their documentation, if any, render URLs as hyperlinks on
+// terminals supporting them, and go through the pager of the user
+// when it is too long, if enabled.
+func setHelp(cmd *cobra.Command, settings options) {
+	render := cmd.HelpFunc()
+
+	cmd.SetHelpFunc(func(c *cobra.Command, args []string) {
+		out := c.OutOrStdout()
+		help := &bytes.Buffer{}
+
+		c.SetOut(help)
+		render(c, args)
+		c.SetOut(out)
+
+		if url := c.Annotations[docsAnnotation]; url != "" {
+			fmt.Fprintf(help, "\nMore info: %s\n", url)
+		}
+
+		text := help.String()
+		if term.SupportsHyperlinks(out) {
+			text = term.Linkify(text)
+		}
+
+		if !settings.pager {
+			_, _ = io.WriteString(out, text)
+
+			return
+		}
+
+		if err := term.Page(out, text); err != nil {
+			c.PrintErrln(err)
+		}
+	})
+}
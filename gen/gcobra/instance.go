@@ -0,0 +1,52 @@
+package gcobra
+
+import (
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
+)
+
+// freshAnnotation is the root command annotation enabling fresh instances.
+const freshAnnotation = "fresh-instances"
+
+// WithFreshInstances executes the commands of the tree on new instances of
+// their structs, given the values parsed from the command line, while the
+// parsed structs are reset to their defaults after each execution (see
+// sflags.Reset). Thus, fields set by a command, like the results of a previous
+// run, never leak into the next executions: this is mostly useful in closed-loop
+// applications (like consoles), which execute the same command tree repeatedly.
+func WithFreshInstances() Option {
+	return func(opts *options) { opts.fresh = true }
+}
+
+// freshInstance returns a new instance of a command
+// struct, with the values of its parsed fields.
+func freshInstance(parsed sflags.Commander) sflags.Commander {
+	parsedVal := reflect.ValueOf(parsed)
+	if parsedVal.Kind() != reflect.Ptr || parsedVal.Elem().Kind() != reflect.Struct {
+		return parsed
+	}
+
+	instance := reflect.New(parsedVal.Elem().Type())
+	copyParsedFields(parsedVal.Elem(), instance.Elem())
+
+	impl, _ := instance.Interface().(sflags.Commander)
+
+	return impl
+}
+
+// commandFlags returns the flags generated for the struct of a command.
+func commandFlags(cmd *cobra.Command) []*sflags.Flag {
+	var flags []*sflags.Flag
+
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		if parsed, _, found := sflags.Field(flag.Value); found {
+			flags = append(flags, parsed)
+		}
+	})
+
+	return flags
+}
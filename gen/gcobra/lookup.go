@@ -0,0 +1,58 @@
+package gcobra
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
+)
+
+// namespaceAnnotation is the flag annotation holding the namespace
+// of the option group a flag belongs to, like "server." for server.port.
+const namespaceAnnotation = "namespace"
+
+func init() {
+	sflags.RegisterResolver(resolveFlag)
+}
+
+// resolveFlag resolves a flag of a cobra command through its scopes: the command
+// local flags, its namespaced groups of options, and then the persistent flags of
+// its parents, from the closest to the root.
+func resolveFlag(cmd interface{}, name string) (sflags.Value, bool) {
+	command, ok := cmd.(*cobra.Command)
+	if !ok || command == nil {
+		return nil, false
+	}
+
+	if flag := lookupScope(command.LocalFlags(), name); flag != nil {
+		return flag.Value, true
+	}
+
+	for parent := command.Parent(); parent != nil; parent = parent.Parent() {
+		if flag := lookupScope(parent.PersistentFlags(), name); flag != nil {
+			return flag.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// lookupScope returns the flag with the name, either as is,
+// or once prefixed with the namespace of its option group.
+func lookupScope(flags *pflag.FlagSet, name string) *pflag.Flag {
+	if flag := flags.Lookup(name); flag != nil {
+		return flag
+	}
+
+	var found *pflag.Flag
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		for _, namespace := range flag.Annotations[namespaceAnnotation] {
+			if found == nil && flag.Name == namespace+name {
+				found = flag
+			}
+		}
+	})
+
+	return found
+}
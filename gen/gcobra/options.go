@@ -0,0 +1,106 @@
+package gcobra
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags/internal/platform"
+)
+
+// Option configures a command tree generated with Parse.
+type Option func(*options)
+
+// options holds the settings of a generated command tree.
+type options struct {
+	name    string
+	version string
+	pager   bool
+	fresh   bool
+	silence bool
+	strict  bool
+	usage   UsagePolicy
+	tracer  Tracer
+
+	unsorted bool
+
+	profiles map[string]interface{}
+
+	completions CompletionBackend
+
+	versionCmd bool
+	buildInfo  map[string]string
+
+	crashes  bool
+	crashDir string
+
+	strictArgs bool
+	posix      bool
+
+	groups []*cobra.Group
+
+	suggestions int
+
+	// scans are those of the commands of the tree, by command.
+	scans map[*cobra.Command]*commandScan
+}
+
+// WithName sets the name of the root command, which is otherwise the
+// name of the binary (without its directory). Applications hosting several command trees in one
+// process (like console menus) use it to tell their roots apart.
+func WithName(name string) Option {
+	return func(opts *options) { opts.name = name }
+}
+
+// WithVersion sets the version of the root command, which then gets a
+// --version flag printing it. It overrides the `version` tag of the root
+// struct, if any (see WithVersionCommand).
+func WithVersion(version string) Option {
+	return func(opts *options) { opts.version = version }
+}
+
+// WithSilenceUsage prevents the commands from printing their usage
+// when they fail, whether to parse their arguments or when executed.
+func WithSilenceUsage() Option {
+	return func(opts *options) { opts.silence = true }
+}
+
+// WithRequiredTags makes Parse fail when an exported field of the command
+// structs has no tags, so that all fields are explicitly either exposed as
+// options, arguments and commands, or excluded (see sflags.RequireTags).
+func WithRequiredTags() Option {
+	return func(opts *options) { opts.strict = true }
+}
+
+// WithProfiles declares named sets of default values for the flags of the
+// commands, selected with a --profile flag added to each command with flags
+// (see sflags.Profiles). Each profile is a map of flag names to their values:
+// those not found on the command fail when it is selected.
+func WithProfiles(profiles map[string]interface{}) Option {
+	return func(opts *options) { opts.profiles = profiles }
+}
+
+// WithPager pages the help output of the commands through the pager of the
+// user ($PAGER, or less), when it is longer than the height of the terminal.
+// The help is printed as usual when stdout is not a terminal.
+func WithPager() Option {
+	return func(opts *options) { opts.pager = true }
+}
+
+func newOptions(opts ...Option) options {
+	settings := options{name: platform.ProgramName(), scans: map[*cobra.Command]*commandScan{}}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	return settings
+}
+
+// argsOf returns the words left by the last parsing of a command of the tree,
+// to be passed to its Execute(args) implementation. Commands of other trees
+// (see AddCommand) are not found, and have none.
+func (o *options) argsOf(cmd *cobra.Command) []string {
+	if scanned, found := o.scans[cmd]; found {
+		return scanned.args
+	}
+
+	return nil
+}
@@ -75,6 +75,25 @@ func TestPointerPrimitiveFlags(t *testing.T) {
 	test.Equal(map[string]int{"k1": 2, "k2": -5}, *data.Map)
 }
 
+// TestPointerPrimitiveUnset checks that pointers to primitive types
+// are left nil when their flags are not given on the command-line.
+func TestPointerPrimitiveUnset(t *testing.T) {
+	t.Parallel()
+
+	data := pointerRoot{}
+
+	root := newCommandWithArgs(&data, []string{"-s", ""})
+	cmd, err := root.ExecuteC()
+
+	test := assert.New(t)
+	test.NotNil(cmd)
+	test.Nil(err, "Command should have exited successfully")
+
+	test.Nil(data.Bool, "flag -v was not given")
+	test.NotNil(data.String, "flag -s was given an empty value")
+	test.Equal("", *data.String)
+}
+
 // TestPointerGroup checks that pointers to a struct marked as a group
 // (either a command group, or an option one), are correctly initialized
 // and parse their values accordingly.
@@ -2,16 +2,17 @@ package gcobra
 
 import (
 	"reflect"
-	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/octago/sflags"
 	"github.com/octago/sflags/internal/positional"
 	"github.com/octago/sflags/internal/tag"
 )
 
 // positionals finds a struct tagged as containing positionals arguments and scans them.
-func positionals(cmd *cobra.Command, stag tag.MultiTag, val reflect.Value) (bool, error) {
+// If the command data implements sflags.ArgValidator, it is used to validate each word.
+func positionals(cmd *cobra.Command, scanned *commandScan, stag tag.MultiTag, val reflect.Value, data interface{}) (bool, error) {
 	// We need the struct to be marked as such
 	if pargs, _ := stag.Get("positional-args"); len(pargs) == 0 {
 		return false, nil
@@ -26,6 +27,16 @@ func positionals(cmd *cobra.Command, stag tag.MultiTag, val reflect.Value) (bool
 		return true, err
 	}
 
+	// The command might validate its arguments against its other values.
+	if validator, ok := data.(sflags.ArgValidator); ok {
+		positionals = positional.WithWordValidator(positionals, func(arg *positional.Arg, word string) error {
+			return validator.ValidateArg(arg.Name, word)
+		})
+	}
+
+	// Words standing for stdin are read from the input of the command.
+	positionals = positional.WithStdin(positionals, commandInput{cmd})
+
 	// Finally, assemble all the parsers into our cobra Args function.
 	cmd.Args = func(cmd *cobra.Command, args []string) error {
 		// Apply the words on the all/some of the positional fields,
@@ -36,37 +47,21 @@ func positionals(cmd *cobra.Command, stag tag.MultiTag, val reflect.Value) (bool
 		// Once we have consumed the words we wanted, we update the
 		// command's return (non-consummed) arguments, to be passed
 		// later to the Execute(args []string) implementation.
-		defer setRemainingArgs(cmd, retargs)
+		scanned.args = retargs
 
-		// Directly return the error, which might be non-nil.
-		return err
+		// Return the errors of the arguments with those of the flags.
+		return collectedErrors(cmd, err)
 	}
 
 	return true, nil
 }
 
-func setRemainingArgs(cmd *cobra.Command, retargs []string) {
-	if len(retargs) == 0 || retargs == nil || cmd == nil {
-		return
-	}
-
-	if cmd.Annotations == nil {
-		cmd.Annotations = map[string]string{}
-	}
-	// Add these arguments in an annotation to be used
-	// in our Run implementation, where we pass just the
-	// unparsed positional arguments to the command Execute(args []string).
-	cmd.Annotations["sflags"] = strings.Join(retargs, " ")
+// commandInput reads from the input of a command (see cobra.Command.SetIn),
+// as set when values are read rather than when they are generated.
+type commandInput struct {
+	cmd *cobra.Command
 }
 
-func getRemainingArgs(cmd *cobra.Command) (args []string) {
-	if cmd.Annotations == nil {
-		return
-	}
-
-	if argString, found := cmd.Annotations["sflags"]; found {
-		return strings.Split(argString, " ")
-	}
-
-	return
+func (in commandInput) Read(p []byte) (int, error) {
+	return in.cmd.InOrStdin().Read(p)
 }
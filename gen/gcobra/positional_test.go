@@ -2,11 +2,21 @@ package gcobra
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/octago/sflags"
 )
 
 // Tests partially ported from github.com/jessevdk/go-flags/arg_test.go,
@@ -434,3 +444,527 @@ func newCommandWithArgs(data interface{}, args []string) *cobra.Command {
 
 	return cmd
 }
+
+// validatedArgs is a command with validated positional arguments.
+type validatedArgs struct {
+	Positional struct {
+		Port  int      `validate:"min=1,max=65535"`
+		Hosts []string `validate:"regexp=^[a-z.]+$"`
+	} `positional-args:"yes"`
+}
+
+func (*validatedArgs) Execute(args []string) error { return nil }
+
+// TestPositionalValidate checks that positional arguments are validated
+// with their `validate` tag, and that errors mention the positional name.
+func TestPositionalValidate(t *testing.T) {
+	t.Parallel()
+
+	opts := validatedArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"22", "example.com"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal(22, opts.Positional.Port)
+	pt.Equal([]string{"example.com"}, opts.Positional.Hosts)
+
+	cmd = newCommandWithArgs(&opts, []string{"70000"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Port`: 70000 must be lower than or equal to 65535")
+
+	cmd = newCommandWithArgs(&opts, []string{"22", "a.com", "B.com"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Hosts`: \"B.com\" does not match ^[a-z.]+$")
+
+	cmd = newCommandWithArgs(&opts, []string{"port"})
+	_, err = cmd.ExecuteC()
+	pt.ErrorContains(err, "invalid argument for `Port`: ")
+}
+
+// choiceArgs is a command with positional arguments restricted to some choices.
+type choiceArgs struct {
+	Positional struct {
+		Format string `choices:"json yaml"`
+	} `positional-args:"yes"`
+}
+
+func (*choiceArgs) Execute(args []string) error { return nil }
+
+// TestPositionalChoices checks that positional arguments
+// are refused when they are not one of the allowed choices.
+func TestPositionalChoices(t *testing.T) {
+	t.Parallel()
+
+	opts := choiceArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"yaml"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal("yaml", opts.Positional.Format)
+
+	cmd = newCommandWithArgs(&opts, []string{"xml"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Format`: invalid choice \"xml\": must be one of json, yaml")
+}
+
+// regionArgs has a positional referencing a named set of choices.
+type regionArgs struct {
+	Positional struct {
+		Region string `choices-ref:"test-regions"`
+	} `positional-args:"yes"`
+}
+
+func (*regionArgs) Execute(args []string) error { return nil }
+
+// TestPositionalChoicesRef checks that positionals can reference
+// sets of choices declared with sflags.Choices.
+func TestPositionalChoicesRef(t *testing.T) {
+	t.Parallel()
+
+	sflags.Choices("test-regions", "eu-west", "us-east")
+
+	opts := regionArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"eu-west"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal("eu-west", opts.Positional.Region)
+
+	cmd = newCommandWithArgs(&opts, []string{"ap-south"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Region`: invalid choice \"ap-south\": must be one of eu-west, us-east")
+}
+
+// zone has its allowed values only known at runtime.
+type zone string
+
+func (zone) Choices() []sflags.Choice {
+	return []sflags.Choice{{Value: "a"}, {Value: "b", Description: "backup zone"}}
+}
+
+// zoneArgs has positionals providing their own choices.
+type zoneArgs struct {
+	Positional struct {
+		Zones []zone
+	} `positional-args:"yes"`
+}
+
+func (*zoneArgs) Execute(args []string) error { return nil }
+
+// TestPositionalChoiceProvider checks that positionals can
+// have their allowed values provided at runtime.
+func TestPositionalChoiceProvider(t *testing.T) {
+	t.Parallel()
+
+	opts := zoneArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"a", "b"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal([]zone{"a", "b"}, opts.Positional.Zones)
+
+	opts = zoneArgs{}
+	cmd = newCommandWithArgs(&opts, []string{"a", "c"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Zones`: invalid choice \"c\": must be one of a, b")
+}
+
+// profileArgs is a command whose valid positional targets depend on a flag.
+type profileArgs struct {
+	Profile    string `long:"profile"`
+	Positional struct {
+		Target string
+	} `positional-args:"yes"`
+}
+
+func (*profileArgs) Execute(args []string) error { return nil }
+
+func (p *profileArgs) ValidateArg(name, word string) error {
+	targets := map[string][]string{
+		"dev":  {"local", "staging"},
+		"prod": {"eu", "us"},
+	}
+
+	for _, target := range targets[p.Profile] {
+		if word == target {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q is not a target of the %q profile", word, p.Profile)
+}
+
+type payloadArgs struct {
+	Positional struct {
+		Payload string `expand-file:"true"`
+	} `positional-args:"yes"`
+}
+
+func (*payloadArgs) Execute(args []string) error { return nil }
+
+// TestPositionalExpandFile checks that positionals
+// given as @path are read from files, when enabled.
+func TestPositionalExpandFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "payload.json")
+	pt := assert.New(t)
+	pt.Nil(os.WriteFile(path, []byte("{\"id\": 1}\n"), 0o600))
+
+	opts := payloadArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"@" + path})
+	_, err := cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal(`{"id": 1}`, opts.Positional.Payload)
+
+	cmd = newCommandWithArgs(&opts, []string{"@@handle"})
+	_, err = cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal("@handle", opts.Positional.Payload)
+
+	cmd = newCommandWithArgs(&opts, []string{"@" + path + ".missing"})
+	_, err = cmd.ExecuteC()
+	pt.ErrorIs(err, os.ErrNotExist)
+}
+
+// TestPositionalDependsOnFlag checks that a command implementing
+// sflags.ArgValidator can validate its positionals against its flags.
+func TestPositionalDependsOnFlag(t *testing.T) {
+	t.Parallel()
+
+	opts := profileArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"--profile", "prod", "eu"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal("eu", opts.Positional.Target)
+
+	opts = profileArgs{}
+	cmd = newCommandWithArgs(&opts, []string{"--profile", "dev", "eu"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Target`: \"eu\" is not a target of the \"dev\" profile")
+}
+
+type listArgs struct {
+	Positional struct {
+		Hosts []string `sep:","`
+		Tags  []string `accumulate:"false" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func (*listArgs) Execute(args []string) error { return nil }
+
+// TestPositionalSliceSeparators checks that positional words are split
+// on the separator of their slice, and might replace default values.
+func TestPositionalSliceSeparators(t *testing.T) {
+	t.Parallel()
+
+	pt := assert.New(t)
+
+	opts := listArgs{}
+	opts.Positional.Hosts = []string{"localhost"}
+	opts.Positional.Tags = []string{"default"}
+
+	cmd := newCommandWithArgs(&opts, []string{"a,b", "c", "d"})
+	_, err := cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal([]string{"localhost", "a", "b", "c"}, opts.Positional.Hosts)
+	pt.Equal([]string{"d"}, opts.Positional.Tags)
+}
+
+type defaultArgs struct {
+	Positional struct {
+		Port  int      `default:"8080"`
+		Hosts []string `default:"localhost,example.com"`
+	} `positional-args:"yes"`
+}
+
+func (*defaultArgs) Execute(args []string) error { return nil }
+
+// TestPositionalDefaultTag checks that positionals are set to the
+// values of their default tags, which are replaced by any word given.
+func TestPositionalDefaultTag(t *testing.T) {
+	t.Parallel()
+
+	pt := assert.New(t)
+
+	opts := defaultArgs{}
+	cmd := newCommandWithArgs(&opts, []string{})
+	_, err := cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal(8080, opts.Positional.Port)
+	pt.Equal([]string{"localhost", "example.com"}, opts.Positional.Hosts)
+
+	opts = defaultArgs{}
+	cmd = newCommandWithArgs(&opts, []string{"22", "a", "b"})
+	_, err = cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal(22, opts.Positional.Port)
+	pt.Equal([]string{"a", "b"}, opts.Positional.Hosts)
+}
+
+type pathArgs struct {
+	Positional struct {
+		Config string   `type:"path"`
+		Files  []string `type:"path"`
+	} `positional-args:"yes"`
+}
+
+func (*pathArgs) Execute(args []string) error { return nil }
+
+// TestPositionalPathType checks that paths given
+// to positionals are expanded and made absolute.
+func TestPositionalPathType(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	pt := assert.New(t)
+
+	cwd, err := os.Getwd()
+	pt.Nil(err)
+
+	opts := pathArgs{}
+	cmd := newCommandWithArgs(&opts, []string{"~/app.yaml", "a", "$HOME/b"})
+	_, err = cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal(filepath.Join(home, "app.yaml"), opts.Positional.Config)
+	pt.Equal([]string{filepath.Join(cwd, "a"), filepath.Join(home, "b")}, opts.Positional.Files)
+}
+
+type stdinArgs struct {
+	Body       string `long:"body" stdin:"-"`
+	Positional struct {
+		Hosts []string `stdin:"-"`
+	} `positional-args:"yes"`
+}
+
+func (*stdinArgs) Execute(args []string) error { return nil }
+
+// TestPositionalStdin checks that flags and positionals given
+// their stdin placeholder read from the input of the command.
+func TestPositionalStdin(t *testing.T) {
+	t.Parallel()
+
+	pt := assert.New(t)
+
+	opts := stdinArgs{}
+	cmd := newCommandWithArgs(&opts, []string{"a", "-", "z"})
+	cmd.SetIn(strings.NewReader("b\nc\n"))
+	_, err := cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal([]string{"a", "b", "c", "z"}, opts.Positional.Hosts)
+
+	opts = stdinArgs{}
+	cmd = newCommandWithArgs(&opts, []string{"--body", "-"})
+	cmd.SetIn(strings.NewReader("hello\n"))
+	_, err = cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal("hello", opts.Body)
+}
+
+type numberArgs struct {
+	Positional struct {
+		Mode  uint32 `base:"8"`
+		Flags []int
+	} `positional-args:"yes"`
+}
+
+func (*numberArgs) Execute(args []string) error { return nil }
+
+// TestPositionalIntegerLiterals checks that integer positionals accept
+// hexadecimal, octal and binary literals, or are parsed in a forced base.
+func TestPositionalIntegerLiterals(t *testing.T) {
+	t.Parallel()
+
+	pt := assert.New(t)
+
+	opts := numberArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"755", "0x1F", "0o17", "0b1010", "042"})
+	_, err := cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Equal(uint32(0o755), opts.Positional.Mode)
+	pt.Equal([]int{31, 15, 10, 42}, opts.Positional.Flags)
+
+	cmd = newCommandWithArgs(&opts, []string{"755", "0x1G"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Flags`: invalid base 16 integer \"0x1G\": invalid syntax")
+
+	cmd = newCommandWithArgs(&opts, []string{"8"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `Mode`: invalid base 8 integer \"8\": invalid syntax")
+}
+
+// TestPositionalConvertError checks that conversion errors of positionals
+// carry the name of their field, the invalid word and the target type.
+func TestPositionalConvertError(t *testing.T) {
+	t.Parallel()
+
+	pt := assert.New(t)
+
+	opts := numberArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"755", "1", "two"})
+	_, err := cmd.ExecuteC()
+
+	var convErr *sflags.ConvertError
+	pt.ErrorAs(err, &convErr)
+	pt.Equal("Flags", convErr.Field)
+	pt.Equal("two", convErr.Word)
+	pt.Equal(reflect.TypeOf(0), convErr.TargetType)
+	pt.ErrorIs(err, strconv.ErrSyntax)
+}
+
+// coords is a type converted with a registered parser.
+type coords struct {
+	Lat, Long float64
+}
+
+// coordsArgs is a command with positionals of a type without Value implementation.
+type coordsArgs struct {
+	Positional struct {
+		From coords
+		To   []coords
+	} `positional-args:"yes"`
+}
+
+func (*coordsArgs) Execute(args []string) error { return nil }
+
+// TestPositionalRegisteredParser checks that positional arguments
+// are converted with the parser registered for their type, if any.
+func TestPositionalRegisteredParser(t *testing.T) {
+	t.Parallel()
+
+	sflags.RegisterValueParser(reflect.TypeOf(coords{}), func(val string) (interface{}, error) {
+		c := coords{}
+		if _, err := fmt.Sscanf(val, "%f:%f", &c.Lat, &c.Long); err != nil {
+			return nil, fmt.Errorf("invalid coordinates %q", val)
+		}
+
+		return c, nil
+	})
+
+	opts := coordsArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"1.5:2", "3:4", "5:6"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal(coords{1.5, 2}, opts.Positional.From)
+	pt.Equal([]coords{{3, 4}, {5, 6}}, opts.Positional.To)
+
+	cmd = newCommandWithArgs(&opts, []string{"north"})
+	_, err = cmd.ExecuteC()
+	pt.EqualError(err, "invalid argument for `From`: invalid coordinates \"north\"")
+}
+
+// textArgs is a command with positionals implementing encoding.TextUnmarshaler.
+type textArgs struct {
+	Positional struct {
+		Since time.Time
+		Hosts []net.IP
+	} `positional-args:"yes"`
+}
+
+func (*textArgs) Execute(args []string) error { return nil }
+
+// TestPositionalTextUnmarshaler checks that positional arguments are
+// converted with their encoding.TextUnmarshaler implementation, if any.
+func TestPositionalTextUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	opts := textArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"2020-01-02T03:04:05Z", "10.0.0.1", "::1"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), opts.Positional.Since)
+	pt.Equal([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")}, opts.Positional.Hosts)
+
+	cmd = newCommandWithArgs(&opts, []string{"yesterday"})
+	_, err = cmd.ExecuteC()
+	pt.Error(err)
+}
+
+// stdlibArgs is a command with positionals of standard library types.
+type stdlibArgs struct {
+	Positional struct {
+		Day     time.Time `layout:"2006-01-02"`
+		Timeout time.Duration
+		Mirrors []url.URL
+	} `positional-args:"yes"`
+}
+
+func (*stdlibArgs) Execute(args []string) error { return nil }
+
+// TestPositionalStdlibTypes checks that positional arguments of standard
+// library types are converted, with their tag specifications if any.
+func TestPositionalStdlibTypes(t *testing.T) {
+	t.Parallel()
+
+	opts := stdlibArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"2020-01-02", "1m", "http://a", "http://b/pub"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), opts.Positional.Day)
+	pt.Equal(time.Minute, opts.Positional.Timeout)
+	pt.Len(opts.Positional.Mirrors, 2)
+	pt.Equal("/pub", opts.Positional.Mirrors[1].Path)
+
+	cmd = newCommandWithArgs(&opts, []string{"02/01/2020"})
+	_, err = cmd.ExecuteC()
+	pt.Error(err)
+}
+
+// remainderArgs records the words left by its positional fields.
+type remainderArgs struct {
+	Positional struct {
+		Name string
+	} `positional-args:"yes"`
+
+	args []string
+}
+
+func (r *remainderArgs) Execute(args []string) error {
+	r.args = args
+
+	return nil
+}
+
+// TestPositionalRemainingWords checks that the words not consumed by the positional
+// fields are given to the command as they were, even with spaces or when empty.
+func TestPositionalRemainingWords(t *testing.T) {
+	t.Parallel()
+
+	opts := remainderArgs{}
+
+	cmd := newCommandWithArgs(&opts, []string{"name", "two words", "", "last"})
+	_, err := cmd.ExecuteC()
+
+	pt := assert.New(t)
+	pt.Nil(err)
+	pt.Equal("name", opts.Positional.Name)
+	pt.Equal([]string{"two words", "", "last"}, opts.args)
+
+	cmd = newCommandWithArgs(&opts, []string{"name"})
+	_, err = cmd.ExecuteC()
+	pt.Nil(err)
+	pt.Empty(opts.args)
+}
@@ -0,0 +1,22 @@
+package gcobra
+
+import "github.com/spf13/cobra"
+
+// posixAnnotation is the annotation of commands parsing their flags POSIX-style.
+const posixAnnotation = "posix"
+
+// WithPosixParsing makes all the commands of the tree stop parsing flags at their
+// first positional argument, like POSIX getopt: all the following words are
+// arguments, even those starting with a dash. Commands (and their subcommands)
+// can also parse their flags so with a `posix:"true"` tag on their field: this
+// is useful for commands wrapping other programs, whose flags are not swallowed.
+func WithPosixParsing() Option {
+	return func(opts *options) { opts.posix = true }
+}
+
+// setPosix makes a command, once scanned, stop parsing flags at its first argument.
+func setPosix(cmd *cobra.Command) {
+	if cmd.Annotations[posixAnnotation] != "" {
+		cmd.Flags().SetInterspersed(false)
+	}
+}
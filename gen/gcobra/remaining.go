@@ -0,0 +1,94 @@
+package gcobra
+
+import (
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// remaining finds a []string field tagged `remaining:"true"`, which receives
+// all the words not consumed by the flags and positionals of the command, in
+// addition to them being passed to its Execute(args) implementation.
+func remaining(scanned *commandScan, mtag tag.MultiTag, val reflect.Value) (bool, error) {
+	if rem, _ := mtag.Get("remaining"); isStringFalsy(rem) {
+		return false, nil
+	}
+
+	if val.Type() != reflect.TypeOf([]string(nil)) {
+		return true, newError(ErrInvalidTag, "remaining arguments must be bound to a []string field")
+	}
+
+	scanned.remaining = val
+
+	return true, nil
+}
+
+// setRemaining makes a command, once scanned, bind the words left by its
+// positionals (or all of its arguments without them) to its remaining field.
+func setRemaining(cmd *cobra.Command, scanned *commandScan) {
+	field := scanned.remaining
+	if !field.IsValid() {
+		return
+	}
+
+	validate := cmd.Args
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		var err error
+
+		// Without positionals, the command accepts any arguments.
+		// Otherwise, their parser stores the words they leave.
+		if validate != nil {
+			err = validate(c, args)
+		} else {
+			scanned.args = args
+		}
+
+		field.Set(reflect.ValueOf(scanned.args))
+
+		return err
+	}
+}
+
+// passthrough finds a []string field tagged `passthrough-args:"true"`, which
+// receives all the words after `--` verbatim: they are neither parsed as flags
+// nor as positionals, and are not passed to the Execute(args) implementation.
+func passthrough(scanned *commandScan, mtag tag.MultiTag, val reflect.Value) (bool, error) {
+	if pass, _ := mtag.Get("passthrough-args"); isStringFalsy(pass) {
+		return false, nil
+	}
+
+	if val.Type() != reflect.TypeOf([]string(nil)) {
+		return true, newError(ErrInvalidTag, "passthrough arguments must be bound to a []string field")
+	}
+
+	scanned.passthrough = val
+
+	return true, nil
+}
+
+// setPassthrough makes a command, once scanned, bind the words after `--` to its
+// passthrough field, if any, and only validate the other ones as its arguments.
+func setPassthrough(cmd *cobra.Command, scanned *commandScan) {
+	field := scanned.passthrough
+	if !field.IsValid() {
+		return
+	}
+
+	validate := cmd.Args
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		var passed []string
+		if dash := c.ArgsLenAtDash(); dash >= 0 && dash <= len(args) {
+			args, passed = args[:dash], args[dash:]
+		}
+
+		field.Set(reflect.ValueOf(append([]string(nil), passed...)))
+
+		if validate == nil {
+			return nil
+		}
+
+		return validate(c, args)
+	}
+}
@@ -0,0 +1,38 @@
+package gcobra
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/term"
+)
+
+// SecretReader can be implemented by the input of a command (see cobra.Command.SetIn)
+// to read the values of secret flags given without one (like `--password`) without
+// echoing them, like the terminals of console sessions served over the network do.
+type SecretReader interface {
+	ReadSecret(prompt string) (string, error)
+}
+
+// secretPrompt prompts for the values of the secret flags of a command on its own
+// input and error output, rather than on those of the process: with echo disabled
+// if the input is a terminal or a SecretReader, and as any other line otherwise.
+func secretPrompt(cmd *cobra.Command) sflags.OptFunc {
+	return sflags.SecretPrompt(func(flag string) (string, error) {
+		prompt := fmt.Sprintf("%s: ", flag)
+
+		switch in := cmd.InOrStdin().(type) {
+		case SecretReader:
+			return in.ReadSecret(prompt)
+		case *os.File:
+			return term.ReadSecret(in, cmd.ErrOrStderr(), prompt)
+		default:
+			fmt.Fprint(cmd.ErrOrStderr(), prompt)
+
+			return term.ReadLine(in)
+		}
+	})
+}
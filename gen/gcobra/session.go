@@ -0,0 +1,42 @@
+package gcobra
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags"
+)
+
+// sessionKey is the context key for session-scoped values.
+type sessionKey struct{}
+
+// WithSession returns a copy of ctx carrying a session-scoped value (user
+// identity, connections, loggers, etc). When a command tree is executed with
+// this context (with cmd.ExecuteContext()), any command implementing the
+// sflags.ContextSetter interface is passed this value before being executed.
+func WithSession(ctx context.Context, session interface{}) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// Session returns the session value carried by ctx, or nil if none.
+func Session(ctx context.Context) interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	return ctx.Value(sessionKey{})
+}
+
+// setSession passes the session value bound to the command
+// context, if any, to a command implementing sflags.ContextSetter.
+func setSession(cmd *cobra.Command, impl sflags.Commander) {
+	setter, ok := impl.(sflags.ContextSetter)
+	if !ok {
+		return
+	}
+
+	if session := Session(cmd.Context()); session != nil {
+		setter.SetContext(session)
+	}
+}
@@ -0,0 +1,274 @@
+package gcobra
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/positional"
+	"github.com/octago/sflags/internal/scan"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// snapshot holds the values of the commands of a tree, by command path.
+type snapshot map[string]commandState
+
+// commandState holds the values of the flags and positionals of a command,
+// by name, as their struct fields are encoded in JSON.
+type commandState struct {
+	Flags map[string]flagState       `json:"flags,omitempty"`
+	Args  map[string]json.RawMessage `json:"args,omitempty"`
+}
+
+// flagState holds the value of a flag, and whether it was given.
+type flagState struct {
+	Value   json.RawMessage `json:"value"`
+	Changed bool            `json:"changed,omitempty"`
+}
+
+// Snapshot saves the current values of all the flags and positional arguments
+// of the command tree of root, as set by previous executions or by commands, in
+// JSON. The values of secret flags are never saved. This allows console sessions
+// to be suspended, and resumed with Restore, in the same or another process.
+// The positional arguments are found on data, the struct root was parsed from.
+func Snapshot(root *cobra.Command, data interface{}) ([]byte, error) {
+	state := snapshot{}
+
+	positionals, err := commandPositionals(root.CommandPath(), data)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	walkCommands(root, func(cmd *cobra.Command) {
+		if err != nil {
+			return
+		}
+
+		var command commandState
+
+		if command.Flags, err = snapshotFlags(cmd); err != nil {
+			return
+		}
+
+		if command.Args, err = snapshotArgs(cmd, positionals[cmd.CommandPath()]); err != nil {
+			return
+		}
+
+		if len(command.Flags) > 0 || len(command.Args) > 0 {
+			state[cmd.CommandPath()] = command
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(state)
+}
+
+// Restore sets the flags and positional arguments of the command tree of root,
+// parsed from data, to the values saved by Snapshot. The commands, flags and
+// arguments no longer found in the tree are ignored, and those not saved are
+// left as they are.
+func Restore(root *cobra.Command, data interface{}, saved []byte) error {
+	var state snapshot
+	if err := json.Unmarshal(saved, &state); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	positionals, err := commandPositionals(root.CommandPath(), data)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	walkCommands(root, func(cmd *cobra.Command) {
+		command, found := state[cmd.CommandPath()]
+		if !found || err != nil {
+			return
+		}
+
+		if err = restoreFlags(cmd, command.Flags); err != nil {
+			return
+		}
+
+		err = restoreArgs(cmd, positionals[cmd.CommandPath()], command.Args)
+	})
+
+	return err
+}
+
+// snapshotFlags encodes the struct fields of the flags of a command. The flags
+// sharing a field (aliases, --no-<flag> forms) only save it once.
+func snapshotFlags(cmd *cobra.Command) (map[string]flagState, error) {
+	flags := map[string]flagState{}
+	saved := map[fieldKey]bool{}
+
+	var err error
+
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		parsed, field, found := sflags.Field(flag.Value)
+		if !found || parsed.Secret || err != nil || saved[keyOf(field)] {
+			return
+		}
+
+		saved[keyOf(field)] = true
+
+		var value []byte
+		if value, err = json.Marshal(field.Interface()); err != nil {
+			err = fmt.Errorf("snapshot: %s --%s: %w", cmd.CommandPath(), flag.Name, err)
+
+			return
+		}
+
+		flags[flag.Name] = flagState{Value: value, Changed: flag.Changed}
+	})
+
+	return flags, err
+}
+
+// restoreFlags decodes the saved values of the flags of a command onto their fields.
+func restoreFlags(cmd *cobra.Command, flags map[string]flagState) error {
+	for name, state := range flags {
+		flag := cmd.LocalFlags().Lookup(name)
+		if flag == nil {
+			continue
+		}
+
+		_, field, found := sflags.Field(flag.Value)
+		if !found {
+			continue
+		}
+
+		if err := json.Unmarshal(state.Value, field.Addr().Interface()); err != nil {
+			return fmt.Errorf("restore: %s --%s: %w", cmd.CommandPath(), name, err)
+		}
+
+		// Required flags which were given are not missing.
+		flag.Changed = state.Changed
+	}
+
+	return nil
+}
+
+// snapshotArgs encodes the struct fields of the positional arguments of a command.
+func snapshotArgs(cmd *cobra.Command, args *positional.Args) (map[string]json.RawMessage, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	values := map[string]json.RawMessage{}
+
+	for _, arg := range args.Positionals() {
+		value, err := json.Marshal(arg.Value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: %s <%s>: %w", cmd.CommandPath(), arg.Name, err)
+		}
+
+		values[arg.Name] = value
+	}
+
+	return values, nil
+}
+
+// restoreArgs decodes the saved values of the positional arguments of a command onto their fields.
+func restoreArgs(cmd *cobra.Command, args *positional.Args, values map[string]json.RawMessage) error {
+	if args == nil {
+		return nil
+	}
+
+	for _, arg := range args.Positionals() {
+		value, saved := values[arg.Name]
+		if !saved || !arg.Value.CanAddr() {
+			continue
+		}
+
+		if err := json.Unmarshal(value, arg.Value.Addr().Interface()); err != nil {
+			return fmt.Errorf("restore: %s <%s>: %w", cmd.CommandPath(), arg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// commandPositionals scans the struct of a command, at some path, for the
+// positional arguments of the command and of its subcommands, by their path.
+func commandPositionals(path string, data interface{}) (map[string]*positional.Args, error) {
+	positionals := map[string]*positional.Args{}
+	if data == nil {
+		return positionals, nil
+	}
+
+	var handler scan.Handler
+
+	handler = func(val reflect.Value, sfield *reflect.StructField) (bool, error) {
+		mtag, none, err := tag.GetFieldTag(*sfield)
+		if none || err != nil {
+			return true, err
+		}
+
+		// The positionals of the command,
+		if pargs, _ := mtag.Get("positional-args"); len(pargs) > 0 {
+			args, err := positional.ScanArgs(val, mtag)
+			if args != nil {
+				positionals[path] = args
+			}
+
+			return true, err
+		}
+
+		// those of its groups of commands,
+		if _, isSet := mtag.Get("commands"); isSet {
+			ptrval := val
+			if val.Kind() != reflect.Ptr {
+				ptrval = val.Addr()
+			} else if val.IsNil() {
+				return true, nil
+			}
+
+			return true, scan.Type(ptrval.Interface(), handler)
+		}
+
+		// and those of its subcommands.
+		name, _ := mtag.Get("command")
+		if len(name) == 0 {
+			return false, nil
+		}
+
+		if val, implements, _ := sflags.IsCommand(val); implements {
+			subs, err := commandPositionals(path+" "+name, val.Interface())
+			for sub, args := range subs {
+				positionals[sub] = args
+			}
+
+			return true, err
+		}
+
+		return true, nil
+	}
+
+	return positionals, scan.Type(data, handler)
+}
+
+// fieldKey identifies a struct field by its address and type,
+// the first field of a struct sharing the address of the struct.
+type fieldKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+func keyOf(field reflect.Value) fieldKey {
+	return fieldKey{addr: field.Addr().Pointer(), typ: field.Type()}
+}
+
+// walkCommands calls visit for cmd and all its subcommands, depth first.
+func walkCommands(cmd *cobra.Command, visit func(cmd *cobra.Command)) {
+	visit(cmd)
+
+	for _, sub := range cmd.Commands() {
+		walkCommands(sub, visit)
+	}
+}
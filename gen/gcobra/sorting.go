@@ -0,0 +1,81 @@
+package gcobra
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// orderAnnotation is the annotation of a subcommand holding
+// its position among the subcommands of its parent, as added.
+const orderAnnotation = "command-order"
+
+// commandsCall matches the calls to the subcommands of a command in templates.
+var commandsCall = regexp.MustCompile(`([\s({])\.Commands\b`)
+
+// Cobra only has a global setting for sorting commands, shared by all
+// command trees: trees keeping the order of their subcommands list them,
+// in their help, with a template function instead.
+func init() {
+	cobra.AddTemplateFunc("declaredCommands", declaredCommands)
+}
+
+// WithCommandSorting sets whether subcommands are sorted by name in the help of
+// the commands (the default), or listed in the order of their struct fields.
+// Unlike cobra.EnableCommandSorting, it only applies to the generated tree: to
+// list the commands added to it (see AddCommand) as added, give it to them too.
+func WithCommandSorting(sort bool) Option {
+	return func(opts *options) { opts.unsorted = !sort }
+}
+
+// setCommandOrder makes the help of a command tree list the subcommands
+// of its commands in the order they were declared, if not sorted.
+func setCommandOrder(cmd *cobra.Command, settings options) {
+	if !settings.unsorted {
+		return
+	}
+
+	usage := commandsCall.ReplaceAllString(cmd.UsageTemplate(), "${1}(declaredCommands .)")
+	cmd.SetUsageTemplate(usage)
+}
+
+// addCommand adds a subcommand to its parent, after those already added,
+// recording its position if the commands of the tree are not sorted.
+func addCommand(parent, subc *cobra.Command, settings *options) {
+	if !settings.unsorted {
+		parent.AddCommand(subc)
+
+		return
+	}
+
+	if subc.Annotations == nil {
+		subc.Annotations = map[string]string{}
+	}
+
+	subc.Annotations[orderAnnotation] = strconv.Itoa(len(parent.Commands()))
+	parent.AddCommand(subc)
+}
+
+// declaredCommands returns the subcommands of a command in the order they were
+// added by gcobra, followed by the others (eg. the help and completion commands).
+func declaredCommands(cmd *cobra.Command) []*cobra.Command {
+	commands := append([]*cobra.Command(nil), cmd.Commands()...)
+
+	sort.SliceStable(commands, func(i, j int) bool {
+		return commandOrder(commands[i]) < commandOrder(commands[j])
+	})
+
+	return commands
+}
+
+func commandOrder(cmd *cobra.Command) int {
+	order, err := strconv.Atoi(cmd.Annotations[orderAnnotation])
+	if err != nil {
+		return math.MaxInt
+	}
+
+	return order
+}
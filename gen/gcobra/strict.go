@@ -0,0 +1,47 @@
+package gcobra
+
+import "github.com/spf13/cobra"
+
+// strictArgsAnnotation is the annotation of commands failing on leftover arguments.
+const strictArgsAnnotation = "strict-args"
+
+// WithStrictArgs makes all the commands of the tree fail when some words are
+// left after their positionals have been parsed, instead of passing them to
+// their Execute(args) implementation. Commands (and their subcommands) can
+// also be made strict with a `strict-args:"true"` tag on their field.
+// Commands with a `remaining` field still receive the words in it.
+func WithStrictArgs() Option {
+	return func(opts *options) { opts.strictArgs = true }
+}
+
+// setStrictArgs makes a strict command, once scanned, fail on its leftover arguments.
+func setStrictArgs(cmd *cobra.Command, scanned *commandScan) {
+	if cmd.Annotations[strictArgsAnnotation] == "" {
+		return
+	}
+
+	// Leftover arguments are wanted by the command, or are
+	// the names of unknown subcommands, reported as such by cobra.
+	validate := cmd.Args
+	if scanned.remaining.IsValid() || (validate == nil && cmd.HasSubCommands()) {
+		return
+	}
+
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		leftover := args
+
+		if validate != nil {
+			if err := validate(c, args); err != nil {
+				return err
+			}
+
+			leftover = scanned.args
+		}
+
+		if len(leftover) > 0 {
+			return collectedErrors(c, newError(ErrUnexpectedArg, leftover[0]))
+		}
+
+		return nil
+	}
+}
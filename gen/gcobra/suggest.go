@@ -0,0 +1,65 @@
+package gcobra
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags/gen/gpflag"
+)
+
+// WithSuggestions sets the maximum number of edits between mistyped commands
+// or flags and the ones suggested for them (2 by default). Commands can set
+// their own with the `suggestions-distance` tag, applying to their subcommands
+// and flags, and be suggested for other words with `suggest-for:"word"` tags.
+func WithSuggestions(distance int) Option {
+	return func(opts *options) { opts.suggestions = distance }
+}
+
+// suggestFlags adds the flags closest to an unknown flag to its error,
+// unless suggestions are disabled for the command tree (DisableSuggestions).
+func suggestFlags(cmd *cobra.Command, err error) error {
+	if cmd.Root().DisableSuggestions {
+		return err
+	}
+
+	return gpflag.Suggest(cmd.Flags(), err, suggestionsDistance(cmd))
+}
+
+// suggestionsDistance returns the suggestions distance of
+// the nearest command setting one, from cmd to its root.
+func suggestionsDistance(cmd *cobra.Command) int {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if cmd.SuggestionsMinimumDistance > 0 {
+			return cmd.SuggestionsMinimumDistance
+		}
+	}
+
+	return gpflag.DefaultSuggestionsDistance
+}
+
+// unknownCommand returns an error if a command requiring a subcommand is given
+// other words, suggesting the closest subcommands: cobra does not check them
+// when traversing the flags of parent commands (TraverseChildren).
+func unknownCommand(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 || !cmd.HasAvailableSubCommands() {
+		return nil
+	}
+
+	err := fmt.Errorf("unknown command %q for %q", args[0], cmd.CommandPath())
+	if cmd.Root().DisableSuggestions {
+		return err
+	}
+
+	if cmd.SuggestionsMinimumDistance <= 0 {
+		cmd.SuggestionsMinimumDistance = suggestionsDistance(cmd)
+	}
+
+	suggestions := cmd.SuggestionsFor(args[0])
+	if len(suggestions) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w\n\nDid you mean this?\n\t%s\n", err, strings.Join(suggestions, "\n\t"))
+}
@@ -0,0 +1,90 @@
+package gcobra
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Stages of command executions, marked on their spans.
+const (
+	StageParse    = "parse"
+	StageValidate = "validate"
+	StageExecute  = "execute"
+)
+
+// Tracer starts a span for each execution of a command, named after the path
+// of the command ("app remote add"), with the values of the flags given on the
+// command line as attributes ("flag.name"), secret ones being redacted.
+// It is small enough to be implemented with OpenTelemetry in a few lines:
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs map[string]string) (context.Context, gcobra.Span) {
+//	    ctx, span := t.tracer.Start(ctx, name)
+//	    for key, value := range attrs {
+//	        span.SetAttributes(attribute.String(key, value))
+//	    }
+//	    return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span)
+}
+
+// Span is the span of a command execution. Stage is called when the execution
+// enters one of its stages (StageParse, etc), and End once it is over, with its
+// error if any. The context of the span is given to sflags.ContextCommander.
+type Span interface {
+	Stage(name string)
+	End(err error)
+}
+
+// WithTracer traces the executions of the commands of the tree with tracer.
+// Commands failing to parse their flags have a span ended in StageParse.
+func WithTracer(tracer Tracer) Option {
+	return func(opts *options) { opts.tracer = tracer }
+}
+
+// startSpan starts the span of a command execution, in the nearest context
+// of the command or of its parents, if its command tree is traced by tracer.
+func startSpan(cmd *cobra.Command, tracer Tracer) (context.Context, Span) {
+	ctx := commandContext(cmd)
+
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	attrs := map[string]string{}
+
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if hasAnnotation(flag, "secret") {
+			value = "********"
+		}
+
+		attrs["flag."+flag.Name] = value
+	})
+
+	return tracer.Start(ctx, cmd.CommandPath(), attrs)
+}
+
+// commandContext returns the context of a command, or that of its nearest
+// parent: default commands are not given the context of their parent by cobra.
+func commandContext(cmd *cobra.Command) context.Context {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if ctx := cmd.Context(); ctx != nil {
+			return ctx
+		}
+	}
+
+	return nil
+}
+
+// noopSpan is the span of untraced command executions.
+type noopSpan struct{}
+
+func (noopSpan) Stage(string) {}
+func (noopSpan) End(error)    {}
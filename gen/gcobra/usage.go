@@ -0,0 +1,83 @@
+package gcobra
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// UsagePolicy determines what is printed when a command fails to parse its
+// flags or arguments, or when its execution returns an error.
+type UsagePolicy int
+
+const (
+	// UsageOnError prints the error, followed by the usage of the command.
+	// This is the default behavior of cobra.
+	UsageOnError UsagePolicy = iota
+
+	// ErrorOnly prints the error alone.
+	ErrorOnly
+
+	// ErrorWithHint prints the error, followed by a one-line hint
+	// pointing to the help of the command when it failed to parse.
+	ErrorWithHint
+)
+
+// WithUsagePolicy sets what the commands print on errors, for the whole tree.
+func WithUsagePolicy(policy UsagePolicy) Option {
+	return func(opts *options) { opts.usage = policy }
+}
+
+// hintError is a parsing error, followed by a hint to the help of its command.
+type hintError struct {
+	err error
+	cmd *cobra.Command
+}
+
+func (e *hintError) Error() string {
+	return fmt.Sprintf("%s\nSee '%s --help'.", e.err, e.cmd.CommandPath())
+}
+
+func (e *hintError) Unwrap() error { return e.err }
+
+// setUsagePolicy applies a usage policy to a command tree.
+func setUsagePolicy(cmd *cobra.Command, policy UsagePolicy) {
+	if policy == UsageOnError {
+		return
+	}
+
+	// Subcommands respect the setting of the root.
+	cmd.SilenceUsage = true
+
+	if policy != ErrorWithHint {
+		return
+	}
+
+	onFlagError := cmd.FlagErrorFunc()
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		if err = onFlagError(c, err); err == nil {
+			return nil
+		}
+
+		return &hintError{err: err, cmd: c}
+	})
+
+	hintArgs(cmd)
+}
+
+// hintArgs adds the help hint to the argument errors of a command and its subcommands.
+func hintArgs(cmd *cobra.Command) {
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if err := validate(c, args); err != nil {
+				return &hintError{err: err, cmd: c}
+			}
+
+			return nil
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		hintArgs(sub)
+	}
+}
@@ -0,0 +1,76 @@
+package gcobra
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// WithVersionCommand adds a "version" subcommand to the root command (unless it
+// has its own), printing its version followed by the build metadata given by the
+// caller (like the commit or the build date, often injected with -ldflags).
+// The version is set with WithVersion, or a `version` tag on the root struct.
+func WithVersionCommand(metadata map[string]string) Option {
+	return func(opts *options) {
+		opts.versionCmd = true
+		opts.buildInfo = metadata
+	}
+}
+
+// versionTag returns the version declared by the `version` tag
+// of a field of the root struct, like a blank field tagged version:"1.2.3".
+func versionTag(data interface{}) string {
+	typ := reflect.TypeOf(data)
+	if typ == nil {
+		return ""
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return ""
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		if version, isSet := typ.Field(i).Tag.Lookup("version"); isSet && version != "" {
+			return version
+		}
+	}
+
+	return ""
+}
+
+// addVersionCommand adds the command printing the version and build metadata of the root.
+func addVersionCommand(root *cobra.Command, metadata map[string]string) {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == "version" {
+			return
+		}
+	}
+
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	root.AddCommand(&cobra.Command{
+		Use:   "version",
+		Short: "Print the version and build information",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s version %s\n", root.Name(), root.Version)
+
+			for _, key := range keys {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", key, metadata[key])
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package gcomp
+
+import (
+	comp "github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags/gen/gcobra"
+)
+
+// AddCommand adds a subcommand built from data to a command of an existing
+// tree (see gcobra.AddCommand), and generates the completions of the new
+// command and of its own subcommands, which it returns. The options are
+// those given to gcobra.AddCommand.
+func AddCommand(parent *cobra.Command, data interface{}, name string, opts ...gcobra.Option) (*comp.Carapace, error) {
+	if err := gcobra.AddCommand(parent, data, name, opts...); err != nil {
+		return nil, err
+	}
+
+	for _, sub := range parent.Commands() {
+		if sub.Name() == name {
+			return Generate(sub, data, nil)
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,27 @@
+package gcomp
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/octago/sflags/gen/gcobra"
+)
+
+// Backend returns the carapace completion backend of gcobra command trees,
+// generating their completions like Generate when they are parsed:
+//
+//	cmd := gcobra.Parse(&data, gcobra.WithCompletions(gcomp.Backend()))
+func Backend() gcobra.CompletionBackend {
+	return carapaceBackend{}
+}
+
+type carapaceBackend struct{}
+
+func (carapaceBackend) Complete(cmd *cobra.Command, data interface{}) error {
+	if data == nil {
+		return nil
+	}
+
+	_, err := Generate(cmd, data, nil)
+
+	return err
+}
@@ -17,15 +17,7 @@ func Generate(cmd *cobra.Command, data interface{}, comps *comp.Carapace) (*comp
 		comps = comp.Gen(cmd)
 	}
 
-	// A command always accepts embedded subcommand struct fields, so scan them.
-	compScanner := scanCompletions(cmd, comps)
-
-	// Scan the struct recursively, for both arg/option groups and subcommands
-	if err := scan.Type(data, compScanner); err != nil {
-		return comps, err
-	}
-
-	return comps, nil
+	return comps, generate(cmd, data, comps, nil)
 }
 
 // Gen uses a carapace completion builder to register various completions
@@ -37,20 +29,24 @@ func Gen(cmd *cobra.Command, data sflags.Commander, comps *comp.Carapace) (*comp
 		comps = comp.Gen(cmd)
 	}
 
+	return comps, generate(cmd, data, comps, nil)
+}
+
+// generate binds the completers found on data to the carapace of its command.
+// Without carapace, they are only recorded in tests, with those of subcommands.
+func generate(cmd *cobra.Command, data interface{}, comps *comp.Carapace, tests selfTests) error {
 	// A command always accepts embedded subcommand struct fields, so scan them.
-	compScanner := scanCompletions(cmd, comps)
+	compScanner := scanCompletions(cmd, comps, tests, data)
 
 	// Scan the struct recursively, for both arg/option groups and subcommands
-	if err := scan.Type(data, compScanner); err != nil {
-		return comps, err
-	}
-
-	return comps, nil
+	return scan.Type(data, compScanner)
 }
 
 // scanCompletions is in charge of building a recursive scanner, working on a given
 // struct field at a time, checking for arguments, subcommands and option groups.
-func scanCompletions(cmd *cobra.Command, comps *comp.Carapace) scan.Handler {
+// The data is the struct being scanned, to which positional completers have access.
+// Without carapace, the completers found are only recorded in tests (see generate).
+func scanCompletions(cmd *cobra.Command, comps *comp.Carapace, tests selfTests, data interface{}) scan.Handler {
 	handler := func(val reflect.Value, sfield *reflect.StructField) (bool, error) {
 		mtag, none, err := tag.GetFieldTag(*sfield)
 		if none || err != nil {
@@ -59,26 +55,26 @@ func scanCompletions(cmd *cobra.Command, comps *comp.Carapace) scan.Handler {
 
 		// If the field is marked as -one or more- positional arguments, we
 		// return either on a successful scan of them, or with an error doing so.
-		if found, err := positionals(comps, mtag, val); found || err != nil {
+		if found, err := positionals(comps, tests, cmd, mtag, val, data); found || err != nil {
 			return found, err
 		}
 
 		// Else, if the field is marked as a subcommand, we either return on
 		// a successful scan of the subcommand, or with an error doing so.
-		if found, err := command(cmd, mtag, val); found || err != nil {
+		if found, err := command(cmd, comps, tests, mtag, val); found || err != nil {
 			return found, err
 		}
 
 		// Else, try scanning the field as a group of commands/options,
 		// and only use the completion stuff we find on them.
-		return groupComps(comps, cmd, val, sfield)
+		return groupComps(comps, tests, cmd, val, sfield, data)
 	}
 
 	return handler
 }
 
 // command finds if a field is marked as a command, and if yes, scans it.
-func command(cmd *cobra.Command, tag tag.MultiTag, val reflect.Value) (bool, error) {
+func command(cmd *cobra.Command, comps *comp.Carapace, tests selfTests, tag tag.MultiTag, val reflect.Value) (bool, error) {
 	// Parse the command name on struct tag...
 	name, _ := tag.Get("command")
 	if len(name) == 0 {
@@ -109,9 +105,11 @@ func command(cmd *cobra.Command, tag tag.MultiTag, val reflect.Value) (bool, err
 	// Simply generate a new carapace around this command,
 	// so that we can register different positional arguments
 	// without overwriting those of our root command.
-	if _, err := Gen(subc, commander, nil); err != nil {
-		return true, err
+	if comps == nil {
+		return true, generate(subc, commander, nil, tests)
 	}
 
-	return true, nil
+	_, err := Gen(subc, commander, nil)
+
+	return true, err
 }
@@ -4,8 +4,12 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/octago/sflags/internal/tag"
 	comp "github.com/rsteube/carapace"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+	"github.com/octago/sflags/internal/validation"
 )
 
 // Completer represents a type that is able to return some
@@ -14,6 +18,14 @@ type Completer interface {
 	Complete(ctx comp.Context) comp.Action
 }
 
+// ArgCompleter is implemented by commands completing their positional arguments
+// depending on values previously parsed onto them, such as a --profile flag that
+// selects the set of valid targets. CompleteArg is given the name of the argument
+// to complete, and returns false when the argument completers should be used instead.
+type ArgCompleter interface {
+	CompleteArg(name string, ctx comp.Context) (comp.Action, bool)
+}
+
 // CompDirective identifies one of reflags' builtin completer functions.
 type CompDirective int
 
@@ -78,6 +90,12 @@ func getCompletionAction(name, value string) (action comp.Action) {
 	// Should normally not be used often
 	case "Default":
 		return
+
+	// Common values (hosts, users, etc) completed by the library.
+	default:
+		if completer, found := libraryCompleters[name]; found {
+			action = comp.ActionCallback(completer)
+		}
 	}
 
 	return
@@ -116,9 +134,112 @@ func typeCompleter(val reflect.Value) comp.CompletionCallback {
 		}
 	}
 
+	// Types whose allowed values are only known at runtime.
+	if provider, ok := validation.Provider(val); ok {
+		return providerCompleter(provider)
+	}
+
+	// Builtin types with a fixed set of values.
+	switch val.Type() {
+	case reflect.TypeOf(sflags.TriBool(0)):
+		return func(ctx comp.Context) comp.Action {
+			return comp.ActionValues("true", "false", "auto")
+		}
+	case reflect.TypeOf(sflags.DurationOrOff(0)):
+		return durationOrOffCompleter
+	case reflect.TypeOf(sflags.Size(0)):
+		return sizeCompleter
+	}
+
 	return nil
 }
 
+// providerCompleter completes the choices of a ChoiceProvider, with their descriptions.
+func providerCompleter(provider sflags.ChoiceProvider) comp.CompletionCallback {
+	return func(ctx comp.Context) comp.Action {
+		choices := provider.Choices()
+
+		values := make([]string, 0, len(choices)*2)
+		for _, choice := range choices {
+			values = append(values, choice.Value, choice.Description)
+		}
+
+		return comp.ActionValuesDescribed(values...)
+	}
+}
+
+// durationOrOffCompleter completes either the disabled values of
+// a DurationOrOff, or duration units once a number has been typed.
+func durationOrOffCompleter(ctx comp.Context) comp.Action {
+	if value := ctx.CallbackValue; value != "" && strings.Trim(value, "0123456789.") == "" {
+		return comp.ActionValues(value+"ms", value+"s", value+"m", value+"h")
+	}
+
+	return comp.ActionValues("off", "none")
+}
+
+// sizeCompleter completes size units once a number has been typed.
+func sizeCompleter(ctx comp.Context) comp.Action {
+	value := ctx.CallbackValue
+	if value == "" || strings.Trim(value, "0123456789.") != "" {
+		return comp.ActionValues()
+	}
+
+	units := []string{"B", "KB", "KiB", "MB", "MiB", "GB", "GiB", "TB", "TiB"}
+	for i, unit := range units {
+		units[i] = value + unit
+	}
+
+	return comp.ActionValues(units...)
+}
+
+// pathCompletions builds a completion callback offering files for fields
+// holding paths (`type:"path"`), filtered by the extensions of their `ext` tag.
+func pathCompletions(tag tag.MultiTag) (cb comp.CompletionCallback, found bool) {
+	if !convert.IsPath(tag) {
+		return nil, false
+	}
+
+	exts := convert.Extensions(tag)
+
+	callback := func(ctx comp.Context) comp.Action {
+		return comp.ActionFiles(exts...)
+	}
+
+	return callback, true
+}
+
+// choiceCompletions builds a completion callback offering all the
+// values allowed by the `choice`/`choices` tags of a field, if any.
+func choiceCompletions(tag tag.MultiTag) (cb comp.CompletionCallback, found bool) {
+	choices := validation.ParseChoices(tag)
+	if len(choices) == 0 {
+		return nil, false
+	}
+
+	callback := func(ctx comp.Context) comp.Action {
+		return comp.ActionValues(choices...)
+	}
+
+	return callback, true
+}
+
+// argCompletions builds a completion callback for a positional argument using
+// its command ArgCompleter implementation, or the argument fallback completer.
+func argCompletions(completer ArgCompleter, name string, fallback comp.CompletionCallback) comp.CompletionCallback {
+	return func(ctx comp.Context) comp.Action {
+		if action, found := completer.CompleteArg(name, ctx); found {
+			return action
+		}
+
+		if fallback != nil {
+			return fallback(ctx)
+		}
+
+		return comp.ActionValues()
+	}
+}
+
 // taggedCompletions builds a list of completion actions with struct tag specs.
 func taggedCompletions(tag tag.MultiTag) (cb comp.CompletionCallback, found bool) {
 	compTag := tag.GetMany(completeTagName) // TODO constants
@@ -2,7 +2,9 @@ package gcomp
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
 
 	comp "github.com/rsteube/carapace"
 	"github.com/spf13/cobra"
@@ -18,7 +20,7 @@ import (
 var ErrShortNameTooLong = errors.New("short names can only be 1 character long")
 
 // flagsGroup finds if a field is marked as a subgroup of options, and if yes, scans it recursively.
-func groupComps(comps *comp.Carapace, cmd *cobra.Command, val reflect.Value, sfield *reflect.StructField) (bool, error) {
+func groupComps(comps *comp.Carapace, tests selfTests, cmd *cobra.Command, val reflect.Value, sfield *reflect.StructField, data interface{}) (bool, error) {
 	mtag, none, err := tag.GetFieldTag(*sfield)
 	if none || err != nil {
 		return true, err
@@ -42,13 +44,15 @@ func groupComps(comps *comp.Carapace, cmd *cobra.Command, val reflect.Value, sfi
 	// A group of options ("group" is the legacy name)
 	optionsGroup, isSet := mtag.Get("group")
 	if isSet && optionsGroup != "" {
-		cmd.AddGroup(&cobra.Group{
-			Group: optionsGroup,
-			Title: description,
-		})
+		if comps != nil {
+			cmd.AddGroup(&cobra.Group{
+				Group: optionsGroup,
+				Title: description,
+			})
+		}
 
 		// Parse the options for completions
-		err := addFlagComps(comps, mtag, ptrval.Interface())
+		err := addFlagComps(comps, tests, cmd, mtag, ptrval.Interface())
 
 		return true, err
 	}
@@ -59,7 +63,7 @@ func groupComps(comps *comp.Carapace, cmd *cobra.Command, val reflect.Value, sfi
 	commandGroup, isSet := mtag.Get("commands")
 	if isSet {
 		var group *cobra.Group
-		if !isStringFalsy(commandGroup) {
+		if !isStringFalsy(commandGroup) && comps != nil {
 			group = &cobra.Group{
 				Group: commandGroup,
 				Title: description,
@@ -68,7 +72,7 @@ func groupComps(comps *comp.Carapace, cmd *cobra.Command, val reflect.Value, sfi
 		}
 
 		// Parse for commands
-		scannerCommand := scanCompletions(cmd, comps)
+		scannerCommand := scanCompletions(cmd, comps, tests, data)
 		err := scan.Type(ptrval.Interface(), scannerCommand)
 
 		return true, err
@@ -79,7 +83,7 @@ func groupComps(comps *comp.Carapace, cmd *cobra.Command, val reflect.Value, sfi
 
 // addFlagComps scans a struct (potentially nested), for a set of flags, and without
 // binding them to the command, parses them for any completions specified/implemented.
-func addFlagComps(comps *comp.Carapace, mtag tag.MultiTag, data interface{}) error {
+func addFlagComps(comps *comp.Carapace, tests selfTests, cmd *cobra.Command, mtag tag.MultiTag, data interface{}) error {
 	var flagOpts []sflags.OptFunc
 
 	// New change, in order to easily propagate parent namespaces
@@ -96,11 +100,12 @@ func addFlagComps(comps *comp.Carapace, mtag tag.MultiTag, data interface{}) err
 		flagOpts = append(flagOpts, sflags.EnvPrefix(envNamespace))
 	}
 
-	// All completions for this flag set
+	// All completions for this flag set, and the static ones for specs.
 	flagCompletions := make(map[string]comp.Action)
+	flagValues := make(map[string][]string)
 
 	// The handler will append to the completions map as each flag is parsed
-	compScanner := flagCompsScanner(&flagCompletions)
+	compScanner := flagCompsScanner(&flagCompletions, flagValues)
 	flagOpts = append(flagOpts, sflags.FlagHandler(compScanner))
 
 	// Parse the group into a flag set, but don't keep them,
@@ -111,33 +116,96 @@ func addFlagComps(comps *comp.Carapace, mtag tag.MultiTag, data interface{}) err
 	}
 
 	// If we are done parsing the flags without error and we have
-	// some completers found on them (implemented or tagged), bind them.
-	if len(flagCompletions) > 0 {
-		comps.FlagCompletion(comp.ActionMap(flagCompletions))
+	// some completers found on them (implemented or tagged), bind them,
+	// and record the static ones, so that they can be exported.
+	if comps != nil {
+		if len(flagCompletions) > 0 {
+			comps.FlagCompletion(comp.ActionMap(flagCompletions))
+		}
+
+		registerFlagSpec(cmd, flagValues)
+	}
+
+	// Or record them all, so that they can be self-tested.
+	flags := make([]string, 0, len(flagCompletions))
+	for flag := range flagCompletions {
+		flags = append(flags, flag)
+	}
+
+	sort.Strings(flags)
+
+	for _, flag := range flags {
+		tests.register(cmd, "--"+flag, flagCompletions[flag])
 	}
 
 	return nil
 }
 
 // flagCompsScanner builds a scanner that will register some completers for an option flag.
-func flagCompsScanner(actions *map[string]comp.Action) sflags.FlagFunc {
-	handler := func(flag string, tag tag.MultiTag, val reflect.Value) (err error) {
+// Completions known without running the binary are stored in values.
+func flagCompsScanner(actions *map[string]comp.Action, values map[string][]string) sflags.FlagFunc {
+	handler := func(flag string, mtag tag.MultiTag, val reflect.Value) (err error) {
+		if static := specValues(mtag); len(static) > 0 {
+			values[flag] = static
+		}
+
 		// First bind any completer implementation if found
 		if completer := typeCompleter(val); completer != nil {
 			(*actions)[flag] = comp.ActionCallback(completer)
 		}
 
+		// Paths are completed with files, possibly filtered by extension.
+		if completer, found := pathCompletions(mtag); found {
+			(*actions)[flag] = comp.ActionCallback(completer)
+		}
+
+		// Allowed choices are more specific than the type completer.
+		if completer, found := choiceCompletions(mtag); found {
+			(*actions)[flag] = comp.ActionCallback(completer)
+		}
+
 		// Then, check for tags that will override the implementation.
-		if completer, found := taggedCompletions(tag); found {
+		if completer, found := taggedCompletions(mtag); found {
 			(*actions)[flag] = comp.ActionCallback(completer)
 		}
 
+		// Example values are only suggested when nothing else is completed.
+		if example, _ := mtag.Get("example-value"); example != "" {
+			if _, found := (*actions)[flag]; !found {
+				(*actions)[flag] = comp.ActionValues(example)
+			}
+		}
+
+		// Or hints for the format of some builtin types.
+		if completer, found := hintCompletions(val, mtag); found {
+			if _, exists := (*actions)[flag]; !exists {
+				(*actions)[flag] = comp.ActionCallback(completer)
+			}
+		}
+
+		// Values of deprecated flags are completed along with the deprecation.
+		if action, found := (*actions)[flag]; found {
+			if msg, deprecated := tag.Deprecation(mtag); deprecated {
+				(*actions)[flag] = deprecatedAction(action, flag, msg)
+			}
+		}
+
 		return nil
 	}
 
 	return handler
 }
 
+// deprecatedAction annotates the completions of a deprecated flag with its deprecation message.
+func deprecatedAction(action comp.Action, flag, msg string) comp.Action {
+	notice := fmt.Sprintf("flag --%s has been deprecated", flag)
+	if msg != "" {
+		notice += ", " + msg
+	}
+
+	return comp.Batch(action, comp.ActionMessage(notice)).ToA()
+}
+
 func isStringFalsy(s string) bool {
 	return s == "" || s == "false" || s == "no" || s == "0"
 }
@@ -159,7 +227,7 @@ func scanOption(mtag tag.MultiTag, field reflect.StructField, val reflect.Value)
 	// }
 	//
 	// description, _ := mtag.Get("description")                            DONE
-	// def := mtag.GetMany("default")
+	// def := mtag.GetMany("default")                                     DONE
 	//
 	// optionalValue := mtag.GetMany("optional-value")
 	// valueName, _ := mtag.Get("value-name")
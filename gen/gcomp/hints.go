@@ -0,0 +1,89 @@
+package gcomp
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	comp "github.com/rsteube/carapace"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// hintCompletions builds a completion callback suggesting common values for
+// fields of some builtin types (durations, dates and numbers), so that their
+// expected format is discoverable without any completer being written.
+// Hints have the lowest priority, and are only used when nothing else completes.
+func hintCompletions(val reflect.Value, mtag tag.MultiTag) (cb comp.CompletionCallback, found bool) {
+	typ := val.Type()
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ {
+	case reflect.TypeOf(time.Duration(0)):
+		return durationCompleter, true
+	case reflect.TypeOf(time.Time{}):
+		return dateCompleter(mtag), true
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberCompleter("an integer"), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numberCompleter("a positive integer"), true
+	case reflect.Float32, reflect.Float64:
+		return numberCompleter("a number"), true
+	}
+
+	return nil, false
+}
+
+// durationCompleter completes some common durations, or
+// duration units once a number has been typed.
+func durationCompleter(ctx comp.Context) comp.Action {
+	if value := ctx.CallbackValue; value != "" && strings.Trim(value, "0123456789.") == "" {
+		return comp.ActionValues(value+"ms", value+"s", value+"m", value+"h")
+	}
+
+	return comp.ActionValuesDescribed(
+		"30s", "30 seconds",
+		"1m", "1 minute",
+		"5m", "5 minutes",
+		"30m", "30 minutes",
+		"1h", "1 hour",
+		"24h", "1 day",
+	)
+}
+
+// dateCompleter completes yesterday, today and tomorrow, formatted with
+// the layout of the field (`layout` tag) or RFC3339 by default.
+func dateCompleter(mtag tag.MultiTag) comp.CompletionCallback {
+	layout, _ := mtag.Get("layout")
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	return func(ctx comp.Context) comp.Action {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+		return comp.ActionValuesDescribed(
+			today.Format(layout), "today",
+			today.AddDate(0, 0, 1).Format(layout), "tomorrow",
+			today.AddDate(0, 0, -1).Format(layout), "yesterday",
+		)
+	}
+}
+
+// numberCompleter tells the kind of number expected, since
+// there are no values worth suggesting for plain numbers.
+func numberCompleter(kind string) comp.CompletionCallback {
+	return func(ctx comp.Context) comp.Action {
+		return comp.ActionMessage("expects " + kind)
+	}
+}
@@ -0,0 +1,191 @@
+package gcomp
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	comp "github.com/rsteube/carapace"
+)
+
+// libraryCompleters are the completers of common values that fields can
+// use with their `complete` tag, like `complete:"Hosts"`, without any code.
+var libraryCompleters = map[string]comp.CompletionCallback{
+	"Hosts":      hostsCompleter,
+	"Users":      usersCompleter,
+	"Groups":     groupsCompleter,
+	"Interfaces": interfacesCompleter,
+	"EnvVars":    envVarsCompleter,
+	"Pids":       pidsCompleter,
+
+	"ArchivePaths": ArchivePaths,
+}
+
+// hostsCompleter completes the hosts found in the known_hosts file of the user.
+func hostsCompleter(ctx comp.Context) comp.Action {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return comp.ActionMessage(err.Error())
+	}
+
+	var hosts []string
+
+	err = readLines(filepath.Join(home, ".ssh", "known_hosts"), func(line string) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "|") {
+			return
+		}
+
+		// Marked lines (@cert-authority, @revoked) have the hosts second.
+		if strings.HasPrefix(fields[0], "@") {
+			if len(fields) < 2 {
+				return
+			}
+			fields = fields[1:]
+		}
+
+		for _, host := range strings.Split(fields[0], ",") {
+			// Hosts on non-standard ports are written as [host]:port.
+			if end := strings.Index(host, "]"); strings.HasPrefix(host, "[") && end > 0 {
+				host = host[1:end]
+			}
+			hosts = append(hosts, host)
+		}
+	})
+	if err != nil {
+		return comp.ActionMessage(err.Error())
+	}
+
+	return comp.ActionValues(unique(hosts)...)
+}
+
+// usersCompleter completes the users of the system, described with their full names.
+func usersCompleter(ctx comp.Context) comp.Action {
+	return passwdCompleter("/etc/passwd", 4)
+}
+
+// groupsCompleter completes the groups of the system, described with their ids.
+func groupsCompleter(ctx comp.Context) comp.Action {
+	return passwdCompleter("/etc/group", 2)
+}
+
+// passwdCompleter completes the names of a passwd-like file, with
+// the field at index desc of the same entry as their description.
+func passwdCompleter(path string, desc int) comp.Action {
+	var values []string
+
+	err := readLines(path, func(line string) {
+		if strings.HasPrefix(line, "#") {
+			return
+		}
+
+		fields := strings.Split(line, ":")
+		if len(fields) <= desc || fields[0] == "" {
+			return
+		}
+
+		values = append(values, fields[0], strings.TrimRight(fields[desc], ","))
+	})
+	if err != nil {
+		return comp.ActionMessage(err.Error())
+	}
+
+	return comp.ActionValuesDescribed(values...)
+}
+
+// interfacesCompleter completes the network interfaces, described with their addresses.
+func interfacesCompleter(ctx comp.Context) comp.Action {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return comp.ActionMessage(err.Error())
+	}
+
+	values := make([]string, 0, len(ifaces)*2)
+
+	for _, iface := range ifaces {
+		var addrs []string
+		if ifaddrs, err := iface.Addrs(); err == nil {
+			for _, addr := range ifaddrs {
+				addrs = append(addrs, addr.String())
+			}
+		}
+
+		values = append(values, iface.Name, strings.Join(addrs, ", "))
+	}
+
+	return comp.ActionValuesDescribed(values...)
+}
+
+// envVarsCompleter completes the names of the environment variables, described with their values.
+func envVarsCompleter(ctx comp.Context) comp.Action {
+	env := os.Environ()
+	sort.Strings(env)
+
+	values := make([]string, 0, len(env)*2)
+
+	for _, variable := range env {
+		name, value, _ := strings.Cut(variable, "=")
+		if name != "" {
+			values = append(values, name, value)
+		}
+	}
+
+	return comp.ActionValuesDescribed(values...)
+}
+
+// pidsCompleter completes the ids of the running processes, described with their
+// commands. Processes are read from /proc, so there are none on other systems.
+func pidsCompleter(ctx comp.Context) comp.Action {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return comp.ActionMessage(err.Error())
+	}
+
+	var values []string
+
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil || !entry.IsDir() {
+			continue
+		}
+
+		name, _ := os.ReadFile(filepath.Join("/proc", entry.Name(), "comm"))
+		values = append(values, entry.Name(), strings.TrimSpace(string(name)))
+	}
+
+	return comp.ActionValuesDescribed(values...)
+}
+
+// readLines calls fn with each line of the file at path.
+func readLines(path string, fn func(line string)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fn(scanner.Text())
+	}
+
+	return scanner.Err()
+}
+
+// unique returns the values sorted, without duplicates.
+func unique(values []string) []string {
+	sort.Strings(values)
+
+	var uniq []string
+
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			uniq = append(uniq, value)
+		}
+	}
+
+	return uniq
+}
@@ -0,0 +1,119 @@
+package gcomp
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	comp "github.com/rsteube/carapace"
+)
+
+// archiveExtensions are the extensions of the archives completed by ArchivePaths.
+var archiveExtensions = []string{".zip", ".jar", ".tar", ".tar.gz", ".tgz"}
+
+// MultiParts builds a completer for values made of several parts joined by
+// sep, like `host:port` or `archive.zip:inner/path`. Each part is completed by
+// the completer at the same index, and the last one completes any further part.
+// Completers are given the parts already typed in the Parts of their context.
+func MultiParts(sep string, parts ...comp.CompletionCallback) comp.CompletionCallback {
+	return func(ctx comp.Context) comp.Action {
+		if len(parts) == 0 {
+			return comp.ActionValues()
+		}
+
+		return comp.ActionMultiParts(sep, func(ctx comp.Context) comp.Action {
+			index := len(ctx.Parts)
+			if index >= len(parts) {
+				index = len(parts) - 1
+			}
+
+			action := parts[index](ctx)
+
+			// Values of the first parts are followed by others.
+			if index < len(parts)-1 {
+				action = action.NoSpace()
+			}
+
+			return action
+		})
+	}
+}
+
+// ArchivePaths completes values like `archive.zip:inner/path`: archive files
+// (zip, jar, tar and gzipped tar), then the paths of the files they contain.
+// It can be used with the `complete:"ArchivePaths"` tag, or in Completers.
+func ArchivePaths(ctx comp.Context) comp.Action {
+	return MultiParts(":", archiveFiles, archiveEntries)(ctx)
+}
+
+// archiveFiles completes the archives of the current directory.
+func archiveFiles(ctx comp.Context) comp.Action {
+	return comp.ActionFiles(archiveExtensions...)
+}
+
+// archiveEntries completes the paths of the files in the archive of the first part.
+func archiveEntries(ctx comp.Context) comp.Action {
+	if len(ctx.Parts) == 0 {
+		return comp.ActionValues()
+	}
+
+	names, err := archiveNames(ctx.Parts[0])
+	if err != nil {
+		return comp.ActionMessage(err.Error())
+	}
+
+	return comp.ActionValues(names...)
+}
+
+// archiveNames returns the paths of the files in an archive, given its extension.
+func archiveNames(path string) ([]string, error) {
+	if strings.HasSuffix(path, ".zip") || strings.HasSuffix(path, ".jar") {
+		archive, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		defer archive.Close()
+
+		names := make([]string, 0, len(archive.File))
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+
+		return names, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+
+	if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tgz") {
+		gzipped, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipped.Close()
+
+		reader = gzipped
+	}
+
+	var names []string
+
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return names, nil
+		} else if err != nil {
+			return names, err
+		}
+
+		names = append(names, header.Name)
+	}
+}
@@ -4,13 +4,14 @@ import (
 	"reflect"
 
 	comp "github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
 
 	"github.com/octago/sflags/internal/positional"
 	"github.com/octago/sflags/internal/tag"
 )
 
 // positionals finds a struct tagged as containing positional arguments and scans them.
-func positionals(comps *comp.Carapace, tag tag.MultiTag, val reflect.Value) (bool, error) {
+func positionals(comps *comp.Carapace, tests selfTests, cmd *cobra.Command, tag tag.MultiTag, val reflect.Value, data interface{}) (bool, error) {
 	// We need the struct to be marked as such
 	if pargs, _ := tag.Get("positional-args"); len(pargs) == 0 {
 		return false, nil
@@ -29,7 +30,23 @@ func positionals(comps *comp.Carapace, tag tag.MultiTag, val reflect.Value) (boo
 	// build ones based on struct tag specs.
 	// Put them in a cache of completion callbacks that is accessed
 	// by all positional arguments in order to use their completions.
-	completionCache := getCompleters(args, comps)
+	completionCache := getCompleters(args, comps, data)
+
+	// Record the completers, so that they can be self-tested,
+	// and the static ones if bound, so that they can be exported.
+	for _, arg := range args.Positionals() {
+		if completer := completionCache.get(arg.Index); completer != nil {
+			tests.register(cmd, "<"+arg.Name+">", comp.ActionCallback(completer))
+		}
+
+		if comps != nil {
+			registerArgSpec(cmd, specValues(arg.Tag), arg.Maximum)
+		}
+	}
+
+	if comps == nil {
+		return true, nil
+	}
 
 	// Make a custom function for consuming the command words,
 	args = positional.WithWordConsumer(args, consumeWith(completionCache))
@@ -60,7 +77,8 @@ func positionals(comps *comp.Carapace, tag tag.MultiTag, val reflect.Value) (boo
 
 // getCompleters populates the completers for each positional argument in
 // a list of them, through either implemented methods or struct tag specs.
-func getCompleters(args *positional.Args, comps *comp.Carapace) *compCache {
+// If the command data implements ArgCompleter, it is consulted first.
+func getCompleters(args *positional.Args, comps *comp.Carapace, data interface{}) *compCache {
 	// The cache stores all completer functions, to be used later.
 	cache := newCompletionCache()
 
@@ -71,16 +89,38 @@ func getCompleters(args *positional.Args, comps *comp.Carapace) *compCache {
 
 			// Always overwrite the after-dash completion if this argument field is
 			// being indicated as such through its struct tag.
-			if isDashPositionalAny(arg.Tag) {
+			if isDashPositionalAny(arg.Tag) && comps != nil {
 				comps.DashAnyCompletion(comp.ActionCallback(completer))
 			}
 		}
 
+		// Paths are completed with files, possibly filtered by extension.
+		if completer, found := pathCompletions(arg.Tag); found {
+			cache.add(arg.Index, completer)
+		}
+
+		// Allowed choices are more specific than the type completer.
+		if completer, found := choiceCompletions(arg.Tag); found {
+			cache.add(arg.Index, completer)
+		}
+
 		// But struct tags have precedence, so here should take place
 		// most of the work, since it's quite easy to specify powerful completions.
 		if completer, found := taggedCompletions(arg.Tag); found {
 			cache.add(arg.Index, completer)
 		}
+
+		// Hints for the format of some builtin types are the last resort.
+		if cache.get(arg.Index) == nil {
+			if completer, found := hintCompletions(arg.Value, arg.Tag); found {
+				cache.add(arg.Index, completer)
+			}
+		}
+
+		// The command itself might complete depending on its parsed values.
+		if completer, ok := data.(ArgCompleter); ok {
+			cache.add(arg.Index, argCompletions(completer, arg.Name, cache.get(arg.Index)))
+		}
 	}
 
 	return cache
@@ -199,6 +239,10 @@ func (c *compCache) add(index int, cb comp.CompletionCallback) {
 	(*c.completers)[index] = cb
 }
 
+func (c *compCache) get(index int) comp.CompletionCallback {
+	return (*c.completers)[index]
+}
+
 func (c *compCache) useCompleter(index int) {
 	completer, found := (*c.completers)[index]
 	if found {
@@ -0,0 +1,120 @@
+package gcomp
+
+import (
+	"fmt"
+	"time"
+
+	comp "github.com/rsteube/carapace"
+	"github.com/spf13/cobra"
+)
+
+// selfTestName is the name of the hidden command testing all completers.
+const selfTestName = "__complete-selftest"
+
+// defaultSelfTestTimeout is the time given to each completer to return.
+const defaultSelfTestTimeout = 5 * time.Second
+
+// registeredAction is a completer bound by Gen to a flag or an argument of a command.
+type registeredAction struct {
+	name   string
+	action comp.Action
+}
+
+// selfTests holds the completers found on the structs of a command tree,
+// by command, to be tested.
+type selfTests map[*cobra.Command][]registeredAction
+
+// register records a completer bound to a command, if testing them.
+func (t selfTests) register(cmd *cobra.Command, name string, action comp.Action) {
+	if t != nil {
+		t[cmd] = append(t[cmd], registeredAction{name: name, action: action})
+	}
+}
+
+// AddSelfTest adds a hidden __complete-selftest command to cmd, which invokes all the
+// completers bound by Gen to cmd and its subcommands with an empty input, and reports
+// the ones panicking or not returning within timeout (5 seconds if zero). The command
+// fails if any completer did, so that completions can be smoke-tested without a shell.
+// The completers are found again on data, the struct given to Gen.
+func AddSelfTest(cmd *cobra.Command, data interface{}, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultSelfTestTimeout
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:    selfTestName,
+		Short:  "Invoke all completers and report failures",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(self *cobra.Command, _ []string) error {
+			return selfTest(self, cmd, data, timeout)
+		},
+	})
+}
+
+// selfTest invokes all the completers of root and its subcommands, one at a time.
+func selfTest(self, root *cobra.Command, data interface{}, timeout time.Duration) error {
+	var total, failed int
+
+	tests := selfTests{}
+	if err := generate(root, data, nil, tests); err != nil {
+		return err
+	}
+
+	walkCommands(root, func(cmd *cobra.Command) {
+		for _, reg := range tests[cmd] {
+			total++
+
+			if err := invokeWithin(reg.action, timeout); err != nil {
+				failed++
+
+				self.Printf("FAIL %s %s: %s\n", cmd.CommandPath(), reg.name, err)
+
+				continue
+			}
+
+			self.Printf("ok   %s %s\n", cmd.CommandPath(), reg.name)
+		}
+	})
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d completers failed", failed, total)
+	}
+
+	self.Printf("%d completers ok\n", total)
+
+	return nil
+}
+
+// invokeWithin invokes a completion action with an empty context, and
+// returns an error if it panics or does not return within timeout.
+func invokeWithin(action comp.Action, timeout time.Duration) error {
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+
+		action.Invoke(comp.Context{})
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timeout after %s", timeout)
+	}
+}
+
+// walkCommands calls visit on a command and all of its subcommands.
+func walkCommands(cmd *cobra.Command, visit func(*cobra.Command)) {
+	visit(cmd)
+
+	for _, sub := range cmd.Commands() {
+		walkCommands(sub, visit)
+	}
+}
@@ -0,0 +1,292 @@
+package gcomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+	"github.com/octago/sflags/internal/validation"
+)
+
+// specAnnotation holds the static completions of a command, in JSON.
+const specAnnotation = "completion-spec"
+
+// commandSpec holds the static completions of a command, as found by Gen,
+// to be exported in a carapace spec: dynamic completers (implemented by
+// types or commands) can only run in the binary, and are not exported.
+type commandSpec struct {
+	Flags         map[string][]string `json:"flags,omitempty"`
+	Positional    [][]string          `json:"positional,omitempty"`
+	PositionalAny []string            `json:"positionalany"`
+}
+
+// specOf returns the static completions recorded for a command, if any.
+func specOf(cmd *cobra.Command) *commandSpec {
+	encoded, found := cmd.Annotations[specAnnotation]
+	if !found {
+		return nil
+	}
+
+	spec := &commandSpec{}
+	if err := json.Unmarshal([]byte(encoded), spec); err != nil {
+		return nil
+	}
+
+	return spec
+}
+
+// updateSpec records the static completions of a command, as updated by update.
+func updateSpec(cmd *cobra.Command, update func(spec *commandSpec)) {
+	spec := specOf(cmd)
+	if spec == nil {
+		spec = &commandSpec{}
+	}
+
+	if spec.Flags == nil {
+		spec.Flags = map[string][]string{}
+	}
+
+	update(spec)
+
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+
+	encoded, _ := json.Marshal(spec)
+	cmd.Annotations[specAnnotation] = string(encoded)
+}
+
+// registerFlagSpec records the static completions of the flags of a command.
+func registerFlagSpec(cmd *cobra.Command, values map[string][]string) {
+	updateSpec(cmd, func(spec *commandSpec) {
+		for flag, vals := range values {
+			spec.Flags[flag] = vals
+		}
+	})
+}
+
+// registerArgSpec records the static completions of the next positional
+// argument of a command, accepting up to maximum words (-1: any number).
+func registerArgSpec(cmd *cobra.Command, values []string, maximum int) {
+	updateSpec(cmd, func(spec *commandSpec) {
+		if spec.PositionalAny != nil {
+			return
+		}
+
+		if maximum == -1 {
+			spec.PositionalAny = append([]string{}, values...)
+
+			return
+		}
+
+		for i := 0; i < maximum; i++ {
+			spec.Positional = append(spec.Positional, values)
+		}
+	})
+}
+
+// specValues returns the values and carapace macros completing a field,
+// when they are known without running the binary.
+func specValues(mtag tag.MultiTag) []string {
+	if choices := validation.ParseChoices(mtag); len(choices) > 0 {
+		return choices
+	}
+
+	for _, complete := range mtag.GetMany(completeTagName) {
+		name, value, _ := strings.Cut(complete, ",")
+
+		switch name {
+		case "Files", "FilterExt":
+			return []string{filesMacro(strings.Split(value, ","))}
+		case "Dirs", "FilterDirs":
+			return []string{"$directories"}
+		}
+	}
+
+	if convert.IsPath(mtag) {
+		return []string{filesMacro(convert.Extensions(mtag))}
+	}
+
+	if example, _ := mtag.Get("example-value"); example != "" {
+		return []string{example}
+	}
+
+	return nil
+}
+
+// filesMacro returns the carapace macro completing files with some extensions.
+func filesMacro(exts []string) string {
+	var quoted []string
+
+	for _, ext := range exts {
+		if ext != "" {
+			quoted = append(quoted, ext)
+		}
+	}
+
+	if len(quoted) == 0 {
+		return "$files"
+	}
+
+	return "$files([" + strings.Join(quoted, ", ") + "])"
+}
+
+// WriteSpec writes a carapace spec (YAML) describing the command tree of cmd,
+// with its flags and the completions found by Gen that are static: allowed
+// choices, files and directories, and example values. It can be installed for
+// carapace-bin users, even when the binary does not embed its completions.
+func WriteSpec(w io.Writer, cmd *cobra.Command) error {
+	buf := &strings.Builder{}
+	writeSpec(buf, cmd, "")
+
+	_, err := io.WriteString(w, buf.String())
+
+	return err
+}
+
+// writeSpec writes the spec of a command and its subcommands, at some indentation.
+func writeSpec(buf *strings.Builder, cmd *cobra.Command, indent string) {
+	fmt.Fprintf(buf, "name: %s\n", quote(cmd.Name()))
+
+	// Subcommands are items of a list, indented after their dash.
+	if indent != "" {
+		indent += "  "
+	}
+
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(buf, "%saliases: [%s]\n", indent, quoteAll(cmd.Aliases))
+	}
+
+	if cmd.Short != "" {
+		fmt.Fprintf(buf, "%sdescription: %s\n", indent, quote(cmd.Short))
+	}
+
+	if cmd.Hidden {
+		fmt.Fprintf(buf, "%shidden: true\n", indent)
+	}
+
+	writeSpecFlags(buf, "flags", cmd.LocalNonPersistentFlags(), indent)
+	writeSpecFlags(buf, "persistentflags", cmd.PersistentFlags(), indent)
+
+	if spec := specOf(cmd); spec != nil {
+		writeSpecCompletion(buf, spec, indent)
+	}
+
+	var commands []*cobra.Command
+
+	for _, subc := range cmd.Commands() {
+		if subc.Name() != "help" && !strings.HasPrefix(subc.Name(), "__") {
+			commands = append(commands, subc)
+		}
+	}
+
+	if len(commands) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "%scommands:\n", indent)
+
+	for _, subc := range commands {
+		fmt.Fprintf(buf, "%s  - ", indent)
+		writeSpec(buf, subc, indent+"  ")
+	}
+}
+
+// writeSpecFlags writes a set of flags, as "-s, --long=: description" entries.
+func writeSpecFlags(buf *strings.Builder, key string, flags *pflag.FlagSet, indent string) {
+	var entries []string
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Name == "help" {
+			return
+		}
+
+		name := "--" + flag.Name
+		if flag.Shorthand != "" {
+			name = "-" + flag.Shorthand + ", " + name
+		}
+
+		switch {
+		case flag.NoOptDefVal == "":
+			name += "="
+		case flag.Value.Type() != "bool" && flag.NoOptDefVal != "true":
+			name += "?"
+		}
+
+		if cumulative, ok := flag.Value.(interface{ IsCumulative() bool }); ok && cumulative.IsCumulative() {
+			name += "*"
+		}
+
+		entries = append(entries, fmt.Sprintf("%s  %s: %s\n", indent, quote(name), quote(flag.Usage)))
+	})
+
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "%s%s:\n%s", indent, key, strings.Join(entries, ""))
+}
+
+// writeSpecCompletion writes the static completions of the flags and arguments of a command.
+func writeSpecCompletion(buf *strings.Builder, spec *commandSpec, indent string) {
+	flags := make([]string, 0, len(spec.Flags))
+	for flag := range spec.Flags {
+		flags = append(flags, flag)
+	}
+
+	sort.Strings(flags)
+
+	// Arguments without completions are only kept before completed ones.
+	positional := spec.Positional
+	for len(positional) > 0 && len(positional[len(positional)-1]) == 0 {
+		positional = positional[:len(positional)-1]
+	}
+
+	if len(flags) == 0 && len(positional) == 0 && len(spec.PositionalAny) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "%scompletion:\n", indent)
+
+	if len(flags) > 0 {
+		fmt.Fprintf(buf, "%s  flag:\n", indent)
+
+		for _, flag := range flags {
+			fmt.Fprintf(buf, "%s    %s: [%s]\n", indent, quote(flag), quoteAll(spec.Flags[flag]))
+		}
+	}
+
+	if len(positional) > 0 {
+		fmt.Fprintf(buf, "%s  positional:\n", indent)
+
+		for _, values := range positional {
+			fmt.Fprintf(buf, "%s    - [%s]\n", indent, quoteAll(values))
+		}
+	}
+
+	if len(spec.PositionalAny) > 0 {
+		fmt.Fprintf(buf, "%s  positionalany: [%s]\n", indent, quoteAll(spec.PositionalAny))
+	}
+}
+
+// quote returns a string as a YAML double-quoted scalar.
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quote(value)
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,412 @@
+// Package gconsole serves cobra command trees (as generated by gcobra)
+// in a closed-loop console, reading command lines from an input stream
+// and executing them until the stream is closed or the user exits.
+//
+// The console is transport-agnostic: any io.Reader/io.Writer pair can be
+// used as a session, like a local terminal or a network connection. Each
+// session gets its own command tree (and thus its own structs) so that
+// remote operators never share state between them:
+//
+//	console := gconsole.New(func() *cobra.Command {
+//		return gcobra.Parse(&App{})
+//	})
+//
+//	go console.Serve(conn, conn)
+//
+// The flags of a tree are reset to their defaults before each command line,
+// so that those given on a line (including --help) don't apply to the next,
+// as are the positionals when parsed (those not given get their defaults).
+//
+// ServeSSH serves the consoles to SSH clients, with terminals or not, and runs
+// the command lines they execute remotely, including the completion commands,
+// which completes the command lines over the wire:
+//
+//	go console.ServeSSH(ctx, listener, config)
+//
+//	$ ssh -p 2222 operator@host _carapace export app greet --
+//
+// Session-scoped dependencies (like the authenticated user) can be injected
+// into commands implementing sflags.ContextSetter with ServeContext:
+//
+//	ctx := gcobra.WithSession(context.Background(), user)
+//	go console.ServeContext(ctx, conn, conn)
+//
+// A console can also expose several independent command trees (menus), each
+// with its own root, persistent flags and completions. Sessions switch between
+// them with the "menu <name>" line, and can be started on any of them:
+//
+//	console.AddMenu("admin", func() *cobra.Command {
+//		return gcobra.Parse(&Admin{}, gcobra.WithName("admin"))
+//	})
+//
+//	go console.ServeContext(gconsole.WithMenu(ctx, "admin"), conn, conn)
+//
+// Commands switch the menu of their session themselves by returning the error
+// of SwitchMenu, like a "use <target>" command entering a target-specific menu:
+//
+//	func (u *Use) Execute(args []string) error {
+//		return &gconsole.MenuSwitch{Menu: "target", Prompt: u.Target + "> "}
+//	}
+//
+// With Settings, the flags of the root commands can be changed and printed
+// between command lines with the built-in "set <flag> <value>" and "get [flag]"
+// commands, which complete the names of the flags and their valid values.
+package gconsole
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultPrompt = "> "
+
+// MenuSwitch can be returned by a command to switch the current menu of its
+// console session once executed, instead of failing. Completions follow, as
+// they are bound to the command tree of each menu.
+type MenuSwitch struct {
+	// Menu is the name of the menu to switch to, or empty for the main one.
+	Menu string
+
+	// Prompt replaces the prompt of the menu (by default its name followed
+	// by the console prompt) until the session switches menu again.
+	Prompt string
+}
+
+// SwitchMenu returns an error switching the console session to the named menu.
+func SwitchMenu(name string) error {
+	return &MenuSwitch{Menu: name}
+}
+
+func (m *MenuSwitch) Error() string {
+	return fmt.Sprintf("switch to menu %q", m.Menu)
+}
+
+// menuKey is the context key of the menu a session starts on.
+type menuKey struct{}
+
+// ErrExit can be returned by a command to end the console session.
+var ErrExit = errors.New("exit")
+
+// Console builds and runs command trees in a closed-loop shell.
+type Console struct {
+	// Prompt is printed before reading each command line.
+	Prompt string
+
+	// PromptFunc, if set, computes the prompt instead, from
+	// the state of the session (current menu, last error).
+	PromptFunc PromptFunc
+
+	// Settings adds the built-in "set <flag> <value>" and "get [flag]"
+	// commands to the command trees (unless they have their own), which
+	// change and print the values of the flags of their root command.
+	Settings bool
+
+	// newRoot builds a new command tree for each session.
+	newRoot func() *cobra.Command
+
+	// menus build the other command trees of each session, by name.
+	menus map[string]func() *cobra.Command
+}
+
+// New returns a console using newRoot to build a new command
+// tree -bound to new structs- for each session being served.
+func New(newRoot func() *cobra.Command) *Console {
+	return &Console{
+		Prompt:  defaultPrompt,
+		newRoot: newRoot,
+		menus:   map[string]func() *cobra.Command{},
+	}
+}
+
+// AddMenu registers another command tree, built with newRoot for each session
+// like the main one. Sessions switch to it with the "menu <name>" line (and back
+// to the main tree with "menu"), unless the current tree has its own menu command.
+// Each tree is built once per session, the first time it is used.
+func (c *Console) AddMenu(name string, newRoot func() *cobra.Command) {
+	c.menus[name] = newRoot
+}
+
+// WithMenu returns a context starting the sessions served with it
+// on the named menu, instead of the main command tree.
+func WithMenu(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, menuKey{}, name)
+}
+
+// Serve runs a console session, reading command lines from in and
+// writing both prompts and command output to out. It returns when
+// the input is exhausted, or when a command returns ErrExit.
+func (c *Console) Serve(in io.Reader, out io.Writer) error {
+	return c.ServeContext(context.Background(), in, out)
+}
+
+// ServeContext is like Serve, but executes all commands with ctx.
+// Session-scoped dependencies can be bound to the context with
+// gcobra.WithSession, to be injected in the session commands.
+func (c *Console) ServeContext(ctx context.Context, in io.Reader, out io.Writer) error {
+	session, err := c.newSession(ctx, &scannerLines{lines: bufio.NewScanner(in), out: out}, out)
+	if err != nil {
+		return err
+	}
+
+	return session.serve(ctx)
+}
+
+// lineReader reads the command lines of a session, after printing their prompt,
+// and the secrets prompted for by its commands. It returns io.EOF once the input
+// is exhausted.
+type lineReader interface {
+	readLine(prompt string) (string, error)
+	readSecret(prompt string) (string, error)
+}
+
+// scannerLines reads command lines from a plain input stream.
+type scannerLines struct {
+	lines *bufio.Scanner
+	out   io.Writer
+}
+
+func (s *scannerLines) readLine(prompt string) (string, error) {
+	fmt.Fprint(s.out, prompt)
+
+	if !s.lines.Scan() {
+		if err := s.lines.Err(); err != nil {
+			return "", err
+		}
+
+		return "", io.EOF
+	}
+
+	return s.lines.Text(), nil
+}
+
+// readSecret reads a line as any other, since plain streams don't echo their input.
+func (s *scannerLines) readSecret(prompt string) (string, error) {
+	return s.readLine(prompt)
+}
+
+// sessionInput is the input of the commands of a session (see cobra.Command.SetIn),
+// read from its lines, like values given as the stdin placeholder of their flags,
+// and secrets (see gcobra.SecretReader), so that they are read from the session.
+type sessionInput struct {
+	lines  lineReader
+	buffer []byte
+}
+
+func (in *sessionInput) Read(p []byte) (int, error) {
+	if len(in.buffer) == 0 {
+		line, err := in.lines.readLine("")
+		if err != nil {
+			return 0, err
+		}
+
+		in.buffer = []byte(line + "\n")
+	}
+
+	n := copy(p, in.buffer)
+	in.buffer = in.buffer[n:]
+
+	return n, nil
+}
+
+func (in *sessionInput) ReadSecret(prompt string) (string, error) {
+	return in.lines.readSecret(prompt)
+}
+
+// serve runs the session on the lines read, until they are exhausted or a command exits.
+func (s *session) serve(ctx context.Context) error {
+	for {
+		line, err := s.in.lines.readLine(s.prompt())
+
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+
+		if s.runLine(ctx, line) {
+			return nil
+		}
+	}
+}
+
+// session holds the command trees of a console session, and the current one.
+type session struct {
+	console  *Console
+	in       *sessionInput
+	out      io.Writer
+	roots    map[string]*cobra.Command
+	defaults map[string]flagDefaults
+	root     *cobra.Command
+	menu     string
+
+	// menuPrompt, if set, is the prompt of the current menu.
+	menuPrompt string
+
+	// lastErr is the error of the last command line, if any.
+	lastErr error
+}
+
+// newSession returns a session reading lines and writing to out,
+// started on the menu of ctx (see WithMenu).
+func (c *Console) newSession(ctx context.Context, lines lineReader, out io.Writer) (*session, error) {
+	session := &session{
+		console:  c,
+		in:       &sessionInput{lines: lines},
+		out:      out,
+		roots:    map[string]*cobra.Command{},
+		defaults: map[string]flagDefaults{},
+	}
+
+	menu, _ := ctx.Value(menuKey{}).(string)
+	if err := session.switchTo(menu); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// runLine executes a command line on the current command tree of the session,
+// whose flags are first reset to their defaults, so that those given on a line
+// don't apply to the next ones. It returns true if the session must end.
+func (s *session) runLine(ctx context.Context, line string) bool {
+	words, err := SplitWords(line)
+	if err != nil {
+		fmt.Fprintf(s.out, "Error: %s\n", err)
+		s.lastErr = err
+
+		return false
+	}
+
+	if len(words) == 0 {
+		return false
+	}
+
+	if words[0] == "exit" && !hasCommand(s.root, "exit") {
+		return true
+	}
+
+	if words[0] == "menu" && !hasCommand(s.root, "menu") {
+		err = s.menuCommand(words[1:])
+	} else {
+		s.defaults[s.menu].reset(s.root)
+		err = execute(ctx, s.root, words)
+	}
+
+	var switched *MenuSwitch
+
+	switch {
+	case errors.Is(err, ErrExit):
+		return true
+	case errors.As(err, &switched):
+		if err = s.switchTo(switched.Menu); err != nil {
+			fmt.Fprintf(s.out, "Error: %s\n", err)
+		} else {
+			s.menuPrompt = switched.Prompt
+		}
+	case err != nil:
+		fmt.Fprintf(s.out, "Error: %s\n", err)
+	}
+
+	s.lastErr = err
+
+	return false
+}
+
+// switchTo makes the named menu the current one, building its tree if needed.
+// The main command tree has an empty name.
+func (s *session) switchTo(menu string) error {
+	if root, built := s.roots[menu]; built {
+		s.root, s.menu, s.menuPrompt = root, menu, ""
+
+		return nil
+	}
+
+	newRoot := s.console.newRoot
+	if menu != "" {
+		newRoot = s.console.menus[menu]
+	}
+
+	if newRoot == nil {
+		return fmt.Errorf("console: unknown menu %q", menu)
+	}
+
+	root := newRoot()
+	if root == nil {
+		if menu == "" {
+			return errors.New("console: no root command")
+		}
+
+		return fmt.Errorf("console: no root command for menu %q", menu)
+	}
+
+	root.SetIn(s.in)
+	root.SetOut(s.out)
+	root.SetErr(s.out)
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+
+	defaults := saveDefaults(root)
+
+	if s.console.Settings {
+		addSettings(root, defaults)
+	}
+
+	s.roots[menu] = root
+	s.defaults[menu] = defaults
+	s.root, s.menu, s.menuPrompt = root, menu, ""
+
+	return nil
+}
+
+// menuCommand switches to the menu given as argument, or to the main
+// command tree without one. With an unknown menu, it lists the menus.
+func (s *session) menuCommand(args []string) error {
+	if len(args) > 1 {
+		return errors.New("menu: accepts at most one menu name")
+	}
+
+	var menu string
+	if len(args) == 1 {
+		menu = args[0]
+	}
+
+	if _, found := s.console.menus[menu]; menu != "" && !found {
+		names := make([]string, 0, len(s.console.menus))
+		for name := range s.console.menus {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("menu: unknown menu %q (available: %s)", menu, strings.Join(names, ", "))
+	}
+
+	return s.switchTo(menu)
+}
+
+// execute runs a single command line on the session command tree.
+func execute(ctx context.Context, root *cobra.Command, words []string) error {
+	root.SetArgs(words)
+
+	_, err := root.ExecuteContextC(ctx)
+
+	return err
+}
+
+func hasCommand(root *cobra.Command, name string) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,484 @@
+package gconsole
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/octago/sflags/gen/gcobra"
+)
+
+type app struct {
+	Greet greetCmd `command:"greet"`
+	Quit  quitCmd  `command:"quit"`
+}
+
+type greetCmd struct {
+	Name string `long:"name"`
+
+	greeted []string
+}
+
+func (g *greetCmd) Execute(args []string) error {
+	g.greeted = append(g.greeted, g.Name)
+
+	return nil
+}
+
+type quitCmd struct{}
+
+func (quitCmd) Execute(args []string) error { return ErrExit }
+
+func TestServe(t *testing.T) {
+	sessions := []*app{}
+
+	console := New(func() *cobra.Command {
+		data := &app{}
+		sessions = append(sessions, data)
+
+		return gcobra.Parse(data)
+	})
+
+	out := &bytes.Buffer{}
+	input := "greet --name alice\n\ngreet --name 'bob smith'\ngreet --unknown\nquit\ngreet --name never\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, []string{"alice", "bob smith"}, sessions[0].Greet.greeted)
+	assert.Contains(t, out.String(), "Error: unknown flag: --unknown")
+
+	// A new session gets a new command tree.
+	require.NoError(t, console.Serve(strings.NewReader("greet --name carol\nexit\n"), out))
+	require.Len(t, sessions, 2)
+	assert.Equal(t, []string{"carol"}, sessions[1].Greet.greeted)
+}
+
+type admin struct {
+	Ban banCmd `command:"ban"`
+}
+
+type banCmd struct {
+	User string `long:"user"`
+
+	banned []string
+}
+
+func (b *banCmd) Execute(args []string) error {
+	b.banned = append(b.banned, b.User)
+
+	return nil
+}
+
+func TestServeMenus(t *testing.T) {
+	var (
+		clients []*app
+		admins  []*admin
+	)
+
+	console := New(func() *cobra.Command {
+		data := &app{}
+		clients = append(clients, data)
+
+		return gcobra.Parse(data, gcobra.WithName("client"))
+	})
+	console.AddMenu("admin", func() *cobra.Command {
+		data := &admin{}
+		admins = append(admins, data)
+
+		return gcobra.Parse(data, gcobra.WithName("admin"))
+	})
+
+	out := &bytes.Buffer{}
+	input := "menu admin\nban --user eve\nmenu\ngreet --name alice\nmenu admin\nban --user trudy\nmenu root\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	require.Len(t, clients, 1)
+	require.Len(t, admins, 1, "a menu is built once per session")
+	assert.Equal(t, []string{"alice"}, clients[0].Greet.greeted)
+	assert.Equal(t, []string{"eve", "trudy"}, admins[0].Ban.banned)
+	assert.Contains(t, out.String(), `Error: menu: unknown menu "root" (available: admin)`)
+
+	// Sessions can start on another menu than the main one.
+	out.Reset()
+
+	ctx := WithMenu(context.Background(), "admin")
+	require.NoError(t, console.ServeContext(ctx, strings.NewReader("ban --user oscar\n"), out))
+	require.Len(t, admins, 2)
+	assert.Equal(t, []string{"oscar"}, admins[1].Ban.banned)
+	assert.True(t, strings.HasPrefix(out.String(), "admin> "))
+}
+
+type c2 struct {
+	Use useCmd `command:"use"`
+}
+
+type useCmd struct {
+	Target string `long:"target"`
+}
+
+func (u *useCmd) Execute(args []string) error {
+	return &MenuSwitch{Menu: "target", Prompt: u.Target + "> "}
+}
+
+type target struct {
+	Back backCmd `command:"back"`
+}
+
+type backCmd struct{}
+
+func (backCmd) Execute(args []string) error { return SwitchMenu("") }
+
+func TestServeMenuSwitch(t *testing.T) {
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(&c2{}, gcobra.WithName("c2"))
+	})
+	console.AddMenu("target", func() *cobra.Command {
+		return gcobra.Parse(&target{}, gcobra.WithName("target"))
+	})
+
+	out := &bytes.Buffer{}
+	input := "use --target host1\nback\nuse --target host2\nmenu target\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	assert.Equal(t, "> host1> > host2> target> ", out.String())
+}
+
+func TestServePromptFunc(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	active := "none"
+
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(&c2{}, gcobra.WithName("c2"))
+	})
+	console.AddMenu("target", func() *cobra.Command {
+		return gcobra.Parse(&target{}, gcobra.WithName("target"))
+	})
+	console.PromptFunc = func(state PromptState) string {
+		status := Green.Paint(state, "ok")
+		if state.Err != nil {
+			status = Red.Paint(state, "err")
+		}
+
+		return fmt.Sprintf("[%s] %s@%s $ ", status, state.Menu, active)
+	}
+
+	out := &bytes.Buffer{}
+	input := "use --target host1\nback --bad\nback\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	assert.Equal(t, "[\x1b[32mok\x1b[0m] @none $ "+
+		"[\x1b[32mok\x1b[0m] target@none $ "+
+		"Error: unknown flag: --bad\n"+
+		"[\x1b[31merr\x1b[0m] target@none $ "+
+		"[\x1b[32mok\x1b[0m] @none $ ", out.String())
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		line   string
+		words  []string
+		expErr error
+	}{
+		{line: "", words: nil},
+		{line: "  a  b\tc ", words: []string{"a", "b", "c"}},
+		{line: `a "b c" 'd e'`, words: []string{"a", "b c", "d e"}},
+		{line: `a "b \"c\"" 'd \e'`, words: []string{"a", `b "c"`, `d \e`}},
+		{line: `a b\ c ""`, words: []string{"a", "b c", ""}},
+		{line: `a "b`, words: []string{"a"}, expErr: ErrUnterminatedQuote},
+	}
+
+	for _, test := range tests {
+		words, err := SplitWords(test.line)
+		assert.Equal(t, test.expErr, err, test.line)
+		assert.Equal(t, test.words, words, test.line)
+	}
+}
+
+type configured struct {
+	Level string   `long:"level" choice:"info" choice:"debug"`
+	Debug bool     `long:"debug"`
+	Token string   `long:"token" secret:"true"`
+	Greet greetCmd `command:"greet"`
+}
+
+func TestServeSettings(t *testing.T) {
+	cfg := &configured{Level: "info"}
+
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(cfg, gcobra.WithName("app"))
+	})
+	console.Prompt = ""
+	console.Settings = true
+
+	out := &bytes.Buffer{}
+	input := "set level debug\nget level\nset level loud\nset token s3cr3t\nset port 80\nget\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	assert.Equal(t, "debug", cfg.Level)
+	assert.Equal(t, "s3cr3t", cfg.Token)
+	assert.Equal(t, "debug\n"+
+		`Error: set: invalid argument "loud" for "level" flag: invalid choice "loud": must be one of info, debug`+"\n"+
+		`Error: set: unknown flag "port"`+"\n"+
+		"debug = false\nlevel = debug\ntoken = ********\n", out.String())
+
+	out.Reset()
+	require.NoError(t, console.Serve(strings.NewReader("__complete set level \"\"\n"), out))
+	assert.Contains(t, out.String(), "info\ndebug\n:4\n")
+}
+
+func TestServeResetFlags(t *testing.T) {
+	cfg := &configured{Level: "info"}
+
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(cfg, gcobra.WithName("app"))
+	})
+	console.Prompt = ""
+	console.Settings = true
+
+	out := &bytes.Buffer{}
+	input := "greet --name alice\ngreet\ngreet --help\ngreet\n--debug greet\nset level debug\ngreet\n"
+
+	// Flags given on a line don't apply to the next ones, unlike settings.
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	assert.Equal(t, []string{"alice", "", "", "", ""}, cfg.Greet.greeted)
+	assert.Equal(t, 1, strings.Count(out.String(), "Usage:"))
+	assert.False(t, cfg.Debug)
+	assert.Equal(t, "debug", cfg.Level)
+}
+
+type remote struct {
+	Greet  greetCmd  `command:"greet"`
+	Whoami whoamiCmd `command:"whoami"`
+}
+
+type whoamiCmd struct {
+	user string
+}
+
+func (w *whoamiCmd) SetContext(session interface{}) {
+	w.user = session.(*ssh.ServerConn).User()
+}
+
+func (*whoamiCmd) Execute(args []string) error { return nil }
+
+func TestServeSSH(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		sessions []*remote
+	)
+
+	console := New(func() *cobra.Command {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		data := &remote{}
+		sessions = append(sessions, data)
+
+		return gcobra.Parse(data, gcobra.WithName("app"))
+	})
+	console.Prompt = "$ "
+
+	_, hostKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() != "operator" || string(password) != "secret" {
+				return nil, errors.New("access denied")
+			}
+
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+
+	go func() { served <- console.ServeSSH(context.Background(), listener, config) }()
+
+	client, err := ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+		User:            "operator",
+		Auth:            []ssh.AuthMethod{ssh.Password("secret")},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	require.NoError(t, err)
+
+	defer client.Close()
+
+	run := func(line string) (string, error) {
+		session, err := client.NewSession()
+		require.NoError(t, err)
+
+		defer session.Close()
+
+		out, err := session.CombinedOutput(line)
+
+		return string(out), err
+	}
+
+	// Single command lines, with their own session.
+	_, err = run("whoami")
+	require.NoError(t, err)
+
+	out, err := run("greet --unknown")
+	assert.Contains(t, out, "Error: unknown flag: --unknown")
+
+	var exitErr *ssh.ExitError
+
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitStatus())
+
+	// Completions over the wire.
+	out, err = run("__complete greet --")
+	require.NoError(t, err)
+	assert.Contains(t, out, "--name")
+
+	// A console session without a terminal.
+	session, err := client.NewSession()
+	require.NoError(t, err)
+
+	session.Stdin = strings.NewReader("greet --name alice\nexit\n")
+	stdout := &bytes.Buffer{}
+	session.Stdout = stdout
+
+	require.NoError(t, session.Shell())
+	require.NoError(t, session.Wait())
+	assert.Equal(t, "$ $ ", stdout.String())
+
+	// And with a terminal, which reads lines ended by carriage returns.
+	session, err = client.NewSession()
+	require.NoError(t, err)
+
+	session.Stdin = strings.NewReader("greet --name bob\rgreet --unknown\rexit\r")
+	stdout.Reset()
+	session.Stdout = stdout
+
+	require.NoError(t, session.RequestPty("xterm", 24, 80, ssh.TerminalModes{}))
+	require.NoError(t, session.Shell())
+	require.NoError(t, session.Wait())
+	assert.Contains(t, stdout.String(), "Error: unknown flag: --unknown\r\n")
+
+	require.NoError(t, listener.Close())
+	require.NoError(t, <-served)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	require.Len(t, sessions, 5)
+	assert.Equal(t, "operator", sessions[0].Whoami.user)
+	assert.Equal(t, []string{"alice"}, sessions[3].Greet.greeted)
+	assert.Equal(t, []string{"bob"}, sessions[4].Greet.greeted)
+}
+
+type portCmd struct {
+	Port int `long:"port"`
+
+	ports []int
+}
+
+func (p *portCmd) Execute(args []string) error {
+	p.ports = append(p.ports, p.Port)
+
+	return nil
+}
+
+func TestServeResetProfiles(t *testing.T) {
+	data := &struct {
+		Serve portCmd `command:"serve"`
+	}{}
+
+	profiles := map[string]interface{}{"dev": map[string]string{"port": "8080"}}
+
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(data, gcobra.WithName("app"), gcobra.WithProfiles(profiles))
+	})
+
+	// Flags given on a previous line don't override the profile.
+	input := "serve --port 22\nserve --profile dev\nserve\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), io.Discard))
+	assert.Equal(t, []int{22, 8080, 0}, data.Serve.ports)
+}
+
+type targetCmd struct {
+	Args struct {
+		Host string
+		Port string `default:"22"`
+	} `positional-args:"yes"`
+
+	targets []string
+}
+
+func (c *targetCmd) Execute(args []string) error {
+	c.targets = append(c.targets, c.Args.Host+":"+c.Args.Port)
+
+	return nil
+}
+
+func TestServeResetPositionals(t *testing.T) {
+	data := &struct {
+		Connect targetCmd `command:"connect"`
+	}{}
+
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(data, gcobra.WithName("app"))
+	})
+
+	// Optional positionals not given don't keep the words of the previous line.
+	input := "connect alpha 2222\nconnect beta\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), io.Discard))
+	assert.Equal(t, []string{"alpha:2222", "beta:22"}, data.Connect.targets)
+}
+
+type loginCmd struct {
+	Token string `long:"token" secret:"true"`
+
+	tokens []string
+}
+
+func (l *loginCmd) Execute(args []string) error {
+	l.tokens = append(l.tokens, l.Token)
+
+	return nil
+}
+
+func TestServeSecretPrompt(t *testing.T) {
+	data := &struct {
+		Login loginCmd `command:"login"`
+	}{}
+
+	console := New(func() *cobra.Command {
+		return gcobra.Parse(data, gcobra.WithName("app"))
+	})
+	console.Prompt = ""
+
+	// Secrets are prompted for on the session, not on the process terminal.
+	out := &bytes.Buffer{}
+	input := "login --token\nhunter2\nlogin\n"
+
+	require.NoError(t, console.Serve(strings.NewReader(input), out))
+	assert.Equal(t, []string{"hunter2", ""}, data.Login.tokens)
+	assert.Contains(t, out.String(), "token: ")
+	assert.NotContains(t, out.String(), "hunter2")
+}
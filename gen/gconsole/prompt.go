@@ -0,0 +1,69 @@
+package gconsole
+
+import "github.com/octago/sflags/internal/term"
+
+// PromptState is the state of a console session, from which
+// its prompt is computed before reading each command line.
+type PromptState struct {
+	// Menu is the name of the current menu, empty for the main one.
+	Menu string
+
+	// Prompt is the prompt given by the command which switched
+	// to the current menu (see MenuSwitch), if any.
+	Prompt string
+
+	// Err is the error returned by the last command line, if any.
+	Err error
+
+	// Color is true if the session output renders colors:
+	// it is a terminal, and NO_COLOR is not set.
+	Color bool
+}
+
+// PromptFunc computes the prompt of a console session from its state.
+// The application state (like the active target) can be captured by
+// the function, as prompts are computed again for each command line.
+type PromptFunc func(state PromptState) string
+
+// Color is an ANSI color (or style) for prompts.
+type Color string
+
+// Colors for prompts.
+const (
+	Bold   Color = "1"
+	Red    Color = "31"
+	Green  Color = "32"
+	Yellow Color = "33"
+	Blue   Color = "34"
+	Cyan   Color = "36"
+)
+
+// Paint returns text in color, when the session output renders colors.
+func (c Color) Paint(state PromptState, text string) string {
+	if !state.Color {
+		return text
+	}
+
+	return "\x1b[" + string(c) + "m" + text + "\x1b[0m"
+}
+
+// prompt returns the prompt printed before reading each command line:
+// the one computed by the console PromptFunc, if any, or the prompt of
+// the menu, or the name of the menu followed by the console prompt.
+func (s *session) prompt() string {
+	state := PromptState{
+		Menu:   s.menu,
+		Prompt: s.menuPrompt,
+		Err:    s.lastErr,
+		Color:  term.SupportsColor(s.out),
+	}
+
+	switch {
+	case s.console.PromptFunc != nil:
+		return s.console.PromptFunc(state)
+	case state.Prompt != "":
+		return state.Prompt
+	default:
+		return state.Menu + s.console.Prompt
+	}
+}
@@ -0,0 +1,97 @@
+package gconsole
+
+import (
+	"reflect"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
+)
+
+// flagDefaults holds the default values of the struct fields bound to the flags
+// of a command tree, by field, to reset them before executing each command line.
+type flagDefaults map[fieldKey]reflect.Value
+
+// fieldKey identifies a struct field by its address and type,
+// the first field of a struct sharing the address of the struct.
+type fieldKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+func keyOf(field reflect.Value) fieldKey {
+	return fieldKey{addr: field.Addr().Pointer(), typ: field.Type()}
+}
+
+// saveDefaults saves the current values of the flags of the command tree of root.
+func saveDefaults(root *cobra.Command) flagDefaults {
+	defaults := flagDefaults{}
+
+	walkFlags(root, func(flag *pflag.Flag) {
+		if _, field, found := sflags.Field(flag.Value); found {
+			defaults.save(field)
+		}
+	})
+
+	return defaults
+}
+
+// save makes the current value of a field its default, like when it is set
+// with the built-in set command.
+func (d flagDefaults) save(field reflect.Value) {
+	d[keyOf(field)] = clone(field)
+}
+
+// reset sets the flags of the command tree of root to their defaults, and marks
+// them as not given (see sflags.Unset). The flags not bound to struct fields
+// (like --help) are set to the default value of the flag, if they have been given.
+func (d flagDefaults) reset(root *cobra.Command) {
+	walkFlags(root, func(flag *pflag.Flag) {
+		_, field, found := sflags.Field(flag.Value)
+
+		switch {
+		case found:
+			if value, saved := d[keyOf(field)]; saved {
+				field.Set(clone(value))
+			}
+
+			sflags.Unset(flag.Value)
+		case flag.Changed:
+			_ = flag.Value.Set(flag.DefValue)
+		}
+
+		flag.Changed = false
+	})
+}
+
+// clone returns a copy of a value, which does not share
+// the elements of the value if it is a slice or a map.
+func clone(val reflect.Value) reflect.Value {
+	cloned := reflect.New(val.Type()).Elem()
+
+	switch {
+	case val.Kind() == reflect.Slice && !val.IsNil():
+		cloned.Set(reflect.AppendSlice(reflect.MakeSlice(val.Type(), 0, val.Len()), val))
+	case val.Kind() == reflect.Map && !val.IsNil():
+		cloned.Set(reflect.MakeMapWithSize(val.Type(), val.Len()))
+
+		for iter := val.MapRange(); iter.Next(); {
+			cloned.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		cloned.Set(val)
+	}
+
+	return cloned
+}
+
+// walkFlags calls visit for the flags of all the commands of
+// the tree of cmd, once for each flag, where it is declared.
+func walkFlags(cmd *cobra.Command, visit func(flag *pflag.Flag)) {
+	cmd.LocalFlags().VisitAll(visit)
+
+	for _, sub := range cmd.Commands() {
+		walkFlags(sub, visit)
+	}
+}
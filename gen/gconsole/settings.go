@@ -0,0 +1,150 @@
+package gconsole
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
+)
+
+// addSettings adds the built-in set and get commands to a command
+// tree, unless it has its own commands with these names.
+func addSettings(root *cobra.Command, defaults flagDefaults) {
+	if !hasCommand(root, "set") {
+		root.AddCommand(setCommand(root, defaults))
+	}
+
+	if !hasCommand(root, "get") {
+		root.AddCommand(getCommand(root))
+	}
+}
+
+// setCommand returns the command setting a flag of the root command,
+// converted and validated like when given on the command line. The
+// value becomes the default of the flag for the next command lines.
+func setCommand(root *cobra.Command, defaults flagDefaults) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <flag> <value>",
+		Short: "Set the value of a flag",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flag, _, found := sflags.Lookup(root, args[0])
+			if !found {
+				return fmt.Errorf("set: unknown flag %q", args[0])
+			}
+
+			if err := flag.Set(args[1]); err != nil {
+				value := args[1]
+				if flag.Secret {
+					value = "********"
+				}
+
+				return fmt.Errorf("set: invalid argument %q for %q flag: %w", value, args[0], err)
+			}
+
+			if _, field, bound := sflags.Field(flag.Value); bound {
+				defaults.save(field)
+			}
+
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			switch len(args) {
+			case 0:
+				return settingNames(root), cobra.ShellCompDirectiveNoFileComp
+			case 1:
+				return settingValues(root, args[0]), cobra.ShellCompDirectiveNoFileComp
+			default:
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+		},
+	}
+}
+
+// getCommand returns the command printing the value of a flag
+// of the root command, or of all of them without arguments.
+func getCommand(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [flag]",
+		Short: "Print the value of a flag, or of all flags",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := args
+			if len(names) == 0 {
+				names = settingNames(root)
+			}
+
+			for _, name := range names {
+				flag, _, found := sflags.Lookup(root, name)
+				if !found {
+					return fmt.Errorf("get: unknown flag %q", name)
+				}
+
+				value := flag.DisplayValue(flag.Value.String())
+				if flag.Secret && value != "" {
+					value = "********"
+				}
+
+				if len(args) == 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s = %s\n", name, value)
+				} else {
+					fmt.Fprintln(cmd.OutOrStdout(), value)
+				}
+			}
+
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return settingNames(root), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+}
+
+// settingNames returns the sorted names of the visible
+// flags of the root command which have been parsed by sflags.
+func settingNames(root *cobra.Command) []string {
+	var names []string
+
+	root.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		if _, _, found := sflags.Lookup(root, flag.Name); found {
+			names = append(names, flag.Name)
+		}
+	})
+
+	sort.Strings(names)
+
+	return names
+}
+
+// settingValues returns the values completing a flag of the root command.
+func settingValues(root *cobra.Command, name string) []string {
+	flag, _, found := sflags.Lookup(root, name)
+	if !found {
+		return nil
+	}
+
+	if len(flag.Choices) > 0 {
+		return flag.Choices
+	}
+
+	if boolFlag, ok := flag.Value.(sflags.BoolFlag); ok && boolFlag.IsBoolFlag() {
+		return []string{"true", "false"}
+	}
+
+	if flag.Example != "" {
+		return []string{flag.Example}
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package gconsole
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+
+	"github.com/octago/sflags/gen/gcobra"
+)
+
+// ServeSSH accepts SSH connections on listener, authenticated with config, and
+// serves the session channels opened on them, until the listener is closed.
+// Each channel gets its own command trees, like the sessions of Serve:
+//
+//   - A shell request serves a console session, read from a terminal when the
+//     client requested one (line editing and history included), and from the
+//     channel as is otherwise (eg. `ssh host < script`).
+//   - An exec request runs a single command line, whose result is given as the
+//     exit status of the channel: `ssh host greet --name bob`.
+//
+// Exec requests also complete command lines over the wire, with the hidden
+// completion commands of the trees: `ssh host __complete greet --`, or, with
+// carapace completions (see gcomp), `ssh host _carapace export app greet --`,
+// which prints the completions of the line as JSON.
+//
+// Commands are executed with ctx, in which the connection (*ssh.ServerConn) of
+// their session is bound with gcobra.WithSession, to be injected in commands
+// implementing sflags.ContextSetter (eg. to know the user and its permissions).
+func (c *Console) ServeSSH(ctx context.Context, listener net.Listener, config *ssh.ServerConfig) error {
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		go c.serveConn(ctx, conn, config)
+	}
+}
+
+// serveConn serves the session channels of an SSH connection,
+// and rejects the other ones, once the client is authenticated.
+func (c *Console) serveConn(ctx context.Context, conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+
+		return
+	}
+
+	defer sshConn.Close()
+
+	go ssh.DiscardRequests(requests)
+
+	ctx = gcobra.WithSession(ctx, sshConn)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go c.serveChannel(ctx, channel, requests)
+	}
+}
+
+// serveChannel answers the requests of a session channel, and serves the
+// console session or the command line requested, once, on the channel.
+func (c *Console) serveChannel(ctx context.Context, channel ssh.Channel, requests <-chan *ssh.Request) {
+	var (
+		terminal *term.Terminal
+		started  bool
+	)
+
+	for req := range requests {
+		var (
+			accepted bool
+			command  struct{ Line string }
+			pty      ptyRequest
+			window   windowSize
+		)
+
+		switch {
+		case req.Type == "pty-req" && !started:
+			if ssh.Unmarshal(req.Payload, &pty) != nil {
+				break
+			}
+
+			terminal, accepted = term.NewTerminal(channel, ""), true
+			_ = terminal.SetSize(int(pty.Columns), int(pty.Rows))
+		case req.Type == "window-change" && terminal != nil:
+			if ssh.Unmarshal(req.Payload, &window) == nil {
+				_ = terminal.SetSize(int(window.Columns), int(window.Rows))
+			}
+		case req.Type == "shell" && !started:
+			started, accepted = true, true
+
+			go c.serveShell(ctx, channel, terminal)
+		case req.Type == "exec" && !started:
+			if ssh.Unmarshal(req.Payload, &command) != nil {
+				break
+			}
+
+			started, accepted = true, true
+
+			go c.serveExec(ctx, channel, command.Line)
+		}
+
+		if req.WantReply {
+			_ = req.Reply(accepted, nil)
+		}
+	}
+}
+
+// ptyRequest is the payload of a pty-req request (RFC 4254, 6.2).
+type ptyRequest struct {
+	Term                         string
+	Columns, Rows, Width, Height uint32
+	Modes                        string
+}
+
+// windowSize is the payload of a window-change request (RFC 4254, 6.7).
+type windowSize struct {
+	Columns, Rows, Width, Height uint32
+}
+
+// serveShell serves a console session on a channel, through its terminal if any.
+func (c *Console) serveShell(ctx context.Context, channel ssh.Channel, terminal *term.Terminal) {
+	var err error
+
+	if terminal == nil {
+		err = c.ServeContext(ctx, channel, channel)
+	} else {
+		err = c.serveTerminal(ctx, terminal)
+	}
+
+	if err != nil {
+		fmt.Fprintf(channel.Stderr(), "Error: %s\n", err)
+	}
+
+	closeChannel(channel, err != nil)
+}
+
+// serveTerminal serves a console session on a terminal.
+func (c *Console) serveTerminal(ctx context.Context, terminal *term.Terminal) error {
+	session, err := c.newSession(ctx, terminalLines{terminal}, terminal)
+	if err != nil {
+		return err
+	}
+
+	return session.serve(ctx)
+}
+
+// serveExec runs a single command line on a channel.
+func (c *Console) serveExec(ctx context.Context, channel ssh.Channel, line string) {
+	lines := &scannerLines{lines: bufio.NewScanner(channel), out: channel}
+
+	session, err := c.newSession(ctx, lines, channel)
+	if err != nil {
+		fmt.Fprintf(channel.Stderr(), "Error: %s\n", err)
+		closeChannel(channel, true)
+
+		return
+	}
+
+	session.runLine(ctx, line)
+	closeChannel(channel, session.lastErr != nil)
+}
+
+// closeChannel sends the exit status of a session channel, and closes it.
+func closeChannel(channel ssh.Channel, failed bool) {
+	status := struct{ Status uint32 }{}
+	if failed {
+		status.Status = 1
+	}
+
+	_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(&status))
+	channel.Close()
+}
+
+// terminalLines reads command lines from a terminal, with
+// the prompt of the session as the prompt of the terminal.
+type terminalLines struct {
+	terminal *term.Terminal
+}
+
+func (t terminalLines) readLine(prompt string) (string, error) {
+	t.terminal.SetPrompt(prompt)
+
+	return t.terminal.ReadLine()
+}
+
+// readSecret reads a line from the terminal without echoing it.
+func (t terminalLines) readSecret(prompt string) (string, error) {
+	return t.terminal.ReadPassword(prompt)
+}
@@ -0,0 +1,57 @@
+package gconsole
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnterminatedQuote is returned when a command line has an unclosed quote.
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// SplitWords splits a command line into words, the way a POSIX shell would:
+// words are separated by unquoted whitespace, single quotes preserve their
+// content verbatim, and double quotes allow backslash escapes.
+func SplitWords(line string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, char := range line {
+		switch {
+		case escaped:
+			word.WriteRune(char)
+			escaped = false
+		case char == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(char)
+		case char == '\'' || char == '"':
+			quote, inWord = char, true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(char)
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return words, ErrUnterminatedQuote
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
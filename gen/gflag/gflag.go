@@ -2,9 +2,9 @@ package gflag
 
 import (
 	"flag"
-	"os"
 
 	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/platform"
 )
 
 // flagSet describes interface,
@@ -17,27 +17,52 @@ var _ flagSet = (*flag.FlagSet)(nil)
 
 // GenerateTo takes a list of sflag.Flag,
 // that are parsed from some config structure, and put it to dst.
-func GenerateTo(src []*sflags.Flag, dst flagSet) {
-	for _, srcFlag := range src {
-		dst.Var(srcFlag.Value, srcFlag.Name, srcFlag.Usage)
+// If filters are given, only the flags matching all of them are put.
+// Flags are built from their description (see sflags.Flag.Info).
+func GenerateTo(src []*sflags.Flag, dst flagSet, filters ...sflags.Filter) {
+	for _, srcFlag := range sflags.FilterFlags(src, filters...) {
+		info := srcFlag.Info()
+		dst.Var(srcFlag.Value, info.Name, info.Usage)
 	}
 }
 
 // ParseTo parses cfg, that is a pointer to some structure,
-// and puts it to dst.
+// and puts it to dst. If dst is a *flag.FlagSet, its output
+// and usage are set from the options (see sflags.Output).
 func ParseTo(cfg interface{}, dst flagSet, optFuncs ...sflags.OptFunc) error {
 	flags, err := sflags.ParseStruct(cfg, optFuncs...)
 	if err != nil {
 		return err
 	}
 	GenerateTo(flags, dst)
+	if fs, ok := dst.(*flag.FlagSet); ok {
+		present(fs, sflags.PresentationOf(optFuncs...))
+	}
 	return nil
 }
 
+// present applies the presentation settings to a flag set.
+// Flags of the flag package are always sorted in usage.
+func present(fs *flag.FlagSet, presentation sflags.Presentation) {
+	if presentation.Output != nil {
+		fs.SetOutput(presentation.Output)
+	}
+	if presentation.Usage != nil {
+		fs.Usage = presentation.Usage
+	}
+}
+
+// The generator is registered as "flag", for sflags.Generate.
+func init() {
+	sflags.RegisterGenerator("flag", func(data interface{}, optFuncs ...sflags.OptFunc) (interface{}, error) {
+		return Parse(data, optFuncs...)
+	})
+}
+
 // Parse parses cfg, that is a pointer to some structure,
 // puts it to the new flag.FlagSet and returns it.
 func Parse(cfg interface{}, optFuncs ...sflags.OptFunc) (*flag.FlagSet, error) {
-	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	fs := flag.NewFlagSet(platform.Program(), flag.ExitOnError)
 	err := ParseTo(cfg, fs, optFuncs...)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package gflag
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"os"
@@ -124,3 +125,33 @@ func TestParseToDef(t *testing.T) {
 	err = ParseToDef("bad string")
 	assert.Error(t, err)
 }
+
+func TestGenerateToFilters(t *testing.T) {
+	cfg := &struct {
+		Verbose bool   `flag:"verbose" debug:"true"`
+		Name    string `flag:"name"`
+	}{}
+
+	flags, err := sflags.ParseStruct(cfg)
+	require.NoError(t, err)
+
+	fs := flag.NewFlagSet("debug", flag.ContinueOnError)
+	GenerateTo(flags, fs, sflags.Tagged("debug", "true"))
+
+	assert.NotNil(t, fs.Lookup("verbose"))
+	assert.Nil(t, fs.Lookup("name"))
+}
+
+func TestParsePresentation(t *testing.T) {
+	cfg := &struct {
+		Name string `flag:"name"`
+	}{}
+
+	out := &bytes.Buffer{}
+
+	fs, err := Parse(cfg, sflags.Output(out))
+	require.NoError(t, err)
+
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), "-name")
+}
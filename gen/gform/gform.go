@@ -0,0 +1,188 @@
+// Package gform renders a tagged options struct as an interactive
+// terminal form, prompting the user for each flag value in turn.
+// Prompts are either read line by line (see NewPrompter), or rendered
+// and edited in the terminal with bubbletea (see NewTUIPrompter).
+package gform
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/term"
+)
+
+// Prompter is the interface implemented by form backends. A backend is
+// given the description of a flag (with its usage, choices, default and
+// secret status) and must return the raw string entered by the user. An
+// empty answer means that the current (default) value should be kept.
+type Prompter interface {
+	Prompt(flag sflags.FlagInfo) (string, error)
+}
+
+// linePrompter is the default, line-based Prompter backend.
+type linePrompter struct {
+	in   *bufio.Reader
+	file *os.File // The input, if a file (eg. a terminal), to read secrets from.
+	out  io.Writer
+}
+
+// RetryPrompter is implemented by form backends able to prompt again for
+// a flag whose answer is invalid (eg. not one of its choices, or rejected
+// by its validators). Reprompt is given the invalid answer, so that it can
+// be pre-filled, and the error explaining why it is invalid. Backends not
+// implementing it make the form fail on the first invalid answer.
+type RetryPrompter interface {
+	Reprompt(flag sflags.FlagInfo, answer string, err error) (string, error)
+}
+
+var (
+	_ Prompter      = (*linePrompter)(nil)
+	_ RetryPrompter = (*linePrompter)(nil)
+)
+
+// NewPrompter returns a simple line-based Prompter reading
+// answers from in, and writing the prompts to out. When in is
+// a terminal, the answers of secret flags are read without echo.
+func NewPrompter(in io.Reader, out io.Writer) Prompter {
+	file, _ := in.(*os.File)
+
+	return &linePrompter{
+		in:   bufio.NewReader(in),
+		file: file,
+		out:  out,
+	}
+}
+
+// Prompt prints the flag description, name, choices and default
+// value, and reads a single line of input as the flag answer.
+func (p *linePrompter) Prompt(flag sflags.FlagInfo) (string, error) {
+	if flag.Usage != "" {
+		fmt.Fprintf(p.out, "# %s\n", flag.Usage)
+	}
+
+	fmt.Fprint(p.out, flag.Name)
+
+	if len(flag.Choices) > 0 {
+		fmt.Fprintf(p.out, " (%s)", strings.Join(flag.Choices, "|"))
+	}
+
+	// Never show the current value of sensitive flags.
+	if flag.Default != "" && !flag.Secret {
+		fmt.Fprintf(p.out, " [%s]", flag.Default)
+	}
+
+	fmt.Fprint(p.out, ": ")
+
+	// Answers already buffered have been typed ahead, and echoed.
+	if flag.Secret && p.file != nil && p.in.Buffered() == 0 {
+		return term.ReadSecret(p.file, p.out, "")
+	}
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// Reprompt prints why the previous answer is invalid, and prompts again.
+// Lines cannot be pre-filled, so the invalid answer is only shown in the
+// error: an empty answer keeps the current (default) value of the flag.
+func (p *linePrompter) Reprompt(flag sflags.FlagInfo, answer string, err error) (string, error) {
+	fmt.Fprintf(p.out, "! %s\n", err)
+
+	return p.Prompt(flag)
+}
+
+// GenerateTo takes a list of sflag.Flag, that are parsed from some
+// config structure, and prompts for each of their values through dst.
+// Hidden and deprecated flags are not prompted for.
+func GenerateTo(src []*sflags.Flag, dst Prompter) error {
+	for _, srcFlag := range src {
+		info := srcFlag.Info()
+		if info.Hidden || info.Deprecated {
+			continue
+		}
+
+		answer, err := dst.Prompt(info)
+		if err != nil {
+			return err
+		}
+
+		if answer == "" {
+			continue
+		}
+
+		if err := setAnswer(srcFlag, info, answer, dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setAnswer sets the answer onto the flag, prompting again through
+// dst as long as the answer is invalid, if it is a RetryPrompter.
+func setAnswer(flag *sflags.Flag, info sflags.FlagInfo, answer string, dst Prompter) error {
+	retry, canRetry := dst.(RetryPrompter)
+
+	for answer != "" {
+		err := setValue(flag, answer)
+		if err == nil || !canRetry {
+			return err
+		}
+
+		next, promptErr := retry.Reprompt(info, answer, err)
+
+		// Without more input, the answer stays invalid.
+		if errors.Is(promptErr, io.EOF) {
+			return err
+		}
+
+		if promptErr != nil {
+			return promptErr
+		}
+
+		answer = next
+	}
+
+	return nil
+}
+
+// ParseTo parses cfg, that is a pointer to some structure,
+// and prompts for each of its flag values through dst.
+func ParseTo(cfg interface{}, dst Prompter, optFuncs ...sflags.OptFunc) error {
+	flags, err := sflags.ParseStruct(cfg, optFuncs...)
+	if err != nil {
+		return err
+	}
+
+	return GenerateTo(flags, dst)
+}
+
+// Parse parses cfg, that is a pointer to some structure,
+// and prompts for each of its values on the terminal.
+func Parse(cfg interface{}, optFuncs ...sflags.OptFunc) error {
+	return ParseTo(cfg, NewPrompter(os.Stdin, os.Stdout), optFuncs...)
+}
+
+// setValue sets the answer onto the flag, thus checking
+// its allowed choices and running its validators.
+func setValue(flag *sflags.Flag, answer string) error {
+	if err := flag.Value.Set(answer); err != nil {
+		// Sensitive answers are never shown.
+		if flag.Secret {
+			answer = "********"
+		}
+
+		return fmt.Errorf("invalid value %q for %s: %w", answer, flag.Name, err)
+	}
+
+	return nil
+}
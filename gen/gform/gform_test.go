@@ -0,0 +1,192 @@
+package gform
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/octago/sflags"
+)
+
+type formCfg struct {
+	Name     string `long:"name" description:"your name"`
+	Format   string `long:"format" choice:"json" choice:"yaml"`
+	Port     int    `long:"port"`
+	Password string `long:"password" secret:"true"`
+	Internal string `flag:"internal,hidden"`
+}
+
+func TestParseTo(t *testing.T) {
+	tests := []struct {
+		name string
+
+		cfg    *formCfg
+		input  string
+		expCfg *formCfg
+		expErr string
+	}{
+		{
+			name:  "Fill all values",
+			cfg:   &formCfg{},
+			input: "bob\nyaml\n8080\nsecret\n",
+			expCfg: &formCfg{
+				Name:     "bob",
+				Format:   "yaml",
+				Port:     8080,
+				Password: "secret",
+			},
+		},
+		{
+			name:   "Empty answers keep defaults",
+			cfg:    &formCfg{Name: "alice", Port: 22},
+			input:  "\n\n\n\n",
+			expCfg: &formCfg{Name: "alice", Port: 22},
+		},
+		{
+			name:   "Invalid choice",
+			cfg:    &formCfg{},
+			input:  "bob\nxml\n",
+			expErr: `invalid value "xml" for format: invalid choice "xml": must be one of json, yaml`,
+		},
+		{
+			name:   "Invalid value",
+			cfg:    &formCfg{},
+			input:  "bob\njson\nport\n",
+			expErr: `invalid value "port" for port`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			prompter := NewPrompter(strings.NewReader(test.input), out)
+
+			err := ParseTo(test.cfg, prompter)
+			if test.expErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expCfg, test.cfg)
+		})
+	}
+}
+
+func TestPromptSecret(t *testing.T) {
+	cfg := &formCfg{Name: "alice", Password: "hunter2"}
+	out := &bytes.Buffer{}
+	prompter := NewPrompter(strings.NewReader("\n\n\n\n"), out)
+
+	require.NoError(t, ParseTo(cfg, prompter))
+	assert.Contains(t, out.String(), "# your name")
+	assert.Contains(t, out.String(), "name [alice]: ")
+	assert.Contains(t, out.String(), "format (json|yaml): ")
+	assert.NotContains(t, out.String(), "hunter2")
+	assert.NotContains(t, out.String(), "internal")
+}
+
+func TestPromptSecretFile(t *testing.T) {
+	// Secrets are read without echo from files, unless they are not terminals.
+	path := filepath.Join(t.TempDir(), "answers")
+	require.NoError(t, os.WriteFile(path, []byte("hunter2\nbob\n"), 0o600))
+
+	in, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer in.Close()
+
+	cfg := &struct {
+		Password string `long:"password" secret:"true"`
+		Name     string `long:"name"`
+	}{}
+	out := &bytes.Buffer{}
+
+	require.NoError(t, ParseTo(cfg, NewPrompter(in, out)))
+	assert.Equal(t, "hunter2", cfg.Password)
+	assert.Equal(t, "bob", cfg.Name)
+	assert.Equal(t, "password: name: ", out.String())
+}
+
+func TestRepromptInvalid(t *testing.T) {
+	cfg := &formCfg{}
+	out := &bytes.Buffer{}
+	prompter := NewPrompter(strings.NewReader("bob\nxml\nyaml\nport\n\nhunter2\n"), out)
+
+	require.NoError(t, ParseTo(cfg, prompter))
+	assert.Equal(t, &formCfg{Name: "bob", Format: "yaml", Password: "hunter2"}, cfg)
+	assert.Contains(t, out.String(), "! invalid value \"xml\" for format: invalid choice \"xml\": must be one of json, yaml\nformat (json|yaml): ")
+	assert.Contains(t, out.String(), "! invalid value \"port\" for port")
+}
+
+// keysReader reads keys one at a time, like terminals do.
+type keysReader struct {
+	keys []string
+}
+
+func (r *keysReader) Read(data []byte) (int, error) {
+	if len(r.keys) == 0 {
+		return 0, io.EOF
+	}
+
+	read := copy(data, r.keys[0])
+	r.keys = r.keys[1:]
+
+	return read, nil
+}
+
+func TestTUIPrompter(t *testing.T) {
+	cfg := &formCfg{}
+	out := &bytes.Buffer{}
+	keys := &keysReader{keys: []string{"bob\r", "\x1b[B", "\r", "8080\r", "hunter2\r"}}
+
+	prompter := NewTUIPrompter(keys, out)
+	require.NoError(t, ParseTo(cfg, prompter))
+	require.NoError(t, prompter.Close())
+
+	assert.Equal(t, &formCfg{Name: "bob", Format: "yaml", Port: 8080, Password: "hunter2"}, cfg)
+	assert.Contains(t, out.String(), "name: bob\r\nformat: yaml\r\nport: 8080\r\npassword: *******\r\n")
+	assert.NotContains(t, out.String(), "hunter2")
+	assert.NotContains(t, out.String(), "internal")
+}
+
+func TestTUIPrompterView(t *testing.T) {
+	form := &formModel{}
+
+	form.ask(question{flag: sflags.FlagInfo{Name: "format", Usage: "output format", Default: "yaml", Choices: []string{"json", "yaml"}}})
+	assert.Equal(t, "# output format\nformat [yaml]: \n  json\n> yaml", form.View())
+
+	form.ask(question{flag: sflags.FlagInfo{Name: "token", Default: "********", Secret: true}, answer: "abc", err: errors.New("too short")})
+	assert.Equal(t, "! too short\ntoken: ***", form.View())
+}
+
+func TestTUIPrompterReprompt(t *testing.T) {
+	cfg := &formCfg{}
+	out := &bytes.Buffer{}
+	keys := &keysReader{keys: []string{"bob\r", "\r", "port\r", "\x15", "22\r", "\r"}}
+
+	prompter := NewTUIPrompter(keys, out)
+	require.NoError(t, ParseTo(cfg, prompter))
+	require.NoError(t, prompter.Close())
+
+	assert.Equal(t, &formCfg{Name: "bob", Format: "json", Port: 22}, cfg)
+	assert.Contains(t, out.String(), "port: 22\r\n")
+}
+
+func TestTUIPrompterEnd(t *testing.T) {
+	cfg := &formCfg{}
+	prompter := NewTUIPrompter(&keysReader{keys: []string{"bob\r"}}, io.Discard)
+
+	assert.ErrorIs(t, ParseTo(cfg, prompter), io.EOF)
+	assert.Equal(t, "bob", cfg.Name)
+	assert.NoError(t, prompter.Close())
+}
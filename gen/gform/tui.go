@@ -0,0 +1,425 @@
+package gform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+
+	"github.com/octago/sflags"
+)
+
+// TUIPrompter is a Prompter backend rendering the form in the terminal, with
+// bubbletea: answers are edited in place (secret ones masked), and the value
+// of flags with choices is selected with the arrow keys (or tab). Keys typed
+// ahead of a prompt are kept for it, and Ctrl+C ends the form like the end of
+// the input does: the prompts then return io.EOF.
+//
+// A single program renders all the prompts of the form, until it is closed:
+//
+//	prompter := gform.NewTUIPrompter(os.Stdin, os.Stdout)
+//	defer prompter.Close()
+//
+//	err := gform.ParseTo(&cfg, prompter)
+type TUIPrompter struct {
+	questions chan question
+	quit      chan struct{}
+	done      chan struct{}
+	closing   sync.Once
+	err       error
+}
+
+var (
+	_ Prompter      = (*TUIPrompter)(nil)
+	_ RetryPrompter = (*TUIPrompter)(nil)
+)
+
+// NewTUIPrompter returns a Prompter reading keys from in and rendering the form
+// to out. When in is a terminal, it is put in raw mode until the prompter is
+// closed: its keys are then neither echoed nor buffered by lines.
+func NewTUIPrompter(in io.Reader, out io.Writer) *TUIPrompter {
+	prompter := &TUIPrompter{
+		questions: make(chan question),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	model := &formModel{questions: prompter.questions, quit: prompter.quit}
+
+	// The end of inputs other than terminals also ends the form, which
+	// bubbletea would otherwise wait for keys forever.
+	if file, isFile := in.(*os.File); !isFile || !term.IsTerminal(int(file.Fd())) {
+		input := &endReader{Reader: in, end: make(chan struct{})}
+		model.end, in = input.end, input
+	}
+
+	program := tea.NewProgram(model, tea.WithInput(in), tea.WithOutput(out))
+
+	go func() {
+		defer close(prompter.done)
+
+		_, prompter.err = program.StartReturningModel()
+	}()
+
+	return prompter
+}
+
+// Prompt prints the flag description, name and default value,
+// followed by its choices if any, and reads its answer.
+func (p *TUIPrompter) Prompt(flag sflags.FlagInfo) (string, error) {
+	return p.ask(question{flag: flag})
+}
+
+// Reprompt prints why the previous answer is invalid, and prompts
+// again, with the invalid answer pre-filled unless it is a secret.
+func (p *TUIPrompter) Reprompt(flag sflags.FlagInfo, answer string, err error) (string, error) {
+	if flag.Secret {
+		answer = ""
+	}
+
+	return p.ask(question{flag: flag, answer: answer, err: err})
+}
+
+// Close ends the form and restores the terminal, once the
+// pending prompt (if any) is answered. It is safe to call twice.
+func (p *TUIPrompter) Close() error {
+	p.closing.Do(func() { close(p.quit) })
+	<-p.done
+
+	return p.err
+}
+
+// ask sends a question to the form, and waits for its answer.
+func (p *TUIPrompter) ask(q question) (string, error) {
+	answers := make(chan answer, 1)
+	q.answers = answers
+
+	select {
+	case p.questions <- q:
+	case <-p.done:
+		return "", p.ended()
+	}
+
+	select {
+	case res := <-answers:
+		return res.text, res.err
+	case <-p.done:
+		return "", p.ended()
+	}
+}
+
+// ended returns why the form cannot answer anymore.
+func (p *TUIPrompter) ended() error {
+	if p.err != nil {
+		return p.err
+	}
+
+	return io.EOF
+}
+
+// question is a flag to prompt for, possibly again after an invalid answer.
+type question struct {
+	flag    sflags.FlagInfo
+	answer  string // pre-filled
+	err     error  // of the previous answer
+	answers chan<- answer
+}
+
+// answer is the text entered for a question, or why none was.
+type answer struct {
+	text string
+	err  error
+}
+
+type (
+	closedMsg struct{} // the prompter was closed
+	endMsg    struct{} // the input has ended
+)
+
+// formModel is the bubbletea model of a form, prompting for one question at a time.
+type formModel struct {
+	questions <-chan question
+	quit      <-chan struct{}
+	end       <-chan struct{}
+
+	current *question
+	input   []rune
+	choice  int
+	edited  bool
+	ended   bool
+
+	typed    []tea.KeyMsg // the keys typed ahead of the current question
+	answered []string     // the lines of the questions answered
+}
+
+func (m *formModel) Init() tea.Cmd {
+	if m.end == nil {
+		return m.next
+	}
+
+	return tea.Batch(m.next, m.waitEnd)
+}
+
+func (m *formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case question:
+		m.ask(msg)
+
+		for len(m.typed) > 0 && m.current != nil {
+			key := m.typed[0]
+			m.typed = m.typed[1:]
+
+			if quit := m.key(key); quit {
+				return m, tea.Quit
+			}
+		}
+
+		if m.ended {
+			m.endInput()
+		}
+	case tea.KeyMsg:
+		if m.current == nil {
+			m.typed = append(m.typed, msg)
+
+			return m, nil
+		}
+
+		if quit := m.key(msg); quit {
+			return m, tea.Quit
+		}
+	case endMsg:
+		m.ended = true
+		m.endInput()
+	case closedMsg:
+		return m, tea.Quit
+	default:
+		return m, nil
+	}
+
+	// Once answered, the next question is waited for.
+	if m.current == nil {
+		return m, m.next
+	}
+
+	return m, nil
+}
+
+// ask makes a question the current one, with its pre-filled answer.
+func (m *formModel) ask(q question) {
+	m.current = &q
+	m.input = []rune(q.answer)
+	m.choice = 0
+	m.edited = false
+
+	selected := q.answer
+	if selected == "" {
+		selected = q.flag.Default
+	}
+
+	for i, choice := range q.flag.Choices {
+		if choice == selected {
+			m.choice = i
+		}
+	}
+}
+
+// key edits the answer of the current question, or answers it, and
+// returns true when the form must end, like on Ctrl+C.
+func (m *formModel) key(key tea.KeyMsg) bool {
+	choices := len(m.current.flag.Choices)
+
+	switch key.Type {
+	case tea.KeyCtrlC:
+		return true
+	case tea.KeyCtrlD:
+		if len(m.input) == 0 {
+			m.reply(answer{err: io.EOF})
+		}
+	case tea.KeyEnter, tea.KeyCtrlJ:
+		m.submit()
+	case tea.KeyBackspace:
+		if len(m.input) > 0 {
+			m.input, m.edited = m.input[:len(m.input)-1], true
+		}
+	case tea.KeyCtrlU:
+		m.input, m.edited = nil, true
+	case tea.KeyUp, tea.KeyShiftTab:
+		if choices > 0 {
+			m.choice = (m.choice + choices - 1) % choices
+		}
+	case tea.KeyDown, tea.KeyTab:
+		if choices > 0 {
+			m.choice = (m.choice + 1) % choices
+		}
+	case tea.KeySpace:
+		m.typeRunes([]rune{' '})
+	case tea.KeyRunes:
+		m.typeRunes(key.Runes)
+	}
+
+	return false
+}
+
+// typeRunes types runes in the answer of the current question: keys pasted
+// or typed ahead come at once, so lines are split, and those remaining after
+// the first are kept for the next questions.
+func (m *formModel) typeRunes(runes []rune) {
+	for i, r := range runes {
+		if r != '\r' && r != '\n' {
+			if len(m.current.flag.Choices) == 0 {
+				m.input, m.edited = append(m.input, r), true
+			}
+
+			continue
+		}
+
+		m.submit()
+
+		if rest := runes[i+1:]; len(rest) > 0 {
+			m.typed = append([]tea.KeyMsg{{Type: tea.KeyRunes, Runes: rest}}, m.typed...)
+		}
+
+		return
+	}
+}
+
+// submit answers the current question with the choice selected, or the text
+// entered: the default value of the flag is kept when chosen, or for no text.
+func (m *formModel) submit() {
+	text := string(m.input)
+
+	if choices := m.current.flag.Choices; len(choices) > 0 {
+		text = choices[m.choice]
+		if text == m.current.flag.Default {
+			text = ""
+		}
+	}
+
+	m.reply(answer{text: text})
+}
+
+// endInput answers the current question, if any, once the input has
+// ended: with the text typed for it, or with io.EOF if none was.
+func (m *formModel) endInput() {
+	if m.current == nil {
+		return
+	}
+
+	if m.edited && len(m.input) > 0 {
+		m.submit()
+	} else {
+		m.reply(answer{err: io.EOF})
+	}
+}
+
+// reply sends the answer of the current question, and keeps it in the form.
+func (m *formModel) reply(res answer) {
+	flag := m.current.flag
+
+	shown := res.text
+	switch {
+	case errors.Is(res.err, io.EOF):
+		shown = ""
+	case shown == "":
+		shown = flag.Default
+	case flag.Secret:
+		shown = strings.Repeat("*", len([]rune(shown)))
+	}
+
+	m.answered = append(m.answered, fmt.Sprintf("%s: %s", flag.Name, shown))
+	m.current.answers <- res
+	m.current = nil
+}
+
+func (m *formModel) View() string {
+	view := &strings.Builder{}
+
+	for _, line := range m.answered {
+		fmt.Fprintln(view, line)
+	}
+
+	if m.current == nil {
+		return view.String()
+	}
+
+	flag := m.current.flag
+
+	if m.current.err != nil {
+		fmt.Fprintf(view, "! %s\n", m.current.err)
+	}
+
+	if flag.Usage != "" {
+		fmt.Fprintf(view, "# %s\n", flag.Usage)
+	}
+
+	fmt.Fprint(view, flag.Name)
+
+	// Never show the current value of sensitive flags.
+	if flag.Default != "" && !flag.Secret {
+		fmt.Fprintf(view, " [%s]", flag.Default)
+	}
+
+	fmt.Fprint(view, ": ")
+
+	if len(flag.Choices) == 0 {
+		if flag.Secret {
+			fmt.Fprint(view, strings.Repeat("*", len(m.input)))
+		} else {
+			fmt.Fprint(view, string(m.input))
+		}
+
+		return view.String()
+	}
+
+	for i, choice := range flag.Choices {
+		cursor := " "
+		if i == m.choice {
+			cursor = ">"
+		}
+
+		fmt.Fprintf(view, "\n%s %s", cursor, choice)
+	}
+
+	return view.String()
+}
+
+// next waits for the next question of the form, until the prompter is closed.
+func (m *formModel) next() tea.Msg {
+	select {
+	case q := <-m.questions:
+		return q
+	case <-m.quit:
+		return closedMsg{}
+	}
+}
+
+// waitEnd waits for the end of the input, until the prompter is closed.
+func (m *formModel) waitEnd() tea.Msg {
+	select {
+	case <-m.end:
+		return endMsg{}
+	case <-m.quit:
+		return nil
+	}
+}
+
+// endReader notifies the end of its input.
+type endReader struct {
+	io.Reader
+	end  chan struct{}
+	once sync.Once
+}
+
+func (r *endReader) Read(data []byte) (int, error) {
+	read, err := r.Reader.Read(data)
+	if err != nil {
+		r.once.Do(func() { close(r.end) })
+	}
+
+	return read, err
+}
@@ -0,0 +1,227 @@
+// Package ghttp exposes a cobra command tree (as generated by gcobra)
+// over HTTP: GET requests return a JSON schema of the commands and their
+// flags, while POST requests execute an invocation passed as a JSON body,
+// going through the very same parsing and validation pipeline as the CLI.
+// Each request is served by a new command tree, bound to new structs, so
+// that the values given by a caller never leak into the request of another:
+//
+//	http.Handle("/api", ghttp.Handler(func() *cobra.Command {
+//		return gcobra.Parse(&App{})
+//	}))
+package ghttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/octago/sflags"
+)
+
+// Command is the JSON schema of a command, its flags and subcommands.
+type Command struct {
+	Name     string     `json:"name"`
+	Use      string     `json:"use"`
+	Short    string     `json:"short,omitempty"`
+	Long     string     `json:"long,omitempty"`
+	Aliases  []string   `json:"aliases,omitempty"`
+	Hidden   bool       `json:"hidden,omitempty"`
+	Flags    []Flag     `json:"flags,omitempty"`
+	Commands []*Command `json:"commands,omitempty"`
+}
+
+// Flag is the JSON schema of a single command flag, as described by sflags.
+type Flag = sflags.FlagInfo
+
+// Invocation is the JSON body of a POST request, describing
+// the command to run, with its flags and positional arguments.
+type Invocation struct {
+	// Command is the path of the command to run (eg. ["remote", "add"]),
+	// relative to the root command. Empty means the root command itself.
+	Command []string `json:"command"`
+
+	// Flags maps flag long names to their string values.
+	Flags map[string]string `json:"flags"`
+
+	// Args are the positional arguments passed to the command. Like on
+	// the command line, those starting with a dash are parsed as flags.
+	Args []string `json:"args"`
+
+	// Passthrough are the arguments passed after a "--" terminator, which
+	// are never parsed as flags (eg. captured by passthrough-args fields).
+	Passthrough []string `json:"passthrough,omitempty"`
+}
+
+// Result is the JSON response to an Invocation.
+type Result struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+	Error   string `json:"error,omitempty"`
+}
+
+// maxBodySize is the maximum size of the JSON body of a POST request.
+const maxBodySize = 1 << 20
+
+// handler serves command trees over HTTP.
+type handler struct {
+	newRoot func() *cobra.Command
+}
+
+// Handler returns an http.Handler serving the command trees built by newRoot:
+// a new tree -bound to new structs- is built for each request, so that flag
+// values and their changed state never carry over from one request to the next,
+// and invocations can be executed concurrently.
+func Handler(newRoot func() *cobra.Command) http.Handler {
+	return &handler{newRoot: newRoot}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, Schema(h.newRoot()))
+	case http.MethodPost:
+		var inv Invocation
+
+		body := http.MaxBytesReader(w, req.Body, maxBodySize)
+		if err := json.NewDecoder(body).Decode(&inv); err != nil {
+			writeJSON(w, http.StatusBadRequest, Result{Error: err.Error()})
+
+			return
+		}
+
+		res := h.execute(inv)
+		if res.Error != "" {
+			writeJSON(w, http.StatusUnprocessableEntity, res)
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, res)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// Schema returns the JSON schema of a command and all of its subcommands.
+func Schema(cmd *cobra.Command) *Command {
+	schema := &Command{
+		Name:    cmd.Name(),
+		Use:     cmd.Use,
+		Short:   cmd.Short,
+		Long:    cmd.Long,
+		Aliases: cmd.Aliases,
+		Hidden:  cmd.Hidden,
+	}
+
+	persistent := cmd.PersistentFlags()
+
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		schema.Flags = append(schema.Flags, flagInfo(flag, persistent.Lookup(flag.Name) != nil))
+	})
+
+	for _, sub := range cmd.Commands() {
+		schema.Commands = append(schema.Commands, Schema(sub))
+	}
+
+	return schema
+}
+
+// execute builds a command line from an invocation and runs it on a new command tree.
+func (h *handler) execute(inv Invocation) Result {
+	out := &bytes.Buffer{}
+
+	root := h.newRoot()
+	root.SetArgs(inv.words())
+	root.SetOut(out)
+	root.SetErr(out)
+
+	cmd, err := root.ExecuteC()
+
+	res := Result{Output: out.String()}
+	if cmd != nil {
+		res.Command = cmd.CommandPath()
+	}
+
+	if err != nil {
+		res.Error = err.Error()
+	}
+
+	return res
+}
+
+// words returns the invocation as a list of command-line words.
+func (inv Invocation) words() []string {
+	words := append([]string{}, inv.Command...)
+
+	names := make([]string, 0, len(inv.Flags))
+	for name := range inv.Flags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		words = append(words, fmt.Sprintf("--%s=%s", name, inv.Flags[name]))
+	}
+
+	words = append(words, inv.Args...)
+
+	if len(inv.Passthrough) > 0 {
+		words = append(words, "--")
+		words = append(words, inv.Passthrough...)
+	}
+
+	return words
+}
+
+// flagInfo returns the description of the option a flag was generated from,
+// or builds one from the flag itself, for flags added without sflags (or the
+// negated and alias flags of options). Flags might be hidden, required or made
+// persistent with cobra after being generated, so those are given by the flag.
+func flagInfo(flag *pflag.Flag, persistent bool) Flag {
+	info := Flag{
+		Name:    flag.Name,
+		Short:   flag.Shorthand,
+		Usage:   flag.Usage,
+		Type:    flag.Value.Type(),
+		Default: flag.DefValue,
+	}
+
+	if parsed, _, found := sflags.Field(flag.Value); found && parsed.Name == flag.Name {
+		info = parsed.Info()
+	}
+
+	info.Hidden = flag.Hidden
+	info.Required = isRequired(flag)
+	info.Persistent = persistent
+
+	return info
+}
+
+func isRequired(flag *pflag.Flag) bool {
+	for _, annot := range flag.Annotations["sflags"] {
+		if annot == "required" {
+			return true
+		}
+	}
+
+	if required := flag.Annotations[cobra.BashCompOneRequiredFlag]; len(required) > 0 {
+		return required[0] == "true"
+	}
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,193 @@
+package ghttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/octago/sflags/gen/gcobra"
+)
+
+type rootCmd struct {
+	Verbose bool `short:"v" long:"verbose" description:"verbose output"`
+
+	Deploy deployCmd `command:"deploy" description:"deploy a target"`
+}
+
+func (*rootCmd) Execute(args []string) error { return nil }
+
+type deployCmd struct {
+	Target string `long:"target" required:"true" choices:"prod staging" env:"DEPLOY_TARGET"`
+
+	Args struct {
+		Hosts []string
+	} `positional-args:"yes"`
+
+	ran bool
+}
+
+func (d *deployCmd) Execute(args []string) error {
+	d.ran = true
+
+	return nil
+}
+
+// post sends an invocation to the server, and decodes its result.
+func post(t *testing.T, url, body string) (int, Result) {
+	t.Helper()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var res Result
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+	return resp.StatusCode, res
+}
+
+func TestSchema(t *testing.T) {
+	data := &rootCmd{}
+	srv := httptest.NewServer(Handler(func() *cobra.Command { return gcobra.Parse(data) }))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var schema Command
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&schema))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, schema.Flags, 1)
+	assert.Equal(t, "verbose", schema.Flags[0].Name)
+	assert.Equal(t, "v", schema.Flags[0].Short)
+	assert.Equal(t, "bool", schema.Flags[0].Type)
+
+	require.Len(t, schema.Commands, 1)
+	assert.Equal(t, "deploy", schema.Commands[0].Name)
+	assert.Equal(t, "deploy a target", schema.Commands[0].Short)
+	require.Len(t, schema.Commands[0].Flags, 1)
+	assert.True(t, schema.Commands[0].Flags[0].Required)
+	assert.Equal(t, []string{"prod", "staging"}, schema.Commands[0].Flags[0].Choices)
+	assert.Equal(t, "DEPLOY_TARGET", schema.Commands[0].Flags[0].EnvName)
+}
+
+func TestExecute(t *testing.T) {
+	data := &rootCmd{}
+	srv := httptest.NewServer(Handler(func() *cobra.Command { return gcobra.Parse(data) }))
+	defer srv.Close()
+
+	body := `{"command": ["deploy"], "flags": {"target": "prod"}, "args": ["a", "b"]}`
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var res Result
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, res.Error)
+	assert.True(t, data.Deploy.ran)
+	assert.Equal(t, "prod", data.Deploy.Target)
+	assert.Equal(t, []string{"a", "b"}, data.Deploy.Args.Hosts)
+}
+
+func TestExecuteError(t *testing.T) {
+	srv := httptest.NewServer(Handler(func() *cobra.Command { return gcobra.Parse(&rootCmd{}) }))
+	defer srv.Close()
+
+	body := `{"command": ["deploy"], "flags": {"unknown": "value"}}`
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var res Result
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Contains(t, res.Error, "unknown flag: --unknown")
+}
+
+func TestExecuteFreshTrees(t *testing.T) {
+	var (
+		trees []*rootCmd
+		mu    sync.Mutex
+	)
+
+	srv := httptest.NewServer(Handler(func() *cobra.Command {
+		data := &rootCmd{}
+
+		mu.Lock()
+		trees = append(trees, data)
+		mu.Unlock()
+
+		return gcobra.Parse(data)
+	}))
+	defer srv.Close()
+
+	status, res := post(t, srv.URL, `{"command": ["deploy"], "flags": {"target": "prod"}, "args": ["a"]}`)
+	assert.Equal(t, http.StatusOK, status, res.Error)
+
+	status, res = post(t, srv.URL, `{"command": ["deploy"], "args": ["b"]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Contains(t, res.Error, "target")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, trees, 2)
+	assert.Equal(t, "prod", trees[0].Deploy.Target)
+	assert.Empty(t, trees[1].Deploy.Target)
+	assert.Equal(t, []string{"b"}, trees[1].Deploy.Args.Hosts)
+}
+
+type wrapRoot struct {
+	Run wrapCmd `command:"run"`
+}
+
+type wrapCmd struct {
+	Args struct {
+		Target string
+	} `positional-args:"yes"`
+
+	Command []string `passthrough-args:"true"`
+}
+
+func (*wrapCmd) Execute(args []string) error { return nil }
+
+func TestExecutePassthrough(t *testing.T) {
+	data := &wrapRoot{}
+	srv := httptest.NewServer(Handler(func() *cobra.Command { return gcobra.Parse(data) }))
+	defer srv.Close()
+
+	status, res := post(t, srv.URL, `{"command": ["run"], "args": ["local"], "passthrough": ["ls", "-la"]}`)
+	assert.Equal(t, http.StatusOK, status, res.Error)
+	assert.Equal(t, "local", data.Run.Args.Target)
+	assert.Equal(t, []string{"ls", "-la"}, data.Run.Command)
+
+	data = &wrapRoot{}
+
+	status, res = post(t, srv.URL, `{"command": ["run"], "args": ["remote"]}`)
+	assert.Equal(t, http.StatusOK, status, res.Error)
+	assert.Equal(t, "remote", data.Run.Args.Target)
+	assert.Empty(t, data.Run.Command)
+}
+
+func TestExecuteBodyTooLarge(t *testing.T) {
+	srv := httptest.NewServer(Handler(func() *cobra.Command { return gcobra.Parse(&rootCmd{}) }))
+	defer srv.Close()
+
+	body := `{"command": ["deploy"], "args": ["` + strings.Repeat("a", maxBodySize) + `"]}`
+
+	status, res := post(t, srv.URL, body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, res.Error, "too large")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/alecthomas/kingpin"
 	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/platform"
 )
 
 type flagger interface {
@@ -13,22 +14,20 @@ type flagger interface {
 
 // GenerateTo takes a list of sflag.Flag,
 // that are parsed from some config structure, and put it to dst.
+// Flags are built from their description (see sflags.Flag.Info).
 func GenerateTo(src []*sflags.Flag, dst flagger) {
 	for _, srcFlag := range src {
-		name := srcFlag.Name
-		if srcFlag.Short != "" {
-			name += ", " + srcFlag.Short
-		}
-		flag := dst.Flag(srcFlag.Name, srcFlag.Usage)
+		info := srcFlag.Info()
+		flag := dst.Flag(info.Name, info.Usage)
 		flag.SetValue(srcFlag.Value)
-		if srcFlag.EnvName != "" {
-			flag.Envar(srcFlag.EnvName)
+		if info.EnvName != "" {
+			flag.Envar(info.EnvName)
 		}
-		if srcFlag.Hidden {
+		if info.Hidden {
 			flag.Hidden()
 		}
-		if srcFlag.Short != "" {
-			r, _ := utf8.DecodeRuneInString(srcFlag.Short)
+		if info.Short != "" {
+			r, _ := utf8.DecodeRuneInString(info.Short)
 			if r != utf8.RuneError {
 				flag.Short(r)
 			}
@@ -47,3 +46,15 @@ func ParseTo(cfg interface{}, dst flagger, optFuncs ...sflags.OptFunc) error {
 	GenerateTo(flags, dst)
 	return nil
 }
+
+// The generator is registered as "kingpin", for sflags.Generate:
+// it returns a new *kingpin.Application, named after the binary.
+func init() {
+	sflags.RegisterGenerator("kingpin", func(data interface{}, optFuncs ...sflags.OptFunc) (interface{}, error) {
+		app := kingpin.New(platform.ProgramName(), "")
+		if err := ParseTo(data, app, optFuncs...); err != nil {
+			return nil, err
+		}
+		return app, nil
+	})
+}
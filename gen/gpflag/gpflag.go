@@ -1,9 +1,10 @@
 package gpflag
 
 import (
-	"os"
+	"strings"
 
 	"github.com/octago/sflags"
+	"github.com/octago/sflags/internal/platform"
 	"github.com/spf13/pflag"
 )
 
@@ -17,50 +18,105 @@ var _ flagSet = (*pflag.FlagSet)(nil)
 
 // GenerateTo takes a list of sflag.Flag,
 // that are parsed from some config structure, and put it to dst.
-func GenerateTo(src []*sflags.Flag, dst flagSet) {
-	for _, srcFlag := range src {
-		flag := dst.VarPF(srcFlag.Value, srcFlag.Name, srcFlag.Short, srcFlag.Usage)
+// If filters are given, only the flags matching all of them are put.
+// Flags are built from their description (see sflags.Flag.Info).
+func GenerateTo(src []*sflags.Flag, dst flagSet, filters ...sflags.Filter) {
+	for _, srcFlag := range sflags.FilterFlags(src, filters...) {
+		info := srcFlag.Info()
+		flag := dst.VarPF(srcFlag.Value, info.Name, info.Short, info.Usage)
 
 		// Annotations used for things like completions
 		flag.Annotations = map[string][]string{}
 		var annots []string
 
-		if boolFlag, casted := srcFlag.Value.(sflags.BoolFlag); casted && boolFlag.IsBoolFlag() {
+		if info.Bool {
 			// pflag uses -1 in this case,
 			// we will use the same behaviour as in flag library
 			flag.NoOptDefVal = "true"
-		} else if srcFlag.Required {
-			// Only non-boolean flags can be required.
-			annots = append(annots, "required")
+		} else {
+			// Only non-boolean flags can be required,
+			// or have a value when given without one.
+			if info.Required {
+				annots = append(annots, "required")
+			}
+			if len(info.OptionalValue) > 0 {
+				flag.NoOptDefVal = strings.Join(info.OptionalValue, ",")
+			}
+		}
+		if info.Secret {
+			annots = append(annots, "secret")
+		}
+		if info.Persistent {
+			annots = append(annots, "persistent")
+		}
+		if srcFlag.Display != "" {
+			flag.DefValue = info.Default
 		}
-		flag.Hidden = srcFlag.Hidden
-		if srcFlag.Deprecated {
-			// we use Usage as Deprecated message for a pflag
-			flag.Deprecated = srcFlag.Usage
+		flag.Hidden = info.Hidden
+		if info.Deprecated {
+			// we use Usage as Deprecated message for a pflag,
+			// unless a deprecation message has been given.
+			flag.Deprecated = info.DeprecatedMsg
+			if flag.Deprecated == "" {
+				flag.Deprecated = srcFlag.Usage
+			}
 			if flag.Deprecated == "" {
 				flag.Deprecated = "Deprecated"
 			}
 		}
 		// Register annotations to be used by clients and completers
 		flag.Annotations["sflags"] = annots
+		if info.Exclusive != "" {
+			flag.Annotations[exclusiveAnnotation] = []string{info.Exclusive}
+		}
+		if info.RequiredWith != "" {
+			flag.Annotations[togetherAnnotation] = []string{info.RequiredWith}
+		}
 	}
 }
 
 // ParseTo parses cfg, that is a pointer to some structure,
-// and puts it to dst.
+// and puts it to dst. If dst is a *pflag.FlagSet (or a FlagSet), its output,
+// usage and sorting are set from the options (see sflags.Output).
 func ParseTo(cfg interface{}, dst flagSet, optFuncs ...sflags.OptFunc) error {
 	flags, err := sflags.ParseStruct(cfg, optFuncs...)
 	if err != nil {
 		return err
 	}
 	GenerateTo(flags, dst)
+	switch fs := dst.(type) {
+	case *pflag.FlagSet:
+		present(fs, sflags.PresentationOf(optFuncs...))
+	case *FlagSet:
+		present(fs.FlagSet, sflags.PresentationOf(optFuncs...))
+	}
 	return nil
 }
 
+// present applies the presentation settings to a flag set.
+func present(fs *pflag.FlagSet, presentation sflags.Presentation) {
+	if presentation.Output != nil {
+		fs.SetOutput(presentation.Output)
+	}
+	if presentation.Usage != nil {
+		fs.Usage = presentation.Usage
+	}
+	if presentation.SortFlags != nil {
+		fs.SortFlags = *presentation.SortFlags
+	}
+}
+
+// The generator is registered as "pflag", for sflags.Generate.
+func init() {
+	sflags.RegisterGenerator("pflag", func(data interface{}, optFuncs ...sflags.OptFunc) (interface{}, error) {
+		return Parse(data, optFuncs...)
+	})
+}
+
 // Parse parses cfg, that is a pointer to some structure,
 // puts it to the new pflag.FlagSet and returns it.
 func Parse(cfg interface{}, optFuncs ...sflags.OptFunc) (*pflag.FlagSet, error) {
-	fs := pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
+	fs := pflag.NewFlagSet(platform.Program(), pflag.ExitOnError)
 	err := ParseTo(cfg, fs, optFuncs...)
 	if err != nil {
 		return nil, err
@@ -1,10 +1,12 @@
 package gpflag
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"net"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -298,3 +300,367 @@ func TestPFlagGetters(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []int{10, 20}, intSliceValue)
 }
+
+func TestParseCounterType(t *testing.T) {
+	cfg := &struct {
+		Verbose int `short:"v" long:"verbose" type:"counter"`
+		Level   int `long:"level"`
+	}{}
+
+	flagSet, err := Parse(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, flagSet.Parse([]string{"-vvv", "--level", "2"}))
+	assert.Equal(t, 3, cfg.Verbose)
+	assert.Equal(t, 2, cfg.Level)
+
+	countValue, err := flagSet.GetCount("verbose")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, countValue)
+
+	require.NoError(t, flagSet.Parse([]string{"--verbose", "-v"}))
+	assert.Equal(t, 5, cfg.Verbose)
+}
+
+func TestParseProfiles(t *testing.T) {
+	cfg := &struct {
+		Host string `long:"host"`
+		Port int    `long:"port"`
+	}{}
+
+	flagSet, err := Parse(cfg, sflags.Profiles(map[string]interface{}{
+		"prod": map[string]interface{}{"host": "example.com", "port": 443},
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, flagSet.Parse([]string{"--port", "8443", "--profile", "prod"}))
+	assert.Equal(t, "example.com", cfg.Host)
+	assert.Equal(t, 8443, cfg.Port)
+}
+
+func TestParseAliases(t *testing.T) {
+	cfg := &struct {
+		Color string `long:"color" short:"c" alias:"colour,couleur" alias:"colors"`
+	}{}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+
+	for _, alias := range []string{"colour", "couleur", "colors"} {
+		flag := flagSet.Lookup(alias)
+		require.NotNil(t, flag, alias)
+		assert.True(t, flag.Hidden, alias)
+		assert.Empty(t, flag.Shorthand, alias)
+	}
+
+	require.NoError(t, flagSet.Parse([]string{"--couleur", "rouge"}))
+	assert.Equal(t, "rouge", cfg.Color)
+
+	require.NoError(t, flagSet.Parse([]string{"-c", "red"}))
+	assert.Equal(t, "red", cfg.Color)
+}
+
+func TestParseDeprecated(t *testing.T) {
+	cfg := &struct {
+		Old   string `long:"old" description:"old flag" deprecated:"use --new instead"`
+		Older string `long:"older" description:"older flag" deprecated:"yes"`
+	}{}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+
+	assert.Equal(t, "use --new instead", flagSet.Lookup("old").Deprecated)
+	assert.Equal(t, "older flag", flagSet.Lookup("older").Deprecated)
+}
+
+func TestParseChanged(t *testing.T) {
+	cfg := &struct {
+		Host string `long:"host"`
+		Port int    `long:"port"`
+	}{Port: 80}
+
+	flags, err := sflags.ParseStruct(cfg)
+	require.NoError(t, err)
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	GenerateTo(flags, flagSet)
+
+	require.NoError(t, flagSet.Parse([]string{"--port", "80"}))
+	assert.Equal(t, []string{"port"}, sflags.Changed(flags))
+	assert.True(t, flagSet.Changed("port"))
+}
+
+func TestParseMapFlags(t *testing.T) {
+	cfg := &struct {
+		Labels map[string]string `long:"label"`
+		Limits map[string]int    `long:"limit" key-value-delimiter:":"`
+	}{}
+
+	flagSet, err := Parse(cfg)
+	require.NoError(t, err)
+
+	args := []string{"--label", "app=web", "--label=tier=front", "--limit", "cpu:2", "--limit", "mem:512"}
+	require.NoError(t, flagSet.Parse(args))
+	assert.Equal(t, map[string]string{"app": "web", "tier": "front"}, cfg.Labels)
+	assert.Equal(t, map[string]int{"cpu": 2, "mem": 512}, cfg.Limits)
+}
+
+func TestParseTriBool(t *testing.T) {
+	cfg := &struct {
+		Color sflags.TriBool `long:"color"`
+		Pager sflags.TriBool `long:"pager"`
+		Cache sflags.TriBool `long:"cache"`
+	}{}
+
+	flagSet, err := Parse(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "auto", flagSet.Lookup("color").DefValue)
+
+	require.NoError(t, flagSet.Parse([]string{"--color", "--pager=false"}))
+	assert.Equal(t, sflags.TriTrue, cfg.Color)
+	assert.Equal(t, sflags.TriFalse, cfg.Pager)
+	assert.Equal(t, sflags.TriAuto, cfg.Cache)
+}
+
+func TestParseDurationOrOff(t *testing.T) {
+	cfg := &struct {
+		Timeout sflags.DurationOrOff `long:"timeout"`
+		TTL     sflags.DurationOrOff `long:"ttl"`
+	}{TTL: sflags.DurationOrOff(time.Hour)}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+	require.NoError(t, ParseTo(cfg, flagSet))
+	assert.Equal(t, "1h0m0s", flagSet.Lookup("ttl").DefValue)
+
+	require.NoError(t, flagSet.Parse([]string{"--timeout", "30s", "--ttl", "off"}))
+	assert.Equal(t, 30*time.Second, cfg.Timeout.Duration())
+	assert.True(t, cfg.TTL.IsOff())
+	assert.Error(t, flagSet.Parse([]string{"--timeout", "never"}))
+}
+
+func TestParseSecret(t *testing.T) {
+	cfg := &struct {
+		Password string `long:"password" secret:"true"`
+	}{Password: "hunter2"}
+
+	prompt := func(flag string) (string, error) { return "letmein", nil }
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet, sflags.SecretPrompt(prompt)))
+
+	flag := flagSet.Lookup("password")
+	assert.Equal(t, "********", flag.DefValue)
+	assert.Equal(t, []string{"secret"}, flag.Annotations["sflags"])
+
+	require.NoError(t, flagSet.Parse([]string{"--password"}))
+	assert.Equal(t, "letmein", cfg.Password)
+
+	require.NoError(t, flagSet.Parse([]string{"--password=s3cr3t"}))
+	assert.Equal(t, "s3cr3t", cfg.Password)
+}
+
+func TestParseSliceSeparators(t *testing.T) {
+	cfg := &struct {
+		Tags  []string `long:"tag"`
+		Exprs []string `long:"expr" sep:"none"`
+		Hosts []string `long:"host" accumulate:"false"`
+	}{}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+
+	args := []string{
+		"--tag", "a,b", "--tag", "c",
+		"--expr", "a,b", "--expr", "c",
+		"--host", "a,b", "--host", "c",
+	}
+	require.NoError(t, flagSet.Parse(args))
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Tags)
+	assert.Equal(t, []string{"a,b", "c"}, cfg.Exprs)
+	assert.Equal(t, []string{"c"}, cfg.Hosts)
+}
+
+func TestParseExampleValue(t *testing.T) {
+	cfg := &struct {
+		Timeout time.Duration `long:"timeout" description:"request timeout" example-value:"10s"`
+		Name    string        `long:"name" example-value:"alice"`
+	}{}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+
+	assert.Equal(t, "request timeout (e.g. 10s)", flagSet.Lookup("timeout").Usage)
+	assert.Equal(t, "(e.g. alice)", flagSet.Lookup("name").Usage)
+	assert.Contains(t, flagSet.FlagUsages(), "--timeout duration   request timeout (e.g. 10s)")
+}
+
+func TestParseDisplay(t *testing.T) {
+	cfg := &struct {
+		Timeout time.Duration `long:"timeout" display:"duration-human"`
+		Config  string        `long:"config" display:"basename"`
+	}{Timeout: 90 * time.Minute, Config: "/etc/app/config.yaml"}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+
+	assert.Equal(t, "1h30m", flagSet.Lookup("timeout").DefValue)
+	assert.Equal(t, "config.yaml", flagSet.Lookup("config").DefValue)
+	assert.Contains(t, flagSet.FlagUsages(), `(default "config.yaml")`)
+}
+
+func TestParseZeroDefaults(t *testing.T) {
+	cfg := &struct {
+		Name    string `long:"name"`
+		Count   int    `long:"count"`
+		Verbose bool   `long:"verbose"`
+		Level   string `long:"level" choices:"info debug"`
+		Host    string `long:"host"`
+	}{Host: "localhost"}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	profiles := sflags.Profiles(map[string]interface{}{
+		"prod": map[string]string{"host": "example.com"},
+	})
+	require.NoError(t, ParseTo(cfg, flagSet, profiles, sflags.CollectErrors(&sflags.Errors{})))
+
+	usages := flagSet.FlagUsages()
+	assert.NotContains(t, usages, "(default 0)")
+	assert.NotContains(t, usages, `(default "")`)
+	assert.NotContains(t, usages, "(default false)")
+	assert.Contains(t, usages, `(default "localhost")`)
+	assert.Regexp(t, `--count int\s`, usages)
+	assert.Regexp(t, `--verbose\s`, usages)
+	assert.Equal(t, "true", flagSet.Lookup("verbose").NoOptDefVal)
+	assert.Empty(t, flagSet.Lookup("count").NoOptDefVal)
+}
+
+func TestGenerateToFilters(t *testing.T) {
+	cfg := &struct {
+		Verbose bool `long:"verbose"`
+		Server  struct {
+			Host string `long:"host"`
+		} `group:"server" flag:"server"`
+	}{}
+
+	flags, err := sflags.ParseStruct(cfg)
+	require.NoError(t, err)
+
+	global := pflag.NewFlagSet("global", pflag.ContinueOnError)
+	server := pflag.NewFlagSet("server", pflag.ContinueOnError)
+	GenerateTo(flags, global, sflags.InGroup())
+	GenerateTo(flags, server, sflags.InGroup("server"))
+
+	assert.NotNil(t, global.Lookup("verbose"))
+	assert.Nil(t, global.Lookup("server-host"))
+	assert.NotNil(t, server.Lookup("server-host"))
+	assert.Nil(t, server.Lookup("verbose"))
+}
+
+func TestParsePresentation(t *testing.T) {
+	cfg := &struct {
+		Zeta  string `long:"zeta"`
+		Alpha string `long:"alpha"`
+	}{}
+
+	out := &bytes.Buffer{}
+	usage := false
+
+	flagSet, err := Parse(cfg,
+		sflags.Output(out),
+		sflags.Usage(func() { usage = true }),
+		sflags.SortFlags(false),
+	)
+	require.NoError(t, err)
+
+	flagSet.PrintDefaults()
+	assert.False(t, flagSet.SortFlags)
+	assert.Less(t, strings.Index(out.String(), "zeta"), strings.Index(out.String(), "alpha"))
+
+	flagSet.Usage()
+	assert.True(t, usage)
+}
+
+func TestCheckGroups(t *testing.T) {
+	cfg := &struct {
+		User     string `long:"user" required-with:"credentials"`
+		Password string `long:"password" required-with:"credentials"`
+		Quiet    bool   `long:"quiet" xor:"output"`
+		Verbose  bool   `long:"verbose" xor:"output"`
+		TLS      struct {
+			Cert string `long:"cert"`
+			Key  string `long:"key"`
+		} `flag:"tls" all-or-none:"true"`
+	}{}
+
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{args: []string{"--quiet"}},
+		{args: []string{"--user", "alice", "--password", "secret"}},
+		{args: []string{"--user", "alice"}, err: "if any flags in the group [password user] are set they must all be set; missing [password]"},
+		{args: []string{"--quiet", "--verbose"}, err: "if any flags in the group [quiet verbose] are set none of the others can be; [quiet verbose] were all set"},
+		{args: []string{"--tls-key", "key.pem"}, err: "missing [tls-cert]"},
+		{args: []string{"--tls-cert", "cert.pem", "--tls-key", "key.pem"}},
+	}
+
+	for _, test := range tests {
+		flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		require.NoError(t, ParseTo(cfg, flagSet))
+		require.NoError(t, flagSet.Parse(test.args))
+
+		err := CheckGroups(flagSet)
+		if test.err == "" {
+			assert.NoError(t, err, test.args)
+		} else {
+			assert.ErrorContains(t, err, test.err, test.args)
+		}
+	}
+}
+
+func TestFlagSetGroups(t *testing.T) {
+	cfg := &struct {
+		Quiet   bool `long:"quiet" xor:"output"`
+		Verbose bool `long:"verbose" xor:"output"`
+	}{}
+
+	flagSet := NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet, sflags.SortFlags(false)))
+	assert.False(t, flagSet.SortFlags)
+
+	assert.NoError(t, flagSet.Parse([]string{"--quiet"}))
+
+	flagSet = NewFlagSet("test", pflag.ContinueOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+	assert.ErrorContains(t, flagSet.Parse([]string{"--quiet", "--verbose"}), "[quiet verbose] were all set")
+
+	flagSet = NewFlagSet("test", pflag.PanicOnError)
+	require.NoError(t, ParseTo(cfg, flagSet))
+	assert.Panics(t, func() { _ = flagSet.Parse([]string{"--quiet", "--verbose"}) })
+}
+
+func TestSuggest(t *testing.T) {
+	cfg := &struct {
+		Color   string `long:"color" alias:"colour"`
+		Colors  bool   `long:"colors"`
+		Verbose bool   `long:"verbose"`
+		Old     bool   `long:"old-color" deprecated:"true"`
+	}{}
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+	require.NoError(t, ParseTo(cfg, flagSet))
+
+	err := Suggest(flagSet, flagSet.Parse([]string{"--colr", "red"}), 0)
+	assert.EqualError(t, err, "unknown flag: --colr, did you mean --color or --colors?")
+
+	err = Suggest(flagSet, flagSet.Parse([]string{"--verb"}), 0)
+	assert.EqualError(t, err, "unknown flag: --verb, did you mean --verbose?")
+
+	err = Suggest(flagSet, flagSet.Parse([]string{"--size"}), 0)
+	assert.EqualError(t, err, "unknown flag: --size")
+
+	assert.Nil(t, Suggest(flagSet, nil, 0))
+	assert.Equal(t, []string{"color", "colors"}, SuggestFlags(flagSet, "cloro", 3))
+}
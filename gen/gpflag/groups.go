@@ -0,0 +1,142 @@
+package gpflag
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// exclusiveAnnotation is the flag annotation holding the name of the
+	// group of mutually exclusive flags it belongs to (`xor` tag).
+	exclusiveAnnotation = "xor"
+
+	// togetherAnnotation is the flag annotation holding the name of the
+	// group of flags it must be set together with (`required-with` tag).
+	togetherAnnotation = "required-with"
+)
+
+// MarkExclusive makes all flags in a set mutually exclusive with each
+// other, as if they were tagged with the same `xor:"group"` tag.
+func MarkExclusive(flags *pflag.FlagSet, group string) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		flag.Annotations[exclusiveAnnotation] = []string{group}
+	})
+}
+
+// MarkRequiredTogether makes all flags in a set required together, as
+// if they were tagged with the same `required-with:"group"` tag.
+func MarkRequiredTogether(flags *pflag.FlagSet, group string) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		flag.Annotations[togetherAnnotation] = []string{group}
+	})
+}
+
+// FlagSet is a pflag.FlagSet checking the groups of its flags when parsed (see
+// CheckGroups), as pflag has no hook after parsing. Flags are put to it like to
+// a pflag.FlagSet, with GenerateTo or ParseTo.
+type FlagSet struct {
+	*pflag.FlagSet
+	errorHandling pflag.ErrorHandling
+}
+
+// NewFlagSet returns a new, empty flag set with the specified
+// name and error handling, checking the groups of its flags.
+func NewFlagSet(name string, errorHandling pflag.ErrorHandling) *FlagSet {
+	return &FlagSet{
+		FlagSet:       pflag.NewFlagSet(name, errorHandling),
+		errorHandling: errorHandling,
+	}
+}
+
+// Parse parses the flags from the argument list, like pflag.FlagSet.Parse,
+// and then checks the groups of flags, whose errors are handled like those
+// of parsing: returned, printed before exiting, or raised in a panic.
+func (f *FlagSet) Parse(arguments []string) error {
+	if err := f.FlagSet.Parse(arguments); err != nil {
+		return err
+	}
+
+	err := CheckGroups(f.FlagSet)
+	if err == nil {
+		return nil
+	}
+
+	switch f.errorHandling {
+	case pflag.ExitOnError:
+		fmt.Println(err)
+		os.Exit(2)
+	case pflag.PanicOnError:
+		panic(err)
+	}
+
+	return err
+}
+
+// CheckGroups returns an error if, after parsing, more than one flag of a group
+// of mutually exclusive flags is set, or if only some flags of a group of flags
+// required together are. pflag has no hook after parsing, so applications using
+// a pflag.FlagSet on their own should call it right after flags.Parse(), or use
+// a FlagSet, which does.
+func CheckGroups(flags *pflag.FlagSet) error {
+	exclusive, exclusiveSet := flagGroups(flags, exclusiveAnnotation)
+	for _, group := range sortedGroups(exclusive) {
+		if len(exclusiveSet[group]) > 1 {
+			return fmt.Errorf("if any flags in the group [%s] are set none of the others can be; [%s] were all set",
+				strings.Join(exclusive[group], " "), strings.Join(exclusiveSet[group], " "))
+		}
+	}
+
+	together, togetherSet := flagGroups(flags, togetherAnnotation)
+	for _, group := range sortedGroups(together) {
+		if set := len(togetherSet[group]); set > 0 && set < len(together[group]) {
+			return fmt.Errorf("if any flags in the group [%s] are set they must all be set; missing [%s]",
+				strings.Join(together[group], " "), strings.Join(unset(flags, together[group]), " "))
+		}
+	}
+
+	return nil
+}
+
+// flagGroups returns the flags of each group found in an annotation, and the ones set.
+func flagGroups(flags *pflag.FlagSet, annotation string) (all, set map[string][]string) {
+	all = make(map[string][]string)
+	set = make(map[string][]string)
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		for _, group := range flag.Annotations[annotation] {
+			all[group] = append(all[group], flag.Name)
+			if flag.Changed {
+				set[group] = append(set[group], flag.Name)
+			}
+		}
+	})
+
+	return all, set
+}
+
+func sortedGroups(groups map[string][]string) []string {
+	names := make([]string, 0, len(groups))
+	for group := range groups {
+		names = append(names, group)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func unset(flags *pflag.FlagSet, names []string) []string {
+	var missing []string
+
+	for _, name := range names {
+		if !flags.Lookup(name).Changed {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
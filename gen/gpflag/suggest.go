@@ -0,0 +1,108 @@
+package gpflag
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultSuggestionsDistance is the maximum number of edits between an
+// unknown flag and the flags suggested for it, like for cobra commands.
+const DefaultSuggestionsDistance = 2
+
+// unknownFlag matches the errors of pflag for unknown long flags.
+var unknownFlag = regexp.MustCompile(`^unknown flag: --([^= ]+)`)
+
+// Suggest adds the names of the flags closest to an unknown flag to the error
+// returned by pflag, like "unknown flag: --colr, did you mean --color?". Other
+// errors are returned as is. Applications using a flag set on their own should
+// call it with the error of flags.Parse(), pflag having no hook for it.
+func Suggest(flags *pflag.FlagSet, err error, distance int) error {
+	if err == nil {
+		return nil
+	}
+
+	match := unknownFlag.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	names := SuggestFlags(flags, match[1], distance)
+	if len(names) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w, did you mean --%s?", err, strings.Join(names, " or --"))
+}
+
+// SuggestFlags returns the names of the visible flags of a set which are at
+// most distance edits away from name, or start with it, sorted by distance.
+// The help flag is never suggested, being always there.
+func SuggestFlags(flags *pflag.FlagSet, name string, distance int) []string {
+	if distance <= 0 {
+		distance = DefaultSuggestionsDistance
+	}
+
+	distances := map[string]int{}
+
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Deprecated != "" || flag.Name == name || flag.Name == "help" {
+			return
+		}
+
+		edits := levenshtein(strings.ToLower(name), strings.ToLower(flag.Name))
+		if edits <= distance || strings.HasPrefix(flag.Name, name) {
+			distances[flag.Name] = edits
+		}
+	})
+
+	names := make([]string, 0, len(distances))
+	for flag := range distances {
+		names = append(names, flag)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if distances[names[i]] != distances[names[j]] {
+			return distances[names[i]] < distances[names[j]]
+		}
+
+		return names[i] < names[j]
+	})
+
+	return names
+}
+
+// levenshtein returns the number of single-character edits between two words.
+func levenshtein(first, second string) int {
+	previous := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current := make([]int, len(second)+1)
+		current[0] = i
+
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+			if edit := previous[j] + 1; edit < current[j] {
+				current[j] = edit
+			}
+			if edit := current[j-1] + 1; edit < current[j] {
+				current[j] = edit
+			}
+		}
+
+		previous = current
+	}
+
+	return previous[len(second)]
+}
@@ -0,0 +1,59 @@
+package sflags
+
+import (
+	"sort"
+	"sync"
+)
+
+// Generator builds the flags (or commands) of a library from data, a pointer to
+// a struct, like the Parse functions of the generator packages (gen/gpflag, etc).
+type Generator func(data interface{}, optFuncs ...OptFunc) (interface{}, error)
+
+var (
+	generators   = map[string]Generator{}
+	generatorsMu sync.RWMutex
+)
+
+// RegisterGenerator registers a generator under a name, to be used by Generate.
+// Generator packages register themselves when imported (eg. "pflag" for gpflag),
+// so that only the generators (and libraries) needed by a binary are compiled in:
+//
+//	import _ "github.com/octago/sflags/gen/gcobra"
+//
+//	cmd, err := sflags.Generate("cobra", &data)
+func RegisterGenerator(name string, gen Generator) {
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+
+	generators[name] = gen
+}
+
+// Generate builds the flags (or commands) of the generator registered under
+// name from data. The result is of the type returned by the Parse function of
+// the generator, like *pflag.FlagSet for "pflag", or *cobra.Command for "cobra".
+func Generate(name string, data interface{}, optFuncs ...OptFunc) (interface{}, error) {
+	generatorsMu.RLock()
+	gen, found := generators[name]
+	generatorsMu.RUnlock()
+
+	if !found {
+		return nil, newError(ErrUnknownGenerator, name)
+	}
+
+	return gen(data, optFuncs...)
+}
+
+// Generators returns the names of the registered generators, sorted.
+func Generators() []string {
+	generatorsMu.RLock()
+	defer generatorsMu.RUnlock()
+
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,30 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	RegisterGenerator("test-flags", func(data interface{}, optFuncs ...OptFunc) (interface{}, error) {
+		return ParseStruct(data, optFuncs...)
+	})
+
+	cfg := &struct {
+		Name string `long:"name"`
+	}{}
+
+	generated, err := Generate("test-flags", cfg, Prefix("app-"))
+	require.NoError(t, err)
+
+	flags, ok := generated.([]*Flag)
+	require.True(t, ok)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "app-name", flags[0].Name)
+	assert.Contains(t, Generators(), "test-flags")
+
+	_, err = Generate("viper", cfg)
+	assert.ErrorIs(t, err, ErrUnknownGenerator)
+}
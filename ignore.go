@@ -0,0 +1,16 @@
+package sflags
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// IgnoreType registers a type whose struct fields (or pointers to it) are never
+// scanned as flags, positional arguments or groups of options, whatever their
+// tags: for instance client handles or locks embedded in command structs.
+// The sync.Mutex, sync.RWMutex, sync.WaitGroup, sync.Once and context.Context
+// types are always ignored.
+func IgnoreType(typ reflect.Type) {
+	tag.Ignore(typ)
+}
@@ -0,0 +1,39 @@
+package sflags
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ignoredClient struct {
+	Endpoint string `long:"endpoint"`
+}
+
+type ignoreCfg struct {
+	sync.Mutex `group:"lock"`
+
+	Ctx    context.Context `long:"ctx"`
+	Client *ignoredClient  `group:"client"`
+	Name   string          `long:"name"`
+}
+
+func TestIgnoreType(t *testing.T) {
+	cfg := &ignoreCfg{Client: &ignoredClient{}}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+	assert.Equal(t, "client-endpoint", flags[0].Name)
+
+	IgnoreType(reflect.TypeOf(ignoredClient{}))
+
+	flags, err = ParseStruct(cfg, RequireTags())
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "name", flags[0].Name)
+}
@@ -0,0 +1,64 @@
+package sflags
+
+// FlagInfo describes a flag without its value, for tools rendering the flags
+// of parsed structs (documentation, schemas, forms, help) without depending on
+// a given flag library. Its fields are only ever added, never changed.
+type FlagInfo struct {
+	Name          string   `json:"name"`
+	Short         string   `json:"short,omitempty"`
+	Usage         string   `json:"usage,omitempty"`
+	EnvName       string   `json:"env,omitempty"`
+	Default       string   `json:"default,omitempty"`
+	Type          string   `json:"type"`
+	Group         string   `json:"group,omitempty"`
+	Hidden        bool     `json:"hidden,omitempty"`
+	Deprecated    bool     `json:"deprecated,omitempty"`
+	DeprecatedMsg string   `json:"deprecatedMessage,omitempty"`
+	Required      bool     `json:"required,omitempty"`
+	Persistent    bool     `json:"persistent,omitempty"`
+	Secret        bool     `json:"secret,omitempty"`
+	Example       string   `json:"example,omitempty"`
+	Choices       []string `json:"choices,omitempty"`
+	Validators    []string `json:"validators,omitempty"`
+	Exclusive     string   `json:"xor,omitempty"`
+	RequiredWith  string   `json:"requiredWith,omitempty"`
+	OptionalValue []string `json:"optionalValue,omitempty"`
+	Bool          bool     `json:"bool,omitempty"` // the flag is given without value
+}
+
+// Info returns the description of the flag, from which generators build the
+// flags of their libraries. Its type is the one of its value, as used by pflag
+// (eg. "int" or "stringSlice"), and its default value is displayed as in help
+// output, so that secret defaults are never exposed.
+func (f *Flag) Info() FlagInfo {
+	info := FlagInfo{
+		Name:          f.Name,
+		Short:         f.Short,
+		Usage:         f.HelpUsage(),
+		EnvName:       f.EnvName,
+		Default:       f.DefValue,
+		Group:         f.Group,
+		Hidden:        f.Hidden,
+		Deprecated:    f.Deprecated,
+		DeprecatedMsg: f.DeprecatedMsg,
+		Required:      f.Required,
+		Persistent:    f.Persistent,
+		Secret:        f.Secret,
+		Example:       f.Example,
+		Choices:       append([]string(nil), f.Choices...),
+		Validators:    append([]string(nil), f.Validators...),
+		Exclusive:     f.Exclusive,
+		RequiredWith:  f.RequiredWith,
+		OptionalValue: append([]string(nil), f.OptionalValue...),
+	}
+
+	if f.Value != nil {
+		info.Type = f.Value.Type()
+	}
+
+	if boolFlag, casted := f.Value.(BoolFlag); casted {
+		info.Bool = boolFlag.IsBoolFlag()
+	}
+
+	return info
+}
@@ -0,0 +1,74 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type infoCfg struct {
+	Server struct {
+		Port  int    `long:"port" short:"p" desc:"port to listen on" example-value:"8080" validate:"min=1,max=65535"`
+		Token string `long:"token" secret:"true" default:"s3cr3t"`
+	} `group:"server options" persistent:"true"`
+	Format string `long:"format" choices:"json yaml" required:"true"`
+	Name   string `long:"name" validate:"nonzero,regexp=^a{1,3}$"`
+}
+
+func TestFlagInfo(t *testing.T) {
+	flags, err := ParseStruct(&infoCfg{})
+	require.NoError(t, err)
+	require.Len(t, flags, 4)
+
+	assert.Equal(t, FlagInfo{
+		Name:       "server-port",
+		Short:      "p",
+		Usage:      "port to listen on (e.g. 8080)",
+		EnvName:    "SERVER_PORT",
+		Default:    "0",
+		Type:       "int",
+		Group:      "server options",
+		Persistent: true,
+		Example:    "8080",
+		Validators: []string{"min=1", "max=65535"},
+	}, flags[0].Info())
+
+	token := flags[1].Info()
+	assert.Equal(t, "********", token.Default)
+	assert.Equal(t, "SERVER_TOKEN", token.EnvName)
+	assert.True(t, token.Secret)
+
+	format := flags[2].Info()
+	assert.Empty(t, format.Group)
+	assert.True(t, format.Required)
+	assert.Equal(t, []string{"json", "yaml"}, format.Choices)
+
+	// Regular expressions might contain commas.
+	assert.Equal(t, []string{"nonzero", "regexp=^a{1,3}$"}, flags[3].Info().Validators)
+}
+
+func TestFlagInfoGenerators(t *testing.T) {
+	cfg := &struct {
+		Dry     bool   `long:"dry" xor:"mode"`
+		Level   int    `long:"level" optional-value:"3" required-with:"log"`
+		Ignored string `long:"old" deprecated:"use --level instead"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	dry := flags[0].Info()
+	assert.True(t, dry.Bool)
+	assert.Equal(t, "mode", dry.Exclusive)
+
+	level := flags[1].Info()
+	assert.False(t, level.Bool)
+	assert.Equal(t, "log", level.RequiredWith)
+	assert.Equal(t, []string{"3"}, level.OptionalValue)
+
+	old := flags[2].Info()
+	assert.True(t, old.Deprecated)
+	assert.Equal(t, "use --level instead", old.DeprecatedMsg)
+}
@@ -0,0 +1,51 @@
+package sflags
+
+import "github.com/octago/sflags/internal/tag"
+
+// inheritedTags are the tags of groups of options which cascade
+// to the fields of the groups (and of their nested groups),
+// unless these fields are given their own.
+var inheritedTags = []string{"hidden", "persistent", "env-namespace", "validate"}
+
+// InheritTags makes the parsed fields inherit the tags of the group of options
+// they belong to, like hidden, persistent, env-namespace or validate, unless
+// they have their own. Nested groups inherit them from their parents anyway:
+// this is meant for generators parsing the struct of a group themselves.
+func InheritTags(mtag tag.MultiTag) OptFunc {
+	return func(opt *opts) { *opt = opt.inherit(mtag) }
+}
+
+// inherit returns the options of the fields of a group, inheriting its tags.
+func (o opts) inherit(mtag tag.MultiTag) opts {
+	inherited := make(map[string]string, len(inheritedTags))
+	for key, value := range o.inherited {
+		inherited[key] = value
+	}
+
+	for _, key := range inheritedTags {
+		if value, isSet := mtag.Get(key); isSet {
+			inherited[key] = value
+		}
+	}
+
+	o.inherited = inherited
+
+	// Fields also know the title of their group.
+	if title, _ := mtag.Get("group"); title != "" {
+		o.group = title
+	} else if title, _ := mtag.Get("options"); title != "" {
+		o.group = title
+	}
+
+	return o
+}
+
+// applyInherited sets the tags inherited from groups on the tags of a field,
+// unless it has its own.
+func (o opts) applyInherited(mtag *tag.MultiTag) {
+	for key, value := range o.inherited {
+		if _, isSet := mtag.Get(key); !isSet {
+			mtag.Set(key, value)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritTags(t *testing.T) {
+	cfg := &struct {
+		Debug struct {
+			Trace   bool   `long:"trace" validate:""`
+			Profile string `long:"profile" hidden:"false" validate:"oneof=cpu mem"`
+			Dump    struct {
+				Path string `long:"path"`
+			} `flag:"dump" env-namespace:"DUMP_"`
+		} `flag:"debug" hidden:"true" persistent:"true" env-namespace:"APP_" validate:"max=3"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	byName := map[string]*Flag{}
+	for _, flag := range flags {
+		byName[flag.Name] = flag
+	}
+
+	assert.True(t, byName["debug-trace"].Hidden)
+	assert.True(t, byName["debug-trace"].Persistent)
+	assert.Equal(t, "APP_DEBUG_TRACE", byName["debug-trace"].EnvName)
+
+	assert.False(t, byName["debug-profile"].Hidden, "overridden")
+	assert.True(t, byName["debug-profile"].Persistent)
+
+	assert.True(t, byName["debug-dump-path"].Hidden, "inherited from the grandparent")
+	assert.Equal(t, "DUMP_DEBUG_DUMP_PATH", byName["debug-dump-path"].EnvName)
+
+	// Validation rules are inherited, unless overridden.
+	assert.Error(t, byName["debug-dump-path"].Value.Set("/tmp"))
+	assert.NoError(t, byName["debug-dump-path"].Value.Set("/a"))
+	assert.NoError(t, byName["debug-profile"].Value.Set("cpu"))
+
+	cfg.Debug.Dump.Path = "/tmp"
+	assert.Error(t, Validate(cfg))
+
+	cfg.Debug.Dump.Path = ""
+	assert.NoError(t, Validate(cfg))
+}
@@ -0,0 +1,375 @@
+package sflags
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags/internal/positional"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// Model is the tree of commands, option groups, flags and positional arguments
+// declared by a struct, as returned by Inspect, so that tools can build docs,
+// forms or remote schemas without scanning the struct themselves.
+type Model struct {
+	Name            string   // Name of the command, empty for the root struct.
+	Description     string   // Short description of the command.
+	LongDescription string   // Long description of the command.
+	Aliases         []string // Other names of the command.
+	Group           string   // Group of commands the command belongs to, if any.
+	Hidden          bool
+
+	// Metadata of the command (`annotation:"key:value"` tags), for tools.
+	Annotations map[string]string
+
+	Flags       []*Flag       // Flags of the command, outside of option groups.
+	Groups      []*Group      // Groups of options of the command.
+	Positionals []*Positional // Positional arguments, in order.
+	Commands    []*Model      // Subcommands of the command.
+}
+
+// Group is a group of options, possibly containing other groups.
+type Group struct {
+	Name        string
+	Description string
+	Flags       []*Flag
+	Groups      []*Group
+}
+
+// Positional is a positional argument slot of a command.
+type Positional struct {
+	Name        string   // Name of the argument, as shown in usage.
+	Field       string   // Name of the struct field holding the argument.
+	Description string   // Description of the argument.
+	Minimum     int      // Minimum number of words the argument requires.
+	Maximum     int      // Maximum number of words the argument accepts (-1: any number).
+	Default     []string // Default values, if no words are given.
+}
+
+// Inspect returns the model of the commands, option groups, flags and positional
+// arguments declared by data, a pointer to a struct, with their names, tags,
+// requirements and defaults, the same way generators would find them. The struct
+// is not modified, as defaults are computed on a copy of it, in which the groups
+// of options and subcommands it points to are copied as well.
+func Inspect(data interface{}, optFuncs ...OptFunc) (*Model, error) {
+	if data == nil {
+		return nil, ErrObjectIsNil
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, ErrNotPointerToStruct
+	}
+
+	if v.IsNil() {
+		return nil, ErrObjectIsNil
+	}
+
+	// Defaults and parsed flags are bound to a copy of the struct.
+	inspected := deepCopy(v.Elem(), map[uintptr]reflect.Value{})
+
+	model := &Model{}
+	if err := inspectCommand(model, inspected, defOpts().apply(optFuncs...)); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// inspectCommand adds the flags, groups, positionals and subcommands
+// declared by the fields of a command struct to its model.
+func inspectCommand(model *Model, val reflect.Value, opt opts) error {
+	ApplyDefaults(val)
+
+	opt.owner = val.Type()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		fieldVal := val.Field(i)
+
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		mtag, none, err := tag.GetFieldTag(field)
+		if none || err != nil {
+			continue
+		}
+
+		// Positional arguments
+		if pargs, _ := mtag.Get("positional-args"); pargs != "" {
+			args, err := positional.ScanArgs(reflect.Indirect(fieldVal), mtag)
+			if err != nil {
+				return err
+			}
+
+			fields := reflect.Indirect(fieldVal).Type()
+			model.Positionals = append(model.Positionals, inspectPositionals(args, fields)...)
+
+			continue
+		}
+
+		// Subcommands
+		if name, _ := mtag.Get("command"); name != "" {
+			if err := inspectSubcommand(model, fieldVal, name, mtag, opt); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Groups of commands (and options)
+		if group, isSet := mtag.Get("commands"); isSet {
+			if inner := indirectStruct(fieldVal); inner.IsValid() {
+				groupModel := &Model{}
+				if err := inspectCommand(groupModel, inner, opt); err != nil {
+					return err
+				}
+
+				for _, subc := range groupModel.Commands {
+					if subc.Group == "" && !isStringFalsy(group) {
+						subc.Group = group
+					}
+				}
+
+				model.Flags = append(model.Flags, groupModel.Flags...)
+				model.Groups = append(model.Groups, groupModel.Groups...)
+				model.Commands = append(model.Commands, groupModel.Commands...)
+			}
+
+			continue
+		}
+
+		flags, groups := inspectField(fieldVal, field, mtag, opt)
+		model.Flags = append(model.Flags, flags...)
+		model.Groups = append(model.Groups, groups...)
+	}
+
+	return nil
+}
+
+// inspectSubcommand adds the model of a subcommand to the model of its parent.
+func inspectSubcommand(model *Model, val reflect.Value, name string, mtag tag.MultiTag, opt opts) error {
+	// Commands declared as interfaces might not be implemented.
+	if val.Kind() == reflect.Interface && val.IsNil() {
+		return nil
+	}
+
+	ptrval, isCmd, _ := IsCommand(val)
+	if !isCmd {
+		return ErrNotCommander
+	}
+
+	subc := &Model{
+		Name:    name,
+		Aliases: mtag.GetMany("alias"),
+	}
+
+	subc.Description, _ = mtag.Get("description")
+	subc.LongDescription, _ = mtag.Get("long-description")
+	subc.Group, _ = mtag.Get("group")
+	_, subc.Hidden = mtag.Get("hidden")
+	subc.Annotations = tag.Annotations(mtag)
+
+	// Flags of subcommands are not prefixed by the ones of their parents.
+	opt.prefix = ""
+	opt.envPrefix = ""
+
+	if err := inspectCommand(subc, ptrval.Elem(), opt); err != nil {
+		return err
+	}
+
+	model.Commands = append(model.Commands, subc)
+
+	return nil
+}
+
+// inspectField returns the flags of a field, or the group of options it declares.
+func inspectField(val reflect.Value, field reflect.StructField, mtag tag.MultiTag, opt opts) ([]*Flag, []*Group) {
+	if !isGroup(field.Type) {
+		flags, _ := ParseField(val, field, copyOpts(opt))
+
+		return flags, nil
+	}
+
+	inner := indirectStruct(val)
+	if !inner.IsValid() {
+		return nil, nil
+	}
+
+	flag, _ := parseFlagTag(field, opt)
+	if flag == nil {
+		return nil, nil
+	}
+
+	fieldOpt := opt.inherit(mtag)
+	fieldOpt.owner = inner.Type()
+
+	if envNamespace, _ := mtag.Get("env-namespace"); envNamespace != "" {
+		fieldOpt.envPrefix = envNamespace
+	}
+
+	// Groups tagged as such are namespaced by their tags, while other
+	// nested structs are prefixed by their name, unless flattened.
+	group := &Group{}
+	group.Description, _ = mtag.Get("description")
+
+	legacyGroup, isLegacy := mtag.Get("group")
+	optionsGroup, isOptions := mtag.Get("options")
+
+	switch {
+	case isLegacy || isOptions:
+		group.Name = legacyGroup + optionsGroup
+		namespace, _ := mtag.Get("namespace")
+		delim, _ := mtag.Get("namespace-delimiter")
+		fieldOpt.prefix = ""
+		if namespace != "" {
+			fieldOpt.prefix = namespace + delim
+		}
+	case field.Anonymous && opt.flatten:
+		group = nil
+		fieldOpt.prefix = opt.prefix
+	default:
+		group.Name = flag.Name
+		fieldOpt.prefix = flag.Name + opt.flagDivider
+	}
+
+	ApplyDefaults(inner)
+
+	var flags []*Flag
+
+	var groups []*Group
+
+	for i := 0; i < inner.NumField(); i++ {
+		innerField := inner.Type().Field(i)
+		if innerField.PkgPath != "" && !innerField.Anonymous {
+			continue
+		}
+
+		innerTag, none, err := tag.GetFieldTag(innerField)
+		if none || err != nil {
+			continue
+		}
+
+		innerFlags, innerGroups := inspectField(inner.Field(i), innerField, innerTag, fieldOpt)
+		flags = append(flags, innerFlags...)
+		groups = append(groups, innerGroups...)
+	}
+
+	// Nested groups might be mutually exclusive, or required together.
+	if exclusive, _ := mtag.Get("exclusive"); !isStringFalsy(exclusive) {
+		for _, nested := range flags {
+			nested.Exclusive = flag.Name
+		}
+	}
+
+	if allOrNone, _ := mtag.Get("all-or-none"); !isStringFalsy(allOrNone) {
+		for _, nested := range flags {
+			nested.RequiredWith = flag.Name
+		}
+	}
+
+	if group == nil {
+		return flags, groups
+	}
+
+	group.Flags = flags
+	group.Groups = groups
+
+	return nil, []*Group{group}
+}
+
+// inspectPositionals returns the models of the positional arguments of a command.
+// The fields of the struct holding them are all positional arguments, in order.
+func inspectPositionals(args *positional.Args, fields reflect.Type) []*Positional {
+	var positionals []*Positional
+
+	for _, arg := range args.Positionals() {
+		pos := &Positional{
+			Name:    arg.Name,
+			Field:   fields.Field(arg.Index).Name,
+			Minimum: arg.Minimum,
+			Maximum: arg.Maximum,
+			Default: arg.Tag.GetMany("default"),
+		}
+
+		pos.Description, _ = arg.Tag.Get("description")
+		positionals = append(positionals, pos)
+	}
+
+	return positionals
+}
+
+// indirectStruct returns the struct held by a value or pointed to
+// by it, allocating the pointer if needed, or an invalid value.
+func indirectStruct(val reflect.Value) reflect.Value {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			if !val.CanSet() {
+				return reflect.Value{}
+			}
+
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return val
+}
+
+// deepCopy returns a copy of a struct, in which the values pointed to by its
+// exported fields (groups of options, subcommands, etc) are copied as well, so
+// that defaults can be set on it without modifying the original. Copies are
+// shared by pointers, to preserve cycles.
+func deepCopy(val reflect.Value, copies map[uintptr]reflect.Value) reflect.Value {
+	copied := reflect.New(val.Type()).Elem()
+	copied.Set(val)
+
+	for i := 0; i < copied.NumField(); i++ {
+		if val.Type().Field(i).PkgPath != "" {
+			continue
+		}
+
+		field := copied.Field(i)
+
+		switch field.Kind() {
+		case reflect.Struct:
+			field.Set(deepCopy(field, copies))
+		case reflect.Ptr:
+			if !field.IsNil() {
+				field.Set(copyPointer(field, copies))
+			}
+		case reflect.Interface:
+			// Commands might be declared as interfaces.
+			if elem := field.Elem(); elem.Kind() == reflect.Ptr && !elem.IsNil() {
+				field.Set(copyPointer(elem, copies))
+			}
+		case reflect.Slice, reflect.Map:
+			field.Set(cloneValue(field))
+		}
+	}
+
+	return copied
+}
+
+// copyPointer returns a pointer to a copy of the value pointed to by ptr.
+func copyPointer(ptr reflect.Value, copies map[uintptr]reflect.Value) reflect.Value {
+	if copied, found := copies[ptr.Pointer()]; found {
+		return copied
+	}
+
+	copied := reflect.New(ptr.Type().Elem())
+	copies[ptr.Pointer()] = copied
+
+	if ptr.Elem().Kind() == reflect.Struct {
+		copied.Elem().Set(deepCopy(ptr.Elem(), copies))
+	} else {
+		copied.Elem().Set(ptr.Elem())
+	}
+
+	return copied
+}
@@ -0,0 +1,109 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inspectedCommand struct {
+	Force bool `long:"force" description:"Force the deployment"`
+	Args  struct {
+		Target string   `description:"Target of the deployment" default:"all"`
+		Hosts  []string `required:"1"`
+	} `positional-args:"yes"`
+}
+
+func (*inspectedCommand) Execute(args []string) error { return nil }
+
+func TestInspect(t *testing.T) {
+	cfg := &struct {
+		Verbose bool `short:"v" long:"verbose"`
+		Log     struct {
+			Level string `long:"level" default:"info" required:"true"`
+		} `flag:"log" description:"Logging options"`
+		Server struct {
+			Port int `long:"port"`
+		} `group:"Server options" namespace:"server" namespace-delimiter:"."`
+		Deploy inspectedCommand `command:"deploy" description:"Deploy the app" alias:"d" group:"ops" annotations:"team:infra,beta" annotation:"docs:deploy.md"`
+	}{}
+
+	model, err := Inspect(cfg)
+	require.NoError(t, err)
+
+	require.Len(t, model.Flags, 1)
+	assert.Equal(t, "verbose", model.Flags[0].Name)
+
+	require.Len(t, model.Groups, 2)
+	assert.Equal(t, "log", model.Groups[0].Name)
+	assert.Equal(t, "Logging options", model.Groups[0].Description)
+	require.Len(t, model.Groups[0].Flags, 1)
+	assert.Equal(t, "log-level", model.Groups[0].Flags[0].Name)
+	assert.Equal(t, "info", model.Groups[0].Flags[0].DefValue)
+	assert.True(t, model.Groups[0].Flags[0].Required)
+
+	assert.Equal(t, "Server options", model.Groups[1].Name)
+	require.Len(t, model.Groups[1].Flags, 1)
+	assert.Equal(t, "server.port", model.Groups[1].Flags[0].Name)
+
+	require.Len(t, model.Commands, 1)
+	deploy := model.Commands[0]
+	assert.Equal(t, "deploy", deploy.Name)
+	assert.Equal(t, "Deploy the app", deploy.Description)
+	assert.Equal(t, []string{"d"}, deploy.Aliases)
+	assert.Equal(t, "ops", deploy.Group)
+	assert.Equal(t, map[string]string{"team": "infra", "beta": "true", "docs": "deploy.md"}, deploy.Annotations)
+	require.Len(t, deploy.Flags, 1)
+	assert.Equal(t, "Force the deployment", deploy.Flags[0].Usage)
+
+	require.Len(t, deploy.Positionals, 2)
+	assert.Equal(t, &Positional{
+		Name: "Target", Field: "Target", Description: "Target of the deployment",
+		Minimum: 0, Maximum: 1, Default: []string{"all"},
+	}, deploy.Positionals[0])
+	assert.Equal(t, "Hosts", deploy.Positionals[1].Name)
+	assert.Equal(t, 1, deploy.Positionals[1].Minimum)
+	assert.Equal(t, -1, deploy.Positionals[1].Maximum)
+
+	// The inspected struct is left untouched.
+	assert.Empty(t, cfg.Log.Level)
+	assert.Empty(t, cfg.Deploy.Args.Target)
+
+	_, err = Inspect(*cfg)
+	assert.ErrorIs(t, err, ErrNotPointerToStruct)
+}
+
+type inspectedPointers struct {
+	Port int `long:"port" default:"8080"`
+}
+
+func (*inspectedPointers) Execute(args []string) error { return nil }
+
+func TestInspectPointers(t *testing.T) {
+	type logOptions struct {
+		Level string `long:"level" default:"info"`
+	}
+
+	cfg := &struct {
+		Log    *logOptions        `flag:"log"`
+		Unset  *logOptions        `flag:"unset"`
+		Sub    *inspectedPointers `command:"sub"`
+		Tags   []string           `long:"tag" default:"a"`
+		Shared *logOptions        `flag:"shared"`
+	}{Log: &logOptions{}, Sub: &inspectedPointers{}, Tags: []string{}}
+
+	cfg.Shared = cfg.Log
+
+	model, err := Inspect(cfg)
+	require.NoError(t, err)
+	require.Len(t, model.Commands, 1)
+	assert.Equal(t, "8080", model.Commands[0].Flags[0].DefValue)
+
+	// The groups and subcommands pointed to are left untouched too.
+	assert.Equal(t, &logOptions{}, cfg.Log)
+	assert.Nil(t, cfg.Unset)
+	assert.Equal(t, &inspectedPointers{}, cfg.Sub)
+	assert.Empty(t, cfg.Tags)
+	assert.Same(t, cfg.Log, cfg.Shared)
+}
@@ -1,8 +1,10 @@
 package convert
 
 import (
+	"encoding"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -70,12 +72,39 @@ type ValueValidator interface {
 
 // Value converts a string to its underlying/native value type, therefore
 // directly applying this value on the struct field it was created from.
+// Conversion errors are returned as *ConvertError, without their Field set.
 func Value(val string, retval reflect.Value, options tag.MultiTag) error {
+	if err := convertValue(val, retval, options); err != nil {
+		return conversionError(val, retval, err)
+	}
+
+	return nil
+}
+
+func convertValue(val string, retval reflect.Value, options tag.MultiTag) error {
+	// Use any parser registered for the type
+	if ok, err := Registered(val, retval); ok {
+		return err
+	}
+
+	// Support for time.Time and url.URL
+	switch retval.Type() {
+	case reflect.TypeOf(time.Time{}):
+		return convertTime(val, retval, options)
+	case reflect.TypeOf(url.URL{}):
+		return convertURL(val, retval)
+	}
+
 	// Use unmarshaller if available/possible
 	if ok, err := convertUnmarshal(val, retval); ok {
 		return err
 	}
 
+	// Or the standard text unmarshaler
+	if ok, err := convertTextUnmarshal(val, retval); ok {
+		return err
+	}
+
 	valType := retval.Type()
 
 	// Support for time.Duration
@@ -86,6 +115,15 @@ func Value(val string, retval reflect.Value, options tag.MultiTag) error {
 	switch valType.Kind() {
 	// Strings & bools
 	case reflect.String:
+		if IsPath(options) {
+			expanded, err := ExpandPath(val)
+			if err != nil {
+				return err
+			}
+
+			val = expanded
+		}
+
 		retval.SetString(val)
 	case reflect.Bool:
 		return convertBool(val, retval)
@@ -199,11 +237,28 @@ func convertUnmarshal(val string, retval reflect.Value) (bool, error) {
 	return false, nil
 }
 
+func convertTextUnmarshal(val string, retval reflect.Value) (bool, error) {
+	if !retval.CanAddr() {
+		return false, nil
+	}
+
+	unmarshaler, found := retval.Addr().Interface().(encoding.TextUnmarshaler)
+	if !found {
+		return false, nil
+	}
+
+	if err := unmarshaler.UnmarshalText([]byte(val)); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
 func convertWithUnmarshaler(val string, retval reflect.Value, unmarshaler Unmarshaler) (bool, error) {
 	// If we have an existing value, just use it
 	if !retval.IsNil() {
 		if err := unmarshaler.UnmarshalFlag(val); err != nil {
-			return true, fmt.Errorf("unmarshal error: %w", err)
+			return true, err
 		}
 
 		return true, nil
@@ -219,7 +274,7 @@ func convertWithUnmarshaler(val string, retval reflect.Value, unmarshaler Unmars
 
 	// And finally perform the custom unmarshaling
 	if err := unmarshaler.UnmarshalFlag(val); err != nil {
-		return true, fmt.Errorf("unmarshal error: %w", err)
+		return true, err
 	}
 
 	return true, nil
@@ -232,7 +287,7 @@ func convertWithUnmarshaler(val string, retval reflect.Value, unmarshaler Unmars
 func convertDuration(val string, retval reflect.Value) error {
 	parsed, err := time.ParseDuration(val)
 	if err != nil {
-		return fmt.Errorf("convert duration: %w", err)
+		return err
 	}
 
 	retval.SetInt(int64(parsed))
@@ -240,13 +295,40 @@ func convertDuration(val string, retval reflect.Value) error {
 	return nil
 }
 
+func convertTime(val string, retval reflect.Value, options tag.MultiTag) error {
+	layout, _ := options.Get("layout")
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	parsed, err := time.Parse(layout, val)
+	if err != nil {
+		return err
+	}
+
+	retval.Set(reflect.ValueOf(parsed))
+
+	return nil
+}
+
+func convertURL(val string, retval reflect.Value) error {
+	parsed, err := url.Parse(val)
+	if err != nil {
+		return err
+	}
+
+	retval.Set(reflect.ValueOf(*parsed))
+
+	return nil
+}
+
 func convertBool(val string, retval reflect.Value) error {
 	if val == "" {
 		retval.SetBool(true)
 	} else {
 		value, err := strconv.ParseBool(val)
 		if err != nil {
-			return fmt.Errorf("convert bool: %w", err)
+			return err
 		}
 
 		retval.SetBool(value)
@@ -264,16 +346,11 @@ func convertBoolStr(val reflect.Value) (string, error) {
 }
 
 func convertInt(val string, valType reflect.Type, retval reflect.Value, options tag.MultiTag) error {
-	base, err := getBase(options, baseParseInt)
+	parsed, err := ParseInt(val, valType.Bits(), options)
 	if err != nil {
 		return err
 	}
 
-	parsed, err := strconv.ParseInt(val, base, valType.Bits())
-	if err != nil {
-		return fmt.Errorf("convert int: %w", err)
-	}
-
 	retval.SetInt(parsed)
 
 	return nil
@@ -289,16 +366,11 @@ func convertIntStr(val reflect.Value, options tag.MultiTag) (string, error) {
 }
 
 func convertUint(val string, valType reflect.Type, retval reflect.Value, options tag.MultiTag) error {
-	base, err := getBase(options, baseParseInt)
+	parsed, err := ParseUint(val, valType.Bits(), options)
 	if err != nil {
 		return err
 	}
 
-	parsed, err := strconv.ParseUint(val, base, valType.Bits())
-	if err != nil {
-		return fmt.Errorf("convert uint: %w", err)
-	}
-
 	retval.SetUint(parsed)
 
 	return nil
@@ -316,7 +388,7 @@ func convertUintStr(val reflect.Value, options tag.MultiTag) (string, error) {
 func convertFloat(val string, valType reflect.Type, retval reflect.Value) error {
 	parsed, err := strconv.ParseFloat(val, valType.Bits())
 	if err != nil {
-		return fmt.Errorf("convert float: %w", err)
+		return err
 	}
 
 	retval.SetFloat(parsed)
@@ -327,14 +399,17 @@ func convertFloat(val string, valType reflect.Type, retval reflect.Value) error
 func convertSlice(val string, valType reflect.Type, retval reflect.Value, options tag.MultiTag) error {
 	elemtp := valType.Elem()
 
-	elemvalptr := reflect.New(elemtp)
-	elemval := reflect.Indirect(elemvalptr)
+	// Words are single values, unless a separator is given.
+	for _, elem := range Split(val, options, "") {
+		elemvalptr := reflect.New(elemtp)
+		elemval := reflect.Indirect(elemvalptr)
 
-	if err := Value(val, elemval, options); err != nil {
-		return err
-	}
+		if err := Value(elem, elemval, options); err != nil {
+			return err
+		}
 
-	retval.Set(reflect.Append(retval, elemval))
+		retval.Set(reflect.Append(retval, elemval))
+	}
 
 	return nil
 }
@@ -0,0 +1,40 @@
+package convert
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// Default sets the default values of a field, given in its `default` tags,
+// unless the field is already set (non-zero). Values are converted like
+// words given on the command line. Slices and maps might have several ones,
+// either in repeated tags or comma-separated (or split on their `sep` tag).
+// The field is left unset when any of its defaults is invalid.
+func Default(retval reflect.Value, options tag.MultiTag) error {
+	if !retval.IsZero() {
+		return nil
+	}
+
+	kind := retval.Kind()
+	if kind == reflect.Ptr {
+		kind = retval.Type().Elem().Kind()
+	}
+
+	for _, def := range options.GetMany("default") {
+		values := []string{def}
+		if kind == reflect.Slice || kind == reflect.Map {
+			values = Split(def, options, ",")
+		}
+
+		for _, val := range values {
+			if err := Value(val, retval, options); err != nil {
+				retval.Set(reflect.Zero(retval.Type()))
+
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ConvertError is returned when a word cannot be converted to the type of a field.
+// Its message is the one of the underlying error, so that callers can either
+// print it as is, or render their own message from the other fields.
+type ConvertError struct {
+	Field      string       // Name of the field (or flag), if known.
+	Word       string       // Word which could not be converted.
+	TargetType reflect.Type // Type of the field, or of its elements (eg. for slices).
+	Err        error        // Underlying parsing error.
+}
+
+func (e *ConvertError) Error() string { return e.Err.Error() }
+
+func (e *ConvertError) Unwrap() error { return e.Err }
+
+// conversionError wraps err into a ConvertError, unless it already is one.
+func conversionError(val string, retval reflect.Value, err error) error {
+	var convErr *ConvertError
+	if errors.As(err, &convErr) {
+		return err
+	}
+
+	return &ConvertError{Word: val, TargetType: retval.Type(), Err: err}
+}
+
+// Errors is a list of errors, returned when several words could not be parsed,
+// so that all of them are reported at once. errors.Is and errors.As match any
+// of the errors in the list.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors in the list.
+func (e Errors) Unwrap() []error { return e }
+
+// Is returns true if any of the errors in the list matches target.
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error in the list matching target.
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns nil if the list is empty, its only error if it has
+// only one, or the list itself.
+func (e Errors) Err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	default:
+		return e
+	}
+}
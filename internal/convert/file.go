@@ -0,0 +1,43 @@
+package convert
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// filePrefix marks values to be replaced with the contents of a file.
+const filePrefix = "@"
+
+// ExpandsFile returns true if the field tag enables
+// reading values from files, with `expand-file:"true"`.
+func ExpandsFile(options tag.MultiTag) bool {
+	expand, _ := options.Get("expand-file")
+
+	return expand != "" && expand != "false" && expand != "no" && expand != "0"
+}
+
+// ExpandFile replaces a value of the form @path with the contents of the file,
+// without its final newline, if the field tag enables it (see ExpandsFile).
+// A leading @ can be escaped by doubling it: @@value is set as @value.
+func ExpandFile(val string, options tag.MultiTag) (string, error) {
+	if !ExpandsFile(options) || !strings.HasPrefix(val, filePrefix) {
+		return val, nil
+	}
+
+	path := strings.TrimPrefix(val, filePrefix)
+	if strings.HasPrefix(path, filePrefix) {
+		return path, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("expand file: %w", err)
+	}
+
+	expanded := strings.TrimSuffix(string(contents), "\n")
+
+	return strings.TrimSuffix(expanded, "\r"), nil
+}
@@ -0,0 +1,102 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// Bounds of the bases accepted by strconv.
+const (
+	minBase = 2
+	maxBase = 36
+)
+
+// literalBases are the prefixes of hexadecimal, octal and binary integer literals.
+var literalBases = map[string]int{
+	"0x": 16,
+	"0o": 8,
+	"0b": 2,
+}
+
+// ParseInt parses a signed integer of the given bit size (see integerBase).
+func ParseInt(val string, bitSize int, options tag.MultiTag) (int64, error) {
+	digits, base, err := integerBase(val, options)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := strconv.ParseInt(digits, base, bitSize)
+	if err != nil {
+		return 0, integerError(val, base, err)
+	}
+
+	return parsed, nil
+}
+
+// ParseUint parses an unsigned integer of the given bit size (see integerBase).
+func ParseUint(val string, bitSize int, options tag.MultiTag) (uint64, error) {
+	digits, base, err := integerBase(val, options)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := strconv.ParseUint(digits, base, bitSize)
+	if err != nil {
+		return 0, integerError(val, base, err)
+	}
+
+	return parsed, nil
+}
+
+// integerBase returns the digits of an integer and their base: either the one forced
+// by the `base` tag of the field, or the one of its 0x, 0o or 0b prefix, or else 10.
+// The prefix of the forced base, if any, is accepted and removed from the digits.
+func integerBase(val string, options tag.MultiTag) (string, int, error) {
+	forced, err := getBase(options, 0)
+	if err != nil {
+		return val, 0, err
+	}
+
+	if forced != 0 && (forced < minBase || forced > maxBase) {
+		return val, 0, fmt.Errorf("base int: base %d is not between %d and %d", forced, minBase, maxBase)
+	}
+
+	sign, digits := "", val
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	for prefix, base := range literalBases {
+		if len(digits) < len(prefix) || !strings.EqualFold(digits[:len(prefix)], prefix) {
+			continue
+		}
+
+		if forced == 0 || forced == base {
+			return sign + digits[len(prefix):], base, nil
+		}
+	}
+
+	if forced == 0 {
+		forced = baseParseInt
+	}
+
+	return val, forced, nil
+}
+
+// integerError returns an error naming the invalid integer and its base.
+func integerError(val string, base int, err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		err = numErr.Err
+	}
+
+	if base == baseParseInt {
+		return fmt.Errorf("invalid integer %q: %w", val, err)
+	}
+
+	return fmt.Errorf("invalid base %d integer %q: %w", base, val, err)
+}
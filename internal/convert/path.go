@@ -0,0 +1,59 @@
+package convert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// IsPath returns true if the field tag marks its values as file paths, with `type:"path"`.
+func IsPath(options tag.MultiTag) bool {
+	kind, _ := options.Get("type")
+
+	return kind == "path"
+}
+
+// ExpandPath expands a leading ~ to the home directory of the user and
+// the environment variables (eg. $HOME) of a path, and makes it absolute.
+func ExpandPath(path string) (string, error) {
+	if path == "" {
+		return path, nil
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expand path: %w", err)
+		}
+
+		path = home + path[1:]
+	}
+
+	abs, err := filepath.Abs(os.ExpandEnv(path))
+	if err != nil {
+		return "", fmt.Errorf("expand path: %w", err)
+	}
+
+	return abs, nil
+}
+
+// Extensions returns the file extensions allowed for a path field, with
+// their leading dot, from its comma-separated `ext:"yaml,yml"` tag.
+func Extensions(options tag.MultiTag) []string {
+	list, _ := options.Get("ext")
+
+	var exts []string
+
+	for _, ext := range strings.Split(list, ",") {
+		if ext = strings.TrimSpace(ext); ext == "" {
+			continue
+		}
+
+		exts = append(exts, "."+strings.TrimPrefix(ext, "."))
+	}
+
+	return exts
+}
@@ -0,0 +1,65 @@
+package convert
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ParserFunc parses a command-line string into a value of a given type.
+type ParserFunc func(val string) (interface{}, error)
+
+var (
+	parsers   = map[reflect.Type]ParserFunc{}
+	parsersMu sync.RWMutex
+)
+
+// RegisterParser registers a parser for a type, overwriting any existing one.
+func RegisterParser(typ reflect.Type, parser ParserFunc) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	parsers[typ] = parser
+}
+
+// HasParser returns true if a parser is registered for the type.
+func HasParser(typ reflect.Type) bool {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+
+	_, found := parsers[typ]
+
+	return found
+}
+
+// Registered converts a string with the parser registered for the type of
+// retval, if any, and sets the result on it. Returns false if no parser is found.
+func Registered(val string, retval reflect.Value) (bool, error) {
+	parsersMu.RLock()
+	parser, found := parsers[retval.Type()]
+	parsersMu.RUnlock()
+
+	if !found {
+		return false, nil
+	}
+
+	parsed, err := parser(val)
+	if err != nil {
+		return true, err
+	}
+
+	result := reflect.ValueOf(parsed)
+
+	// Parsers might return pointers to the values.
+	if result.IsValid() && result.Kind() == reflect.Ptr && !result.Type().AssignableTo(retval.Type()) {
+		result = reflect.Indirect(result)
+	}
+
+	if !result.IsValid() || !result.Type().AssignableTo(retval.Type()) {
+		return true, fmt.Errorf("parser for %s returned a value of type %T", retval.Type(), parsed)
+	}
+
+	retval.Set(result)
+
+	return true, nil
+}
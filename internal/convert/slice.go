@@ -0,0 +1,43 @@
+package convert
+
+import (
+	"strings"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// noSeparator is the `sep` tag value of slices whose values are never split.
+const noSeparator = "none"
+
+// Separator returns the separator of the values of a slice field, from its
+// `sep` tag or def if not set, and false if the values are never split.
+func Separator(options tag.MultiTag, def string) (string, bool) {
+	sep, isSet := options.Get("sep")
+	if !isSet {
+		sep = def
+	}
+
+	if sep == "" || sep == noSeparator {
+		return "", false
+	}
+
+	return sep, true
+}
+
+// Split splits the value of a slice field on its separator (see Separator).
+func Split(val string, options tag.MultiTag, def string) []string {
+	sep, splits := Separator(options, def)
+	if !splits {
+		return []string{val}
+	}
+
+	return strings.Split(val, sep)
+}
+
+// Accumulates returns false if the values of a slice field replace its previous
+// ones, instead of being appended to them, with `accumulate:"false"`.
+func Accumulates(options tag.MultiTag) bool {
+	accumulate, isSet := options.Get("accumulate")
+
+	return !isSet || (accumulate != "false" && accumulate != "no" && accumulate != "0")
+}
@@ -0,0 +1,57 @@
+package convert
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// StdinPlaceholder returns the word (usually "-") standing for the
+// standard input in the values of a field, from its `stdin:"-"` tag.
+func StdinPlaceholder(options tag.MultiTag) (string, bool) {
+	placeholder, _ := options.Get("stdin")
+
+	return placeholder, placeholder != ""
+}
+
+// Stdin reads all of in, as the values given to a field with its stdin placeholder.
+// []byte fields are directly set to the input, and no values are returned. Other
+// slices are given one value per line, and other fields the whole input, without
+// its final newline.
+func Stdin(in io.Reader, field reflect.Value) ([]string, error) {
+	input, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+
+	target := reflect.Indirect(field)
+	if target.Kind() == reflect.Ptr && target.IsNil() {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	if target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.Uint8 {
+		target.SetBytes(input)
+
+		return nil, nil
+	}
+
+	text := strings.TrimSuffix(strings.TrimSuffix(string(input), "\n"), "\r")
+	if target.Kind() != reflect.Slice {
+		return []string{text}, nil
+	}
+
+	if text == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,33 @@
+// Package platform gives access to the process running the command trees,
+// which might have no arguments at all, as on js/wasm or wasip1 runtimes
+// embedding them in a browser or a serverless function.
+package platform
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultProgram is the name of programs started without any argument.
+const DefaultProgram = "app"
+
+// Program returns the path of the running program (os.Args[0]),
+// or DefaultProgram when the runtime does not give it.
+func Program() string {
+	if len(os.Args) == 0 || os.Args[0] == "" {
+		return DefaultProgram
+	}
+
+	return os.Args[0]
+}
+
+// ProgramName returns the name of the running program, without its directory.
+func ProgramName() string {
+	return filepath.Base(Program())
+}
+
+// HasArgs returns true if the runtime gave arguments to the program,
+// its path being the first one: libraries like cobra index them.
+func HasArgs() bool {
+	return len(os.Args) > 0
+}
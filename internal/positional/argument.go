@@ -3,12 +3,15 @@ package positional
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 	"strings"
 	"sync"
 
 	"github.com/octago/sflags/internal/convert"
 	"github.com/octago/sflags/internal/tag"
+	"github.com/octago/sflags/internal/validation"
 )
 
 // ErrRequired signals an argument field has not been
@@ -29,6 +32,26 @@ func WithWordConsumer(args *Args, consumer WordConsumer) *Args {
 	return args
 }
 
+// WordValidator is a function called on each word about to be parsed onto
+// a positional slot, and before it is converted onto the slot struct field.
+type WordValidator func(arg *Arg, word string) error
+
+// WithWordValidator allows to set a custom function validating
+// each word before it is parsed onto its positional slot.
+func WithWordValidator(args *Args, validator WordValidator) *Args {
+	args.validator = validator
+
+	return args
+}
+
+// WithStdin sets the reader from which the values given as the stdin
+// placeholder of a positional slot (`stdin:"-"`) are read, instead of os.Stdin.
+func WithStdin(args *Args, in io.Reader) *Args {
+	args.stdin = in
+
+	return args
+}
+
 // Arg is a type used to store information and value references to
 // a struct field we use as positional arg. This type is passed in
 // many places, so that we can parse/convert and make informed
@@ -42,6 +65,8 @@ type Arg struct {
 	StartMax int           // if previous positional slots are full, this replaces startAt
 	Tag      tag.MultiTag  // struct tag
 	Value    reflect.Value // A reference to the field value itself
+
+	def reflect.Value // A copy of the field value when scanned, with its defaults
 }
 
 // Args contains an entire list of positional argument "slots" (struct fields)
@@ -69,6 +94,16 @@ type Args struct {
 	// This consumer is called for each positional slot, either
 	// sequentially (normal parsing) or concurrently (useful for completions)
 	consumer WordConsumer
+
+	// An optional validator run on each word before conversion.
+	validator WordValidator
+
+	// The reader of values given as the stdin placeholder (os.Stdin if nil).
+	stdin io.Reader
+
+	// Words which could not be set onto their fields, collected
+	// so that all invalid arguments are reported at once.
+	errs convert.Errors
 }
 
 // Parse acceps a list of command-line words to be ALL parsed as positional
@@ -76,8 +111,16 @@ type Args struct {
 // positional struct field (following quantity constraints/requirements), and
 // will return the list of words that have not been parsed into a field, along
 // with an error if one/more positionals has failed to satisfy their requirements.
+// Words which cannot be set onto their fields do not stop the parsing: all of
+// their errors are returned, as a convert.Errors if there are more than one.
 func (args *Args) Parse(words []string) (retargs []string, err error) {
 	args.setWords(words) // Ensures initializing the counters
+	args.errs = nil
+
+	// Fields not given words keep their defaults, not those of previous parsings.
+	for _, arg := range args.slots {
+		arg.Value.Set(cloneValue(arg.def))
+	}
 
 	// Always set the return arguments when exiting.
 	// This is used by command callers needing them
@@ -96,20 +139,22 @@ func (args *Args) Parse(words []string) (retargs []string, err error) {
 
 		// Either the positional argument has had not enough words
 		if errors.Is(err, ErrRequired) {
-			return retargs, args.positionalRequiredErr(*arg)
+			return retargs, append(args.errs, args.positionalRequiredErr(*arg)).Err()
 		}
 
-		// Or we have failed to parse the word onto the struct field
-		// value, most probably because it's the wrong type.
-		// if errors.Is(err, convert.Err) {
-		//
-		// }
+		if err != nil {
+			return retargs, append(args.errs, err).Err()
+		}
 	}
 
 	// Finally, if we have some return arguments, we verify that
 	// that the last positional was not a list with a maximum specified:
 	// This is to keep retrocompatibility with go-flags. Should be moved.
-	return retargs, args.checkRequirementsFinal()
+	if err := args.checkRequirementsFinal(); err != nil {
+		args.errs = append(args.errs, err)
+	}
+
+	return retargs, args.errs.Err()
 }
 
 // Positionals returns the list of "slots" that have been
@@ -162,6 +207,8 @@ func (args *Args) copyArgs() *Args {
 		done:        0,
 		parsed:      0,
 		consumer:    args.consumer,
+		validator:   args.validator,
+		stdin:       args.stdin,
 	}
 }
 
@@ -186,10 +233,29 @@ func (args *Args) consumeWords(self *Args, arg *Arg) (err error) {
 		// of arguments, we are cleared to consume one.
 		next := args.Pop()
 
-		if err := convert.Value(next, arg.Value, arg.Tag); err != nil {
-			// Any conversion error is fatal: TODO maybe handle errors
-			return err
-		} else if arg.Value.Type().Kind() != reflect.Slice {
+		// Slices might replace their default values, instead of appending to them,
+		// and always replace the ones declared in their `default` tags.
+		if self.parsed == 1 && arg.Value.Kind() == reflect.Slice && (!convert.Accumulates(arg.Tag) || hasDefault(arg.Tag)) {
+			arg.Value.Set(reflect.Zero(arg.Value.Type()))
+		}
+
+		// The word might stand for one or more values (file contents, stdin).
+		words, err := self.expandWord(arg, next)
+		if err != nil {
+			self.errs = append(self.errs, fmt.Errorf("invalid argument for `%s`: %w", arg.Name, err))
+		}
+
+		// We might fail to parse the word onto the struct field value, most
+		// probably because it's the wrong type, or because the value has been
+		// refused by one of the field validators: the error is recorded, and
+		// the next words are parsed anyway.
+		for _, word := range words {
+			if err := self.setWord(arg, word); err != nil {
+				self.errs = append(self.errs, err)
+			}
+		}
+
+		if arg.Value.Type().Kind() != reflect.Slice {
 			// And individual fields only ever need to parse one word.
 			return nil
 		}
@@ -207,6 +273,74 @@ func (args *Args) consumeWords(self *Args, arg *Arg) (err error) {
 	return nil
 }
 
+// expandWord returns the values given by a word: the contents of a file
+// for words of the form @path (see convert.ExpandFile), the input read from
+// stdin for the stdin placeholder of the field (see convert.Stdin), or the
+// word itself.
+func (args *Args) expandWord(arg *Arg, word string) ([]string, error) {
+	if placeholder, ok := convert.StdinPlaceholder(arg.Tag); ok && word == placeholder {
+		return convert.Stdin(args.input(), arg.Value)
+	}
+
+	expanded, err := convert.ExpandFile(word, arg.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{expanded}, nil
+}
+
+// setWord checks a word against the choices and validators
+// of a positional slot, and converts it onto its struct field.
+func (args *Args) setWord(arg *Arg, next string) error {
+	// The word must be one of the allowed choices, if any.
+	if err := validation.CheckChoice(next, validation.ParseChoices(arg.Tag)); err != nil {
+		return fmt.Errorf("invalid argument for `%s`: %w", arg.Name, err)
+	}
+
+	// Or of the ones only known at runtime.
+	if provider, ok := validation.Provider(elemValue(arg.Value)); ok {
+		if err := validation.CheckChoice(next, validation.ChoiceValues(provider.Choices())); err != nil {
+			return fmt.Errorf("invalid argument for `%s`: %w", arg.Name, err)
+		}
+	}
+
+	// Or be accepted by any custom validator.
+	if args.validator != nil {
+		if err := args.validator(arg, next); err != nil {
+			return fmt.Errorf("invalid argument for `%s`: %w", arg.Name, err)
+		}
+	}
+
+	if err := convert.Value(next, arg.Value, arg.Tag); err != nil {
+		var convErr *convert.ConvertError
+		if errors.As(err, &convErr) {
+			convErr.Field = arg.Name
+		}
+
+		// Any conversion error is fatal: TODO maybe handle errors
+		return fmt.Errorf("invalid argument for `%s`: %w", arg.Name, err)
+	}
+
+	// Run any validators specified in the field tag.
+	if rules, _ := arg.Tag.Get("validate"); rules != "" {
+		if err := validation.Check(arg.Value, rules); err != nil {
+			return fmt.Errorf("invalid argument for `%s`: %w", arg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// input returns the reader from which values given as the stdin placeholder are read.
+func (args *Args) input() io.Reader {
+	if args.stdin == nil {
+		return os.Stdin
+	}
+
+	return args.stdin
+}
+
 //
 // Error check/build/format code ----------------------------------------------------------------------
 //
@@ -387,3 +521,40 @@ func (args *Args) Pop() string {
 
 	return arg
 }
+
+// elemValue returns a value of the element type of slices,
+// so that its methods can be called, or the value itself.
+func elemValue(val reflect.Value) reflect.Value {
+	if val.Kind() == reflect.Slice {
+		return reflect.New(val.Type().Elem()).Elem()
+	}
+
+	return val
+}
+
+// hasDefault returns true if the field has default values declared in its tags.
+func hasDefault(options tag.MultiTag) bool {
+	_, isSet := options.Get("default")
+
+	return isSet
+}
+
+// cloneValue returns a copy of a value not sharing its slice or map elements.
+func cloneValue(val reflect.Value) reflect.Value {
+	clone := reflect.New(val.Type()).Elem()
+
+	switch {
+	case val.Kind() == reflect.Slice && !val.IsNil():
+		clone.Set(reflect.AppendSlice(reflect.MakeSlice(val.Type(), 0, val.Len()), val))
+	case val.Kind() == reflect.Map && !val.IsNil():
+		clone.Set(reflect.MakeMapWithSize(val.Type(), val.Len()))
+
+		for iter := val.MapRange(); iter.Next(); {
+			clone.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		clone.Set(val)
+	}
+
+	return clone
+}
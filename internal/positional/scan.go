@@ -1,10 +1,12 @@
 package positional
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
 
+	"github.com/octago/sflags/internal/convert"
 	"github.com/octago/sflags/internal/tag"
 )
 
@@ -26,6 +28,11 @@ func ScanArgs(val reflect.Value, stag tag.MultiTag) (args *Args, err error) {
 		field := stype.Field(fieldCount)
 		fieldValue := val.Field(fieldCount)
 
+		// Some types are never arguments, even untagged.
+		if tag.Ignored(field.Type) {
+			continue
+		}
+
 		ptag, name, err := parsePositionalTag(field)
 		if err != nil {
 			return nil, err
@@ -35,6 +42,11 @@ func ScanArgs(val reflect.Value, stag tag.MultiTag) (args *Args, err error) {
 			args.noTags = false
 		}
 
+		// Defaults declared in tags are set, until words are given.
+		if err := convert.Default(fieldValue, ptag); err != nil {
+			return nil, fmt.Errorf("invalid default value for `%s`: %w", name, err)
+		}
+
 		// Set min/max requirements depending on the tag, the overall
 		// requirement settings (at struct level), also taking into
 		// account the kind of field we are considering (slice or not)
@@ -49,6 +61,7 @@ func ScanArgs(val reflect.Value, stag tag.MultiTag) (args *Args, err error) {
 			StartMin: args.totalMin,
 			StartMax: args.totalMax,
 			Value:    fieldValue,
+			def:      cloneValue(fieldValue),
 		}
 
 		args.slots = append(args.slots, arg)
@@ -0,0 +1,72 @@
+package scan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PanicError is a panic raised while scanning a struct, like when setting an
+// unexported embedded struct, recovered with the path of the field scanned.
+type PanicError struct {
+	Type  string      // The type of the scanned struct.
+	Path  []string    // The names of the fields, from the struct to the field.
+	Value interface{} // The value of the panic.
+}
+
+func (e *PanicError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("cannot scan %s: %v", e.Type, e.Value)
+	}
+
+	return fmt.Sprintf("cannot scan field %s of %s: %v", strings.Join(e.Path, "."), e.Type, e.Value)
+}
+
+// Unwrap returns the value of the panic, if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+
+	return err
+}
+
+// Recover must be deferred when scanning a field: it panics again with
+// a PanicError including the field name, to be turned into an error by Catch.
+func Recover(field string) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	perr, isPanic := value.(*PanicError)
+	if !isPanic {
+		perr = &PanicError{Value: value}
+	}
+
+	perr.Path = append([]string{field}, perr.Path...)
+
+	panic(perr)
+}
+
+// Catch must be deferred when scanning a struct: it turns
+// a panic into a PanicError, assigned to err, naming the struct type.
+func Catch(data interface{}, err *error) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	perr, isPanic := value.(*PanicError)
+	if !isPanic {
+		perr = &PanicError{Value: value}
+	}
+
+	if typ := reflect.TypeOf(data); typ != nil {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+
+		perr.Type = typ.String()
+	}
+
+	*err = perr
+}
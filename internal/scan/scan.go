@@ -16,7 +16,10 @@ var ErrNotPointerToStruct = errors.New("object must be a pointer to struct or in
 type Handler func(reflect.Value, *reflect.StructField) (bool, error)
 
 // Type actually scans the type, recursively if needed.
-func Type(data interface{}, handler Handler) error {
+// Panics raised while scanning are returned as a PanicError.
+func Type(data interface{}, handler Handler) (err error) {
+	defer Catch(data, &err)
+
 	// Get all the public fields in the data struct
 	ptrval := reflect.ValueOf(data)
 
@@ -76,6 +79,8 @@ func scanStruct(val reflect.Value, sfield *reflect.StructField, scan Handler) er
 // either scans recursively if the field is an embedded struct/pointer, or attempts to scan
 // the field as an option of the group. TODO: simplify.
 func scanField(val reflect.Value, field reflect.StructField, scan Handler) error {
+	defer Recover(field.Name)
+
 	// Get the field tag and return/continue if failed/needed
 	_, skip, err := tag.GetFieldTag(field)
 	if err != nil {
@@ -0,0 +1,44 @@
+package tag
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+var (
+	ignored = map[reflect.Type]bool{
+		reflect.TypeOf(sync.Mutex{}):                   true,
+		reflect.TypeOf(sync.RWMutex{}):                 true,
+		reflect.TypeOf(sync.WaitGroup{}):               true,
+		reflect.TypeOf(sync.Once{}):                    true,
+		reflect.TypeOf((*context.Context)(nil)).Elem(): true,
+	}
+	ignoredMu sync.RWMutex
+)
+
+// Ignore registers a type whose struct fields are never scanned, whatever their tags.
+func Ignore(typ reflect.Type) {
+	ignoredMu.Lock()
+	defer ignoredMu.Unlock()
+
+	ignored[typ] = true
+}
+
+// Ignored returns true if a type, or the type it points to, is ignored.
+func Ignored(typ reflect.Type) bool {
+	ignoredMu.RLock()
+	defer ignoredMu.RUnlock()
+
+	for {
+		if ignored[typ] {
+			return true
+		}
+
+		if typ.Kind() != reflect.Ptr {
+			return false
+		}
+
+		typ = typ.Elem()
+	}
+}
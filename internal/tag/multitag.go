@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -45,6 +46,11 @@ func GetFieldTag(field reflect.StructField) (MultiTag, bool, error) {
 		return MultiTag{}, true, nil
 	}
 
+	// Some types are never scanned, like locks and contexts.
+	if Ignored(field.Type) {
+		return MultiTag{}, true, nil
+	}
+
 	// If the field tag is empty, there is no tag
 	if field.Tag == "" {
 		return MultiTag{}, true, nil
@@ -233,3 +239,52 @@ func (x *MultiTag) cached() map[string][]string {
 
 	return x.cache
 }
+
+// Deprecation returns whether a field is marked deprecated with a `deprecated` tag,
+// and the deprecation message, if any (eg. `deprecated:"use --new-flag instead"`).
+// Truthy values like `deprecated:"true"` mark the field deprecated without message.
+func Deprecation(mtag MultiTag) (msg string, deprecated bool) {
+	value, isSet := mtag.Get("deprecated")
+
+	switch strings.ToLower(value) {
+	case "", "false", "no", "0":
+		return "", false
+	case "true", "yes", "1":
+		return "", isSet
+	default:
+		return value, isSet
+	}
+}
+
+// Annotations returns the metadata declared on a field, with `annotation:"key:value"`
+// tags (possibly repeated) and comma-separated `annotations:"key:value,key2:value2"`
+// ones. Keys without values (`annotation:"beta"`) are set to "true".
+func Annotations(mtag MultiTag) map[string]string {
+	var pairs []string
+
+	pairs = append(pairs, mtag.GetMany("annotation")...)
+	for _, list := range mtag.GetMany("annotations") {
+		pairs = append(pairs, strings.Split(list, ",")...)
+	}
+
+	annotations := map[string]string{}
+
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if key == "" {
+			continue
+		}
+
+		if !found {
+			value = "true"
+		}
+
+		annotations[key] = value
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return annotations
+}
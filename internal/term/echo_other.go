@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !js && !wasip1
+
+package term
+
+func disableEcho(fd int) (func(), error) {
+	return nil, ErrEchoNotSupported
+}
@@ -0,0 +1,13 @@
+//go:build js || wasip1
+
+package term
+
+import "errors"
+
+// errNoTerminal is returned on runtimes without terminals, like browsers
+// and WASI hosts, whose inputs are read as is, like pipes.
+var errNoTerminal = errors.New("terminal echo cannot be controlled by this runtime")
+
+func disableEcho(fd int) (func(), error) {
+	return nil, errNoTerminal
+}
@@ -0,0 +1,64 @@
+package term
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// urlPattern matches the http(s) URLs found in text.
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+// Hyperlink returns text as an OSC-8 terminal hyperlink pointing to url.
+func Hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// Linkify turns all the URLs found in text into terminal hyperlinks.
+// Trailing punctuation, like the period ending a sentence, is not linked.
+func Linkify(text string) string {
+	return urlPattern.ReplaceAllStringFunc(text, func(url string) string {
+		trimmed := strings.TrimRight(url, ".,;:!?)]}")
+
+		return Hyperlink(trimmed, trimmed) + url[len(trimmed):]
+	})
+}
+
+// SupportsHyperlinks returns true if out is a terminal which can render hyperlinks.
+// FORCE_HYPERLINK=1 enables them on any output, and FORCE_HYPERLINK=0 disables them.
+func SupportsHyperlinks(out io.Writer) bool {
+	if force := os.Getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0"
+	}
+
+	file, isFile := out.(*os.File)
+	if !isFile || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	_, err := height(int(file.Fd()))
+
+	return err == nil
+}
+
+// SupportsColor returns true if out is a terminal which can render colors.
+// NO_COLOR disables them on any output, and FORCE_COLOR enables them.
+func SupportsColor(out io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if force := os.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0"
+	}
+
+	file, isFile := out.(*os.File)
+	if !isFile || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	_, err := height(int(file.Fd()))
+
+	return err == nil
+}
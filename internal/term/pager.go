@@ -0,0 +1,63 @@
+package term
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is used when $PAGER is not set, like git does.
+const defaultPager = "less"
+
+// Page writes text to out, through the pager of the user ($PAGER, or less)
+// when out is a terminal and the text is longer than its height. The text
+// is written as is when the pager cannot be run, or is set to "cat".
+func Page(out io.Writer, text string) error {
+	file, isFile := out.(*os.File)
+	if !isFile {
+		_, err := io.WriteString(out, text)
+
+		return err
+	}
+
+	rows, err := height(int(file.Fd()))
+	if err != nil || rows == 0 || strings.Count(text, "\n") < rows {
+		_, err = io.WriteString(out, text)
+
+		return err
+	}
+
+	pager := strings.TrimSpace(os.Getenv("PAGER"))
+	if pager == "" {
+		pager = defaultPager
+	}
+
+	if pager == "cat" || runPager(pager, file, text) != nil {
+		_, err = io.WriteString(out, text)
+
+		return err
+	}
+
+	return nil
+}
+
+// runPager pipes text to the pager command, which writes on out.
+func runPager(pager string, out *os.File, text string) error {
+	cmd := exec.Command("sh", "-c", pager)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+
+	// Like git, quit less when the text fits in the screen, keep colors and don't clear the screen.
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pager %s: %w", pager, err)
+	}
+
+	return nil
+}
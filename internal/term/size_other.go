@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package term
+
+func height(fd int) (int, error) {
+	return 0, ErrNotSupported
+}
@@ -0,0 +1,70 @@
+// Package term reads secrets from the terminal, with echo disabled,
+// pages long outputs through the pager of the user, and renders hyperlinks.
+package term
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	// ErrEchoNotSupported is returned when the terminal echo
+	// cannot be disabled on the current platform.
+	ErrEchoNotSupported = errors.New("disabling terminal echo is not supported on this platform")
+
+	// ErrNotSupported is returned when the terminal
+	// cannot be queried on the current platform.
+	ErrNotSupported = errors.New("terminal not supported on this platform")
+)
+
+// ReadSecret prints the prompt on out, and reads a line from in with echo disabled.
+// If in is not a terminal (eg. a pipe), the line is read as is, without prompting.
+func ReadSecret(in *os.File, out io.Writer, prompt string) (string, error) {
+	restore, err := disableEcho(int(in.Fd()))
+
+	switch {
+	case err == nil:
+		defer func() {
+			restore()
+			fmt.Fprintln(out)
+		}()
+
+		fmt.Fprint(out, prompt)
+	case errors.Is(err, ErrEchoNotSupported):
+		return "", err
+	}
+
+	return ReadLine(in)
+}
+
+// ReadLine reads a single line, one byte at a time so as
+// not to consume any input past the line from the reader.
+func ReadLine(in io.Reader) (string, error) {
+	var line []byte
+
+	buf := make([]byte, 1)
+
+	for {
+		n, err := in.Read(buf)
+		if n > 0 && buf[0] == '\n' {
+			break
+		}
+
+		if n > 0 {
+			line = append(line, buf[0])
+		}
+
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimRight(string(line), "\r"), nil
+}
@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// ErrInvalidChoice indicates a value which is not part of the allowed choices.
+var ErrInvalidChoice = errors.New("invalid choice")
+
+var (
+	choiceSets   = map[string][]string{}
+	choiceSetsMu sync.RWMutex
+)
+
+// RegisterChoices registers a named set of choices, overwriting any existing one.
+func RegisterChoices(name string, choices []string) {
+	choiceSetsMu.Lock()
+	defer choiceSetsMu.Unlock()
+
+	choiceSets[name] = append([]string{}, choices...)
+}
+
+// lookupChoices returns the set of choices registered under name, if any.
+func lookupChoices(name string) []string {
+	choiceSetsMu.RLock()
+	defer choiceSetsMu.RUnlock()
+
+	return choiceSets[name]
+}
+
+// ParseChoices returns all the allowed values for a field, specified either
+// with one or more `choice:"value"` tags (go-flags style), with a single
+// space-separated `choices:"json yaml toml"` tag, or with a reference to
+// registered sets of choices with a `choices-ref:"regions"` tag.
+func ParseChoices(mtag tag.MultiTag) []string {
+	choices := append([]string{}, mtag.GetMany("choice")...)
+
+	for _, list := range mtag.GetMany("choices") {
+		choices = append(choices, strings.Fields(list)...)
+	}
+
+	for _, refs := range mtag.GetMany("choices-ref") {
+		for _, ref := range strings.Fields(refs) {
+			choices = append(choices, lookupChoices(ref)...)
+		}
+	}
+
+	if len(choices) == 0 {
+		return nil
+	}
+
+	return choices
+}
+
+// CheckChoice returns an error listing all valid choices if value is not one of them.
+// An empty list of choices means that any value is allowed.
+func CheckChoice(value string, choices []string) error {
+	if len(choices) == 0 {
+		return nil
+	}
+
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w %q: must be one of %s", ErrInvalidChoice, value, strings.Join(choices, ", "))
+}
+
+// Choice is a value allowed for a field, with an optional description.
+type Choice struct {
+	Value       string
+	Description string
+}
+
+// ChoiceProvider is implemented by types whose allowed values are only known at runtime.
+type ChoiceProvider interface {
+	Choices() []Choice
+}
+
+// Provider returns the ChoiceProvider implemented by a value (or its address),
+// or the one of its type if registered as an enum, if any.
+func Provider(value reflect.Value) (ChoiceProvider, bool) {
+	if !value.IsValid() {
+		return nil, false
+	}
+
+	if value.CanInterface() {
+		if provider, ok := value.Interface().(ChoiceProvider); ok {
+			return provider, true
+		}
+	}
+
+	if value.CanAddr() && value.Addr().CanInterface() {
+		if provider, ok := value.Addr().Interface().(ChoiceProvider); ok {
+			return provider, true
+		}
+	}
+
+	// Types registered as enums provide their registered values.
+	if enum, found := lookupEnum(value.Type()); found {
+		return enum, true
+	}
+
+	return nil, false
+}
+
+// ChoiceValues returns the values of a list of choices.
+func ChoiceValues(choices []Choice) []string {
+	values := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		values = append(values, choice.Value)
+	}
+
+	return values
+}
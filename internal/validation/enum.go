@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"reflect"
+	"sync"
+)
+
+// enum is a type whose allowed values have been registered.
+type enum struct {
+	name    string
+	choices []Choice
+}
+
+// Choices returns the allowed values of the enum, so that it is a ChoiceProvider.
+func (e enum) Choices() []Choice { return e.choices }
+
+var (
+	enums   = map[reflect.Type]enum{}
+	enumsMu sync.RWMutex
+)
+
+// RegisterEnum registers the name and the allowed values of a type, overwriting any
+// existing ones. Values of this type then provide their choices like ChoiceProviders.
+func RegisterEnum(typ reflect.Type, name string, choices []Choice) {
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+
+	enums[typ] = enum{name: name, choices: append([]Choice{}, choices...)}
+}
+
+// EnumName returns the name of a registered enum type, or of the
+// type it points to. Returns false if the type is not an enum.
+func EnumName(typ reflect.Type) (string, bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	enum, found := lookupEnum(typ)
+
+	return enum.name, found
+}
+
+func lookupEnum(typ reflect.Type) (enum, bool) {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+
+	enum, found := enums[typ]
+
+	return enum, found
+}
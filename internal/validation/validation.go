@@ -0,0 +1,275 @@
+// Package validation implements the validators registry used by the
+// `validate` struct tag, on both option flags and positional arguments.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownValidator is returned when a validate tag references a validator
+// that has not been registered.
+var ErrUnknownValidator = errors.New("unknown validator")
+
+// Func validates a value once it has been converted onto its struct field.
+// The param is the parameter of the validator in the tag (eg. "1" in "min=1").
+type Func func(value reflect.Value, param string) error
+
+var (
+	validators = map[string]Func{
+		"min":     minimum,
+		"max":     maximum,
+		"len":     length,
+		"regexp":  matches,
+		"oneof":   oneOf,
+		"nonzero": nonZero,
+	}
+	validatorsMu sync.RWMutex
+)
+
+// Register registers a named validator, overwriting any existing one.
+func Register(name string, fn Func) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	validators[name] = fn
+}
+
+// lookup returns the validator registered under name, if any.
+func lookup(name string) (Func, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+
+	fn, found := validators[name]
+
+	return fn, found
+}
+
+// rule is a single validator invocation parsed from a tag.
+type rule struct {
+	name  string
+	param string
+	text  string
+}
+
+// parseRules splits a validate tag into its rules: any comma-separated
+// part not starting with a registered validator name is considered as
+// being part of the previous rule parameter (eg. in regular expressions).
+func parseRules(spec string) []rule {
+	var rules []rule
+
+	for _, part := range strings.Split(spec, ",") {
+		name, param := part, ""
+		if idx := strings.Index(part, "="); idx != -1 {
+			name, param = part[:idx], part[idx+1:]
+		}
+
+		name = strings.TrimSpace(name)
+
+		if _, found := lookup(name); !found && len(rules) > 0 {
+			rules[len(rules)-1].param += "," + part
+			rules[len(rules)-1].text += "," + part
+
+			continue
+		}
+
+		rules = append(rules, rule{name: name, param: param, text: part})
+	}
+
+	return rules
+}
+
+// Rules splits a validate tag into its rules, as written in the tag (eg.
+// "min=1" or "regexp=^a{1,3}$"), the same way they are parsed to be run.
+func Rules(spec string) []string {
+	rules := parseRules(spec)
+	texts := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		texts = append(texts, rule.text)
+	}
+
+	return texts
+}
+
+// Check runs all the validators specified in a validate tag against a value.
+// Pointers are dereferenced, and each element of a slice is validated in turn.
+func Check(value reflect.Value, spec string) error {
+	if spec == "" {
+		return nil
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		for i := 0; i < value.Len(); i++ {
+			if err := Check(value.Index(i), spec); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, rule := range parseRules(spec) {
+		fn, found := lookup(rule.name)
+		if !found {
+			return fmt.Errorf("%w: %q", ErrUnknownValidator, rule.name)
+		}
+
+		if err := fn(value, rule.param); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// Builtin validators ------------------------------------------------------------ //
+//
+
+// minimum checks a number is not lower than, or a string not shorter than, param.
+func minimum(value reflect.Value, param string) error {
+	cmp, err := compare(value, param)
+	if err != nil {
+		return fmt.Errorf("min: %w", err)
+	}
+
+	if cmp < 0 {
+		if value.Kind() == reflect.String {
+			return fmt.Errorf("%q must be at least %s characters long", value.String(), param)
+		}
+
+		return fmt.Errorf("%v must be greater than or equal to %s", value.Interface(), param)
+	}
+
+	return nil
+}
+
+// maximum checks a number is not greater than, or a string not longer than, param.
+func maximum(value reflect.Value, param string) error {
+	cmp, err := compare(value, param)
+	if err != nil {
+		return fmt.Errorf("max: %w", err)
+	}
+
+	if cmp > 0 {
+		if value.Kind() == reflect.String {
+			return fmt.Errorf("%q must be at most %s characters long", value.String(), param)
+		}
+
+		return fmt.Errorf("%v must be lower than or equal to %s", value.Interface(), param)
+	}
+
+	return nil
+}
+
+// length checks a string has exactly param characters.
+func length(value reflect.Value, param string) error {
+	want, err := strconv.Atoi(param)
+	if err != nil {
+		return fmt.Errorf("len: %w", err)
+	}
+
+	if got := len([]rune(toString(value))); got != want {
+		return fmt.Errorf("%q must be %d characters long", toString(value), want)
+	}
+
+	return nil
+}
+
+// matches checks the value string representation matches a regular expression.
+func matches(value reflect.Value, param string) error {
+	expr, err := regexp.Compile(param)
+	if err != nil {
+		return fmt.Errorf("regexp: %w", err)
+	}
+
+	if !expr.MatchString(toString(value)) {
+		return fmt.Errorf("%q does not match %s", toString(value), param)
+	}
+
+	return nil
+}
+
+// oneOf checks the value string representation is one of a space-separated list.
+func oneOf(value reflect.Value, param string) error {
+	choices := strings.Fields(param)
+
+	for _, choice := range choices {
+		if toString(value) == choice {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%q must be one of %s", toString(value), strings.Join(choices, ", "))
+}
+
+// nonZero checks the value is not its type zero value.
+func nonZero(value reflect.Value, _ string) error {
+	if value.IsZero() {
+		return errors.New("value cannot be empty or zero")
+	}
+
+	return nil
+}
+
+// compare returns -1, 0 or 1 if the value is lower, equal or greater than
+// param. Strings are compared by their length.
+func compare(value reflect.Value, param string) (int, error) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		limit, err := strconv.ParseInt(param, 0, 64)
+
+		return compareNum(value.Int(), limit), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		limit, err := strconv.ParseUint(param, 0, 64)
+
+		return compareNum(value.Uint(), limit), err
+	case reflect.Float32, reflect.Float64:
+		limit, err := strconv.ParseFloat(param, 64)
+
+		return compareNum(value.Float(), limit), err
+	case reflect.String:
+		limit, err := strconv.Atoi(param)
+
+		return compareNum(len([]rune(value.String())), limit), err
+	default:
+		return 0, fmt.Errorf("cannot compare values of type %s", value.Type())
+	}
+}
+
+func compareNum[T int | int64 | uint64 | float64](val, limit T) int {
+	switch {
+	case val < limit:
+		return -1
+	case val > limit:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toString(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return value.String()
+	}
+
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return fmt.Sprint(value.Interface())
+}
@@ -0,0 +1,78 @@
+package sflags
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Resolver resolves the value of a flag by name, through the scopes of a command
+// built by some generator: its own flags, the ones of its option groups, and the
+// persistent flags of its parents. Generators register the resolver for their
+// command type with RegisterResolver, like gcobra does for *cobra.Command.
+type Resolver func(cmd interface{}, name string) (Value, bool)
+
+var (
+	resolvers   []Resolver
+	resolversMu sync.RWMutex
+)
+
+// RegisterResolver registers a resolver of flags for some type of command.
+func RegisterResolver(resolver Resolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	resolvers = append(resolvers, resolver)
+}
+
+// Lookup resolves a flag by name from a command, through its scopes (see Resolver),
+// and returns the flag and the current value of the struct field it is bound to.
+// This is meant for code which only has the command at hand, like middlewares.
+// Hidden aliases and --no-<flag> forms resolve to the flag they belong to.
+// Returns false if the flag is not found, or has not been parsed by sflags.
+func Lookup(cmd interface{}, name string) (*Flag, interface{}, bool) {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+
+	for _, resolve := range resolvers {
+		value, found := resolve(cmd, name)
+		if !found {
+			continue
+		}
+
+		if changed, ok := parsedValue(value); ok {
+			return changed.flag, changed.field.Interface(), true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// Field returns the flag generated by sflags for a value, and the struct field it
+// sets, which can be read and set as a whole, eg. to save and restore its value.
+// Returns false if the value has not been generated by sflags.
+func Field(val Value) (*Flag, reflect.Value, bool) {
+	changed, ok := parsedValue(val)
+	if !ok {
+		return nil, reflect.Value{}, false
+	}
+
+	return changed.flag, changed.field, true
+}
+
+// parsedValue returns the value tracking the struct field of a flag, if any.
+func parsedValue(val Value) (*changedValue, bool) {
+	for {
+		switch value := val.(type) {
+		case *changedValue:
+			return value, true
+		case *boolFlagValue:
+			val = value.Value
+		case *profiledValue:
+			val = value.Value
+		case *negatedValue:
+			val = value.Value
+		default:
+			return nil, false
+		}
+	}
+}
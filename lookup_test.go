@@ -0,0 +1,89 @@
+package sflags
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lookupFlags is a command type resolving flags from a list.
+type lookupFlags []*Flag
+
+func init() {
+	RegisterResolver(func(cmd interface{}, name string) (Value, bool) {
+		flags, ok := cmd.(lookupFlags)
+		if !ok {
+			return nil, false
+		}
+
+		for _, flag := range flags {
+			if flag.Name == name {
+				return flag.Value, true
+			}
+		}
+
+		return nil, false
+	})
+}
+
+func TestLookup(t *testing.T) {
+	cfg := &struct {
+		Port    int      `long:"port" alias:"listen-port"`
+		Verbose bool     `long:"verbose" negatable:"true"`
+		Hosts   []string `long:"hosts"`
+	}{Port: 8080}
+
+	flags, err := ParseStruct(cfg, Profiles(map[string]interface{}{
+		"dev": map[string]string{"port": "3000"},
+	}))
+	require.NoError(t, err)
+
+	cmd := lookupFlags(flags)
+
+	flag, value, found := Lookup(cmd, "port")
+	require.True(t, found)
+	assert.Equal(t, "port", flag.Name)
+	assert.Equal(t, 8080, value)
+
+	require.NoError(t, flag.Value.Set("9090"))
+	_, value, _ = Lookup(cmd, "listen-port")
+	assert.Equal(t, 9090, value)
+
+	flag, value, found = Lookup(cmd, "no-verbose")
+	require.True(t, found)
+	assert.Equal(t, "verbose", flag.Name)
+	assert.Equal(t, false, value)
+
+	_, value, _ = Lookup(cmd, "hosts")
+	assert.Equal(t, []string(nil), value)
+
+	_, _, found = Lookup(cmd, "profile")
+	assert.False(t, found, "flags not bound to fields are not resolved")
+
+	_, _, found = Lookup(cmd, "missing")
+	assert.False(t, found)
+
+	_, _, found = Lookup(struct{}{}, "port")
+	assert.False(t, found)
+}
+
+func TestField(t *testing.T) {
+	cfg := &struct {
+		Hosts []string `long:"hosts"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	flag, field, found := Field(flags[0].Value)
+	require.True(t, found)
+	assert.Equal(t, "hosts", flag.Name)
+
+	field.Set(reflect.ValueOf([]string{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+
+	_, _, found = Field(&stringValue{})
+	assert.False(t, found)
+}
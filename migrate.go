@@ -0,0 +1,94 @@
+package sflags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// migration is an obsolete value and the value it has been replaced with.
+type migration struct {
+	obsolete string
+	current  string
+}
+
+// parseMigrations parses the comma-separated oldval=newval pairs
+// of a `migrate` tag. Pairs without an obsolete value are ignored.
+func parseMigrations(spec string) []migration {
+	var migrations []migration
+
+	for _, pair := range strings.Split(spec, ",") {
+		obsolete, current, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || obsolete == "" {
+			continue
+		}
+
+		migrations = append(migrations, migration{obsolete: obsolete, current: current})
+	}
+
+	return migrations
+}
+
+// migratedValue transparently rewrites obsolete values into their current
+// ones before setting them, and reports a deprecation warning when doing so.
+type migratedValue struct {
+	Value
+	name       string
+	isSlice    bool
+	migrations []migration
+	warn       func(Warning)
+}
+
+func (v *migratedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+// Set migrates the value, or each of its comma-separated values for slices.
+func (v *migratedValue) Set(val string) error {
+	if !v.isSlice {
+		return v.Value.Set(v.migrate(val))
+	}
+
+	values := strings.Split(val, ",")
+	for i, value := range values {
+		values[i] = v.migrate(value)
+	}
+
+	return v.Value.Set(strings.Join(values, ","))
+}
+
+// migrate returns the current value of an obsolete one, or the value itself.
+func (v *migratedValue) migrate(val string) string {
+	for _, migration := range v.migrations {
+		if migration.obsolete != val {
+			continue
+		}
+
+		v.warn(Warning{
+			Kind:    WarningMigrated,
+			Flag:    v.name,
+			Message: fmt.Sprintf("value %q for flag %s is deprecated, use %q instead", val, v.name, migration.current),
+		})
+
+		return migration.current
+	}
+
+	return val
+}
+
+// migrateValue wraps a flag value with the migrations of its `migrate` tag.
+func migrateValue(val Value, flag *Flag, field reflect.Value, spec string, opt opts) Value {
+	name := flagName(flag)
+
+	return &migratedValue{
+		Value:      val,
+		name:       name,
+		isSlice:    reflect.Indirect(field).Kind() == reflect.Slice,
+		migrations: parseMigrations(spec),
+		warn:       opt.warner(),
+	}
+}
@@ -0,0 +1,43 @@
+package sflags
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStruct_Migrate(t *testing.T) {
+	cfg := &struct {
+		Format  string   `long:"format" choices:"json yaml" migrate:"yml=yaml,js=json"`
+		Formats []string `long:"formats" migrate:"yml=yaml"`
+		Mode    string   `long:"mode" migrate:"invalid"`
+	}{}
+
+	warnings := &bytes.Buffer{}
+
+	flags, err := ParseStruct(cfg, Warnings(warnings))
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	require.NoError(t, flags[0].Value.Set("yaml"))
+	assert.Equal(t, "yaml", cfg.Format)
+	assert.Empty(t, warnings.String())
+
+	require.NoError(t, flags[0].Value.Set("yml"))
+	assert.Equal(t, "yaml", cfg.Format)
+	assert.Equal(t, "warning: value \"yml\" for flag format is deprecated, use \"yaml\" instead\n", warnings.String())
+
+	assert.Error(t, flags[0].Value.Set("xml"), "migrated values are still checked")
+
+	warnings.Reset()
+	require.NoError(t, flags[1].Value.Set("json,yml"))
+	assert.Equal(t, []string{"json", "yaml"}, cfg.Formats)
+	assert.Contains(t, warnings.String(), `value "yml" for flag formats is deprecated`)
+
+	warnings.Reset()
+	require.NoError(t, flags[2].Value.Set("invalid"))
+	assert.Equal(t, "invalid", cfg.Mode)
+	assert.Empty(t, warnings.String())
+}
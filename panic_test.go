@@ -0,0 +1,29 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panicGroup struct {
+	Port int `long:"port"`
+}
+
+type panicCfg struct {
+	Name        string `long:"name"`
+	*panicGroup `group:"server"`
+}
+
+func TestParseStructPanic(t *testing.T) {
+	// Unexported embedded structs cannot be initialized.
+	_, err := ParseStruct(&panicCfg{})
+	require.Error(t, err)
+
+	var perr *PanicError
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, "sflags.panicCfg", perr.Type)
+	assert.Equal(t, []string{"panicGroup"}, perr.Path)
+	assert.ErrorContains(t, err, "cannot scan field panicGroup of sflags.panicCfg: reflect")
+}
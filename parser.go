@@ -1,10 +1,16 @@
 package sflags
 
 import (
+	"encoding"
 	"fmt"
+	"io"
 	"reflect"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/scan"
 	"github.com/octago/sflags/internal/tag"
 )
 
@@ -39,6 +45,29 @@ type opts struct {
 	flatten     bool
 	validator   ValidateFunc
 	flagFunc    FlagFunc
+	profiles    map[string]interface{}
+	owner       reflect.Type
+	warn        func(Warning)
+	workers     int
+
+	secretPrompt func(flag string) (string, error)
+	stdin        io.Reader
+	errors       *Errors
+
+	// Tags inherited from the groups of options being parsed.
+	inherited map[string]string
+
+	// Title of the group of options being parsed.
+	group string
+
+	// All exported fields must be tagged.
+	requireTags bool
+
+	// Ambiguous clusters of short flags are reported.
+	auditShorts bool
+
+	// How the flag sets of generators print their usage.
+	presentation Presentation
 }
 
 func (o opts) apply(optFuncs ...OptFunc) opts {
@@ -83,6 +112,8 @@ func FlagHandler(val FlagFunc) OptFunc { return func(opt *opts) { opt.flagFunc =
 // Set to false if you don't want anonymous structure fields to be flatten.
 func Flatten(val bool) OptFunc { return func(opt *opts) { opt.flatten = val } }
 
+func owner(val reflect.Type) OptFunc { return func(opt *opts) { opt.owner = val } }
+
 func copyOpts(val opts) OptFunc { return func(opt *opts) { *opt = val } }
 
 func hasOption(options []string, option string) bool {
@@ -106,7 +137,11 @@ func defOpts() opts {
 
 // ParseStruct parses structure and returns list of flags based on this structure.
 // This list of flags can be used by generators for flag, kingpin, cobra, pflag, urfave/cli.
-func ParseStruct(cfg interface{}, optFuncs ...OptFunc) ([]*Flag, error) {
+// Panics raised while scanning the fields, like when setting an unexported embedded
+// struct, are returned as a PanicError naming the field.
+func ParseStruct(cfg interface{}, optFuncs ...OptFunc) (flags []*Flag, err error) {
+	defer scan.Catch(cfg, &err)
+
 	// what we want is Ptr to Structure
 	if cfg == nil {
 		return nil, ErrObjectIsNil
@@ -120,7 +155,28 @@ func ParseStruct(cfg interface{}, optFuncs ...OptFunc) ([]*Flag, error) {
 	}
 	switch e := v.Elem(); e.Kind() {
 	case reflect.Struct:
-		return parseStruct(e, optFuncs...), nil
+		// All fields might have to be explicitly tagged, or excluded.
+		if opt := defOpts().apply(optFuncs...); opt.requireTags {
+			if err := CheckTags(cfg); err != nil {
+				return nil, err
+			}
+		}
+
+		flags = parseStruct(e, optFuncs...)
+
+		// Add the flag selecting a profile of defaults, if any.
+		if opt := defOpts().apply(optFuncs...); len(opt.profiles) > 0 {
+			flags = append(flags, profileFlag(flags, opt))
+		}
+
+		// Short flags which cannot be clustered might be reported.
+		if opt := defOpts().apply(optFuncs...); opt.auditShorts {
+			for _, warning := range ShorthandWarnings(flags) {
+				opt.warner()(warning)
+			}
+		}
+
+		return flags, nil
 	default:
 		return nil, ErrNotPointerToStruct
 	}
@@ -129,6 +185,9 @@ func ParseStruct(cfg interface{}, optFuncs ...OptFunc) ([]*Flag, error) {
 // ParseField parses a single struct field as a list (often only made of only one) flags.
 // This function can be used when you want to scan only some fields for which you want a flag.
 func ParseField(value reflect.Value, field reflect.StructField, optFuncs ...OptFunc) (flags []*Flag, found bool) {
+	// Panics are raised again with the name of the field, for ParseStruct.
+	defer scan.Recover(field.Name)
+
 	opt := defOpts().apply(optFuncs...) // TODO move from here ?
 
 	// skip unexported and non anonymous fields
@@ -142,20 +201,103 @@ func ParseField(value reflect.Value, field reflect.StructField, optFuncs ...OptF
 		return nil, false
 	}
 
+	flag.tag = tag
+
+	// Descriptions might have been generated from field comments.
+	if flag.Usage == "" && opt.owner != nil {
+		flag.Usage, _ = Description(opt.owner, field.Name)
+	}
+
 	flag.EnvName = parseEnvTag(flag.Name, field, opt)
 	prefix := flag.Name + opt.flagDivider
 	if field.Anonymous && opt.flatten {
 		prefix = opt.prefix
 	}
 
+	// Defaults declared in tags are set before being read as the flag default.
+	if err := convert.Default(value, *tag); err != nil {
+		opt.warner()(Warning{
+			Kind:    WarningIgnored,
+			Flag:    flagName(flag),
+			Message: fmt.Sprintf("ignoring invalid default value of flag %s: %s", flagName(flag), err),
+		})
+	}
+
+	// Nested groups of options might have their own namespace
+	// of environment variables, and their fields inherit their tags.
+	nestedOpt := opt.inherit(*tag)
+	if envNamespace, _ := tag.Get("env-namespace"); envNamespace != "" {
+		nestedOpt.envPrefix = envNamespace
+	}
+
 	// We might have to scan for an arbitrarily nested structure of flags
 	nestedFlags, val := parseVal(value,
-		copyOpts(opt),
+		copyOpts(nestedOpt),
 		Prefix(prefix),
 	)
 
+	// Maps might use a custom delimiter between keys and values.
+	if delimiter, isSet := tag.Get("key-value-delimiter"); isSet && delimiter != "" {
+		if mapValue, ok := val.(keyValueDelimiter); ok {
+			mapValue.setKeyValueDelimiter(delimiter)
+		}
+	}
+
+	// Times might be parsed with a custom layout.
+	if layout, isSet := tag.Get("layout"); isSet && layout != "" {
+		if timeValue, ok := val.(timeLayout); ok {
+			timeValue.setTimeLayout(layout)
+		}
+	}
+
+	// Types providing their own choices might be based on basic types.
+	if val == nil && isChoiceProvider(value) {
+		val = parseUnderlying(value)
+	}
+
+	// Enum types are shown with their name instead of their basic type.
+	if val != nil {
+		val = parseEnum(val, value)
+	}
+
+	// Integers might be counted on each occurrence of the flag (eg. -vvv),
+	// or be sizes given with human units (eg. 10MB).
+	switch kind, _ := tag.Get("type"); kind {
+	case "counter":
+		if counter := parseCounter(value); counter != nil {
+			val = counter
+		}
+	case "size":
+		if size := parseSize(value); size != nil {
+			val = size
+		}
+	}
+
+	// Integers might be given and printed in another base than 10.
+	if base, isSet := tag.Get("base"); isSet && base != "" && val != nil {
+		if based := parseBase(value, *tag); based != nil {
+			val = based
+		}
+	}
+
+	// Slices might split their values on another separator than
+	// commas, and replace their previous values when set again.
+	if val != nil {
+		val = parseSlice(val, value, *tag)
+	}
+
+	// Paths are expanded (~, environment variables and relative paths) when set.
+	if val != nil && convert.IsPath(*tag) {
+		val = parsePath(val, value, *tag)
+	}
+
 	// field contains a simple value.
 	if val != nil {
+		isBool := isBoolFlag(val)
+
+		// Words which cannot be converted return a ConvertError.
+		val = &convertedValue{Value: val, name: flagName(flag), typ: value.Type()}
+
 		if opt.validator != nil {
 			val = &validateValue{
 				Value: val,
@@ -164,26 +306,119 @@ func ParseField(value reflect.Value, field reflect.StructField, optFuncs ...OptF
 				},
 			}
 		}
+
+		// Values must be part of the allowed choices, if any.
+		if len(flag.Choices) > 0 {
+			val = &validateValue{
+				Value:        val,
+				validateFunc: choiceValidator(flag.Choices, value, *tag),
+			}
+		}
+
+		// Or be part of the choices only known at runtime.
+		if isChoiceProvider(value) {
+			val = &validateValue{
+				Value:        val,
+				validateFunc: providerValidator(value),
+			}
+		}
+
+		// Validators specified in a `validate` tag run after conversion.
+		if rules, _ := tag.Get("validate"); rules != "" {
+			val = &validatedValue{Value: val, field: value, rules: rules}
+		}
+
+		// Obsolete values are migrated before being checked and set.
+		if migrations, _ := tag.Get("migrate"); migrations != "" {
+			val = migrateValue(val, flag, value, migrations, opt)
+		}
+
+		// Setting deprecated flags is reported to the warning handler, if any.
+		if flag.Deprecated && opt.warn != nil {
+			val = deprecateValue(val, flag, opt)
+		}
+
+		// Sensitive values are redacted, and prompted for when not given.
+		if flag.Secret {
+			val = secretFlag(flag, val, opt)
+		}
+
+		// Values given as @path are read from files.
+		if convert.ExpandsFile(*tag) {
+			val = &expandedValue{Value: val, tag: *tag}
+		}
+
+		// Or from the standard input, when given its placeholder (eg. "-").
+		if placeholder, isSet := convert.StdinPlaceholder(*tag); isSet {
+			val = &stdinValue{Value: val, field: value, placeholder: placeholder, in: opt.stdin}
+		}
+
+		// Invalid values might be collected, instead of stopping the parsing.
+		if opt.errors != nil {
+			val = &collectedValue{Value: val, flag: flag, errs: opt.errors}
+		}
+
+		// Record when the flag is set, as opposed to left to its default,
+		// and the default itself, to be restored when the struct is reset.
+		val = trackChanges(val, flag, value)
+
+		// Values might be set from a profile of defaults.
+		if len(opt.profiles) > 0 {
+			profiled := &profiledValue{Value: val, field: value}
+			if changed, tracked := val.(*changedValue); tracked {
+				changed.profile = profiled
+			}
+
+			val = profiled
+		}
+
+		// Only boolean flags are seen as such by the flag packages.
+		if isBool {
+			val = &boolFlagValue{Value: val}
+		}
+
 		flag.Value = val
-		flag.DefValue = val.String()
+		flag.DefValue = flag.DisplayValue(val.String())
 		flags = append(flags, flag)
 
+		// Boolean flags might also be given a --no-<flag> form.
+		if negatable, _ := tag.Get("negatable"); !isStringFalsy(negatable) {
+			if negated := negatedFlag(flag); negated != nil {
+				flags = append(flags, negated)
+			}
+		}
+
+		// Flags might also be given additional long names.
+		aliases := aliasFlags(flag, *tag, opt)
+		flags = append(flags, aliases...)
+
 		// If the user provided some custom flag
 		// value handlers/scanners, run on it.
 		if opt.flagFunc != nil {
-			var name string
-			if flag.Name != "" {
-				name = flag.Name
-			} else {
-				name = flag.Short
+			opt.flagFunc(flagName(flag), *tag, value)
+
+			for _, alias := range aliases {
+				opt.flagFunc(alias.Name, *tag, value)
 			}
-			opt.flagFunc(name, *tag, value)
 		}
 
 		return flags, true
 	}
 
-	// field is a structure
+	// field is a structure, whose options might be mutually
+	// exclusive, or required together, as a whole group.
+	if exclusive, _ := tag.Get("exclusive"); !isStringFalsy(exclusive) {
+		for _, nested := range nestedFlags {
+			nested.Exclusive = flag.Name
+		}
+	}
+
+	if allOrNone, _ := tag.Get("all-or-none"); !isStringFalsy(allOrNone) {
+		for _, nested := range nestedFlags {
+			nested.RequiredWith = flag.Name
+		}
+	}
+
 	if len(nestedFlags) > 0 {
 		flags = append(flags, nestedFlags...)
 	}
@@ -192,6 +427,13 @@ func ParseField(value reflect.Value, field reflect.StructField, optFuncs ...OptF
 }
 
 func parseVal(value reflect.Value, optFuncs ...OptFunc) ([]*Flag, Value) {
+	// Types with a registered parser have precedence.
+	if value.CanSet() {
+		if val := parseRegistered(value); val != nil {
+			return nil, val
+		}
+	}
+
 	// value is addressable, let's check if we can parse it
 	if value.CanAddr() && value.Addr().CanInterface() {
 		valueInterface := value.Addr().Interface()
@@ -203,11 +445,24 @@ func parseVal(value reflect.Value, optFuncs ...OptFunc) ([]*Flag, Value) {
 		if val, casted := valueInterface.(Value); casted {
 			return nil, val
 		}
+		// check for the standard library types
+		if val := parseStdlib(value); val != nil {
+			return nil, val
+		}
+		// or the standard text unmarshaling interface
+		if _, casted := valueInterface.(encoding.TextUnmarshaler); casted {
+			return nil, &textValue{value: value}
+		}
 	}
 
 	switch value.Kind() {
 	case reflect.Ptr:
 		if value.IsNil() {
+			// Pointers to basic types are only allocated when set.
+			if val := parseOptional(value, optFuncs...); val != nil {
+				return nil, val
+			}
+
 			value.Set(reflect.New(value.Type().Elem()))
 		}
 		val := parseGeneratedPtrs(value.Addr().Interface())
@@ -240,15 +495,201 @@ func parseVal(value reflect.Value, optFuncs ...OptFunc) ([]*Flag, Value) {
 	return nil, nil
 }
 
+// parseOptional returns a value allocating a nil pointer field only once set,
+// or nil if the field does not point to a basic type (eg. structs, slices).
+func parseOptional(value reflect.Value, optFuncs ...OptFunc) Value {
+	switch value.Type().Elem().Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+	default:
+		return nil
+	}
+
+	alloc := reflect.New(value.Type().Elem())
+
+	_, val := parseVal(alloc.Elem(), optFuncs...)
+	if val == nil && isChoiceProvider(alloc.Elem()) {
+		val = parseUnderlying(alloc.Elem())
+	}
+
+	if val == nil {
+		return nil
+	}
+
+	return &optionalValue{field: value, alloc: alloc, value: val}
+}
+
+// parseUnderlying returns a value bound to a field of a type based
+// on a basic type (eg. type Region string), or nil if there is none.
+func parseUnderlying(value reflect.Value) Value {
+	basic, found := basicTypes[value.Kind()]
+	if !found || !value.CanAddr() || !value.Addr().Type().ConvertibleTo(reflect.PtrTo(basic)) {
+		return nil
+	}
+
+	return parseGenerated(value.Addr().Convert(reflect.PtrTo(basic)).Interface())
+}
+
+// basicTypes are the basic types on which other types can be based.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// parseCounter returns a Counter bound to an int struct field, or nil
+// if the field is not an int (or a type based on int).
+func parseCounter(value reflect.Value) Value {
+	counterType := reflect.TypeOf((*Counter)(nil))
+
+	if !value.CanAddr() || !value.Addr().Type().ConvertibleTo(counterType) {
+		return nil
+	}
+
+	counter, _ := value.Addr().Convert(counterType).Interface().(*Counter)
+
+	return counter
+}
+
+// parseBase returns a value bound to an integer field in the base given by its
+// `base` tag, or nil if the field is not an integer, or the base is invalid.
+func parseBase(value reflect.Value, mtag tag.MultiTag) Value {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+
+	spec, _ := mtag.Get("base")
+
+	base, err := strconv.Atoi(spec)
+	if err != nil || base < 2 || base > 36 || !value.CanSet() {
+		return nil
+	}
+
+	return &baseValue{value: value, tag: mtag, base: base}
+}
+
+// parseSize returns a Size bound to an uint64 or int64 struct field,
+// or nil if the field is neither (nor a type based on them).
+func parseSize(value reflect.Value) Value {
+	if !value.CanAddr() {
+		return nil
+	}
+
+	sizeType := reflect.TypeOf((*Size)(nil))
+	intType := reflect.TypeOf((*int64)(nil))
+
+	switch {
+	case value.Addr().Type().ConvertibleTo(sizeType):
+		size, _ := value.Addr().Convert(sizeType).Interface().(*Size)
+
+		return size
+	case value.Addr().Type().ConvertibleTo(intType):
+		size, _ := value.Addr().Convert(intType).Interface().(*int64)
+
+		return &intSizeValue{value: size}
+	}
+
+	return nil
+}
+
+// negatedFlag returns the hidden --no-<flag> counterpart of a boolean
+// flag, or nil if the flag is not a boolean one.
+func negatedFlag(flag *Flag) *Flag {
+	boolFlag, casted := flag.Value.(BoolFlag)
+	if !casted || !boolFlag.IsBoolFlag() {
+		return nil
+	}
+
+	if repeatable, casted := flag.Value.(RepeatableFlag); casted && repeatable.IsCumulative() {
+		return nil
+	}
+
+	value := &negatedValue{Value: flag.Value}
+
+	return &Flag{
+		Name:          "no-" + flag.Name,
+		Usage:         flag.Usage,
+		Value:         value,
+		DefValue:      value.String(),
+		Hidden:        true,
+		Persistent:    flag.Persistent,
+		Deprecated:    flag.Deprecated,
+		DeprecatedMsg: flag.DeprecatedMsg,
+		Group:         flag.Group,
+		tag:           flag.tag,
+	}
+}
+
+// aliasFlags returns the hidden flags of the additional long names given
+// in `alias` tags, either repeated or space/comma-separated, sharing the
+// value of the flag. Like flag names, aliases starting with ~ are not prefixed.
+func aliasFlags(flag *Flag, tag tag.MultiTag, opt opts) []*Flag {
+	var aliases []*Flag
+
+	isSeparator := func(r rune) bool { return r == ',' || r == ' ' }
+
+	for _, names := range tag.GetMany("alias") {
+		for _, name := range strings.FieldsFunc(names, isSeparator) {
+			if name == flag.Name {
+				continue
+			}
+
+			if strings.HasPrefix(name, "~") {
+				name = name[1:]
+			} else {
+				name = opt.prefix + name
+			}
+
+			aliases = append(aliases, &Flag{
+				Name:          name,
+				Usage:         flag.Usage,
+				Value:         flag.Value,
+				DefValue:      flag.DefValue,
+				Display:       flag.Display,
+				Hidden:        true,
+				Persistent:    flag.Persistent,
+				Deprecated:    flag.Deprecated,
+				DeprecatedMsg: flag.DeprecatedMsg,
+				Choices:       flag.Choices,
+				Group:         flag.Group,
+				Validators:    flag.Validators,
+				tag:           flag.tag,
+			})
+		}
+	}
+
+	return aliases
+}
+
 func parseStruct(value reflect.Value, optFuncs ...OptFunc) []*Flag {
 	// TODO: this call is now made for every field in ParseField,
 	// so that external callers don't have to access opts, only OptFuncs.
 	// Maybe change this, quite inefficient.
 	// opt := defOpts().apply(optFuncs...)
 
+	// Dynamic defaults are set before being read as the flags defaults.
+	ApplyDefaults(value)
+
 	flags := []*Flag{}
 
 	valueType := value.Type()
+	fieldOpts := append(append([]OptFunc{}, optFuncs...), owner(valueType))
 fields:
 	for i := 0; i < value.NumField(); i++ {
 		field := valueType.Field(i)
@@ -259,7 +700,7 @@ fields:
 		}
 
 		// Scan the field, potentially a structure.
-		fieldFlags, found := ParseField(fieldValue, field, optFuncs...)
+		fieldFlags, found := ParseField(fieldValue, field, fieldOpts...)
 		if !found || len(fieldFlags) == 0 {
 			continue fields
 		}
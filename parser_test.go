@@ -6,9 +6,12 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/octago/sflags/internal/tag"
 )
 
 func strP(value string) *string {
@@ -488,3 +491,395 @@ func TestFlatten(t *testing.T) {
 	Flatten(false)(&opt)
 	assert.Equal(t, false, opt.flatten)
 }
+
+func TestParseStruct_Choices(t *testing.T) {
+	cfg := &struct {
+		Format string   `long:"format" choices:"json yaml toml"`
+		Level  string   `long:"level" choice:"debug" choice:"info"`
+		Tags   []string `long:"tag" choices:"a b"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	assert.Equal(t, []string{"json", "yaml", "toml"}, flags[0].Choices)
+	assert.Equal(t, []string{"debug", "info"}, flags[1].Choices)
+
+	assert.NoError(t, flags[0].Value.Set("yaml"))
+	assert.EqualError(t, flags[0].Value.Set("xml"), `invalid choice "xml": must be one of json, yaml, toml`)
+	assert.Equal(t, "yaml", cfg.Format)
+
+	assert.NoError(t, flags[1].Value.Set("info"))
+	assert.Error(t, flags[1].Value.Set("warn"))
+
+	assert.NoError(t, flags[2].Value.Set("a,b"))
+	assert.EqualError(t, flags[2].Value.Set("a,c"), `invalid choice "c": must be one of a, b`)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+}
+
+func TestParseStruct_ChoicesSeparator(t *testing.T) {
+	cfg := &struct {
+		Tags  []string `long:"tag" choices:"a b" sep:";"`
+		Names []string `long:"name" choices:"a,b c" sep:"none"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	assert.NoError(t, flags[0].Value.Set("a;b"))
+	assert.EqualError(t, flags[0].Value.Set("a;c"), `invalid choice "c": must be one of a, b`)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+
+	assert.NoError(t, flags[1].Value.Set("a,b"))
+	assert.Error(t, flags[1].Value.Set("c,a"))
+	assert.Equal(t, []string{"a,b"}, cfg.Names)
+}
+
+func TestParseStruct_ChoicesRef(t *testing.T) {
+	Choices("test-regions", "eu-west", "us-east")
+	Choices("test-zones", "a", "b")
+
+	cfg := &struct {
+		Region  string   `long:"region" choices-ref:"test-regions"`
+		Regions []string `long:"regions" choices-ref:"test-regions"`
+		Zone    string   `long:"zone" choices-ref:"test-regions test-zones" choice:"local"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	assert.Equal(t, []string{"eu-west", "us-east"}, flags[0].Choices)
+	assert.Equal(t, flags[0].Choices, flags[1].Choices)
+	assert.Equal(t, []string{"local", "eu-west", "us-east", "a", "b"}, flags[2].Choices)
+
+	assert.NoError(t, flags[0].Value.Set("us-east"))
+	assert.EqualError(t, flags[0].Value.Set("ap-south"), `invalid choice "ap-south": must be one of eu-west, us-east`)
+	assert.EqualError(t, flags[1].Value.Set("eu-west,ap-south"), `invalid choice "ap-south": must be one of eu-west, us-east`)
+	assert.NoError(t, flags[2].Value.Set("b"))
+}
+
+// region has its allowed values only known at runtime.
+type region string
+
+var regions = []Choice{{Value: "eu-west", Description: "Europe"}, {Value: "us-east"}}
+
+func (region) Choices() []Choice { return regions }
+
+func TestParseStruct_ChoiceProvider(t *testing.T) {
+	cfg := &struct {
+		Region region  `long:"region"`
+		Backup *region `long:"backup"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	assert.Equal(t, "string", flags[0].Value.Type())
+	assert.NoError(t, flags[0].Value.Set("eu-west"))
+	assert.EqualError(t, flags[0].Value.Set("ap-south"), `invalid choice "ap-south": must be one of eu-west, us-east`)
+	assert.Equal(t, region("eu-west"), cfg.Region)
+
+	assert.EqualError(t, flags[1].Value.Set("ap-south"), `invalid choice "ap-south": must be one of eu-west, us-east`)
+	assert.Nil(t, cfg.Backup)
+	assert.NoError(t, flags[1].Value.Set("us-east"))
+	require.NotNil(t, cfg.Backup)
+	assert.Equal(t, region("us-east"), *cfg.Backup)
+}
+
+func TestParseStruct_CounterType(t *testing.T) {
+	type verbosity int
+
+	cfg := &struct {
+		Verbose   int       `long:"verbose" type:"counter"`
+		Verbosity verbosity `long:"verbosity" type:"counter"`
+		Level     int64     `long:"level" type:"counter"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	assert.Equal(t, "count", flags[0].Value.Type())
+	assert.Equal(t, "count", flags[1].Value.Type())
+	assert.Equal(t, "int64", flags[2].Value.Type(), "only int fields can be counters")
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, flags[0].Value.Set("true"))
+	}
+
+	require.NoError(t, flags[1].Value.Set("true"))
+	assert.Equal(t, 3, cfg.Verbose)
+	assert.Equal(t, verbosity(1), cfg.Verbosity)
+}
+
+func TestParseStruct_MapDelimiter(t *testing.T) {
+	cfg := &struct {
+		Labels map[string]string `long:"label"`
+		Limits map[string]int    `long:"limit" key-value-delimiter:"->"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	require.NoError(t, flags[0].Value.Set("app=web"))
+	require.NoError(t, flags[0].Value.Set("url=http://host:80"))
+	require.NoError(t, flags[0].Value.Set("tier:front"))
+	assert.EqualError(t, flags[0].Value.Set("app"), "invalid map flag syntax, use -map=key1:val1")
+	assert.Equal(t, map[string]string{"app": "web", "url": "http://host:80", "tier": "front"}, cfg.Labels)
+
+	require.NoError(t, flags[1].Value.Set("cpu->2"))
+	assert.EqualError(t, flags[1].Value.Set("cpu=2"), "invalid map flag syntax, use -map=key1->val1")
+	assert.Error(t, flags[1].Value.Set("cpu->two"))
+	assert.Equal(t, map[string]int{"cpu": 2}, cfg.Limits)
+}
+
+func TestParseStruct_Negatable(t *testing.T) {
+	cfg := &struct {
+		Cache bool           `long:"cache" negatable:"true" description:"use the cache"`
+		Color TriBool        `long:"color" negatable:"yes"`
+		Name  string         `long:"name" negatable:"true"`
+		Count Counter        `long:"count" negatable:"true"`
+		Debug bool           `long:"debug"`
+		Pager map[string]int `long:"pager" negatable:"true"`
+	}{Cache: true}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 8)
+
+	noCache := flags[1]
+	assert.Equal(t, "no-cache", noCache.Name)
+	assert.Equal(t, "use the cache", noCache.Usage)
+	assert.Equal(t, "false", noCache.DefValue)
+	assert.True(t, noCache.Hidden)
+
+	require.NoError(t, noCache.Value.Set("true"))
+	assert.False(t, cfg.Cache)
+	require.NoError(t, noCache.Value.Set("false"))
+	assert.True(t, cfg.Cache)
+	assert.Error(t, noCache.Value.Set("maybe"))
+
+	noColor := flags[3]
+	assert.Equal(t, "no-color", noColor.Name)
+	require.NoError(t, noColor.Value.Set(""))
+	assert.Equal(t, TriFalse, cfg.Color)
+	assert.Equal(t, "true", noColor.Value.String())
+
+	assert.Equal(t, "name", flags[4].Name)
+	assert.Equal(t, "count", flags[5].Name)
+	assert.Equal(t, "debug", flags[6].Name)
+	assert.Equal(t, "pager", flags[7].Name)
+}
+
+func TestParseStruct_Aliases(t *testing.T) {
+	cfg := &struct {
+		Color string `long:"color" alias:"colour" description:"output color"`
+		Group struct {
+			Level int `long:"level" alias:"lvl ~verbosity"`
+		} `flag:"log"`
+	}{Color: "auto"}
+
+	var handled []string
+
+	handler := func(flag string, _ tag.MultiTag, _ reflect.Value) error {
+		handled = append(handled, flag)
+		return nil
+	}
+
+	flags, err := ParseStruct(cfg, FlagHandler(handler))
+	require.NoError(t, err)
+	require.Len(t, flags, 5)
+
+	colour := flags[1]
+	assert.Equal(t, "colour", colour.Name)
+	assert.Equal(t, "output color", colour.Usage)
+	assert.Equal(t, "auto", colour.DefValue)
+	assert.True(t, colour.Hidden)
+
+	require.NoError(t, colour.Value.Set("never"))
+	assert.Equal(t, "never", cfg.Color)
+
+	assert.Equal(t, "log-level", flags[2].Name)
+	assert.Equal(t, "log-lvl", flags[3].Name)
+	assert.Equal(t, "verbosity", flags[4].Name)
+
+	require.NoError(t, flags[4].Value.Set("2"))
+	assert.Equal(t, 2, cfg.Group.Level)
+
+	assert.Equal(t, []string{"color", "colour", "log-level", "log-lvl", "verbosity"}, handled)
+}
+
+func TestParseStruct_OptionalPointers(t *testing.T) {
+	cfg := &struct {
+		Port    *int     `long:"port"`
+		Name    *string  `long:"name"`
+		Verbose *bool    `long:"verbose"`
+		Ratio   *float64 `long:"ratio"`
+		Level   *int     `long:"level"`
+	}{Level: func(level int) *int { return &level }(3)}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 5)
+
+	assert.Nil(t, cfg.Port, "unset flags leave pointers nil")
+	assert.Nil(t, cfg.Name)
+	assert.Nil(t, cfg.Verbose)
+	assert.Equal(t, "", flags[0].DefValue)
+	assert.Equal(t, "int", flags[0].Value.Type())
+	assert.Nil(t, flags[0].Value.(Getter).Get())
+
+	assert.True(t, flags[2].Value.(BoolFlag).IsBoolFlag())
+	assert.Equal(t, "3", flags[4].DefValue, "non-nil pointers keep their value")
+
+	assert.Error(t, flags[0].Value.Set("port"))
+	assert.Nil(t, cfg.Port, "invalid values leave pointers nil")
+
+	require.NoError(t, flags[0].Value.Set("0"))
+	require.NoError(t, flags[1].Value.Set(""))
+	require.NoError(t, flags[2].Value.Set("false"))
+
+	require.NotNil(t, cfg.Port)
+	require.NotNil(t, cfg.Name)
+	require.NotNil(t, cfg.Verbose)
+	assert.Equal(t, 0, *cfg.Port)
+	assert.Equal(t, "", *cfg.Name)
+	assert.False(t, *cfg.Verbose)
+	assert.Nil(t, cfg.Ratio)
+	assert.Equal(t, 0, flags[0].Value.(Getter).Get())
+}
+
+func TestParseStruct_DeprecatedTag(t *testing.T) {
+	cfg := &struct {
+		Old     string `long:"old" deprecated:"use --new instead"`
+		Older   string `long:"older" deprecated:"true"`
+		Current string `long:"current" deprecated:"false"`
+		Legacy  string `flag:"legacy,deprecated"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 4)
+
+	assert.True(t, flags[0].Deprecated)
+	assert.Equal(t, "use --new instead", flags[0].DeprecatedMsg)
+	assert.True(t, flags[1].Deprecated)
+	assert.Empty(t, flags[1].DeprecatedMsg)
+	assert.False(t, flags[2].Deprecated)
+	assert.True(t, flags[3].Deprecated)
+	assert.Empty(t, flags[3].DeprecatedMsg)
+}
+
+func TestParseStruct_IntegerBase(t *testing.T) {
+	cfg := &struct {
+		Mode  uint32 `long:"mode" base:"8"`
+		Mask  uint8  `long:"mask" base:"16"`
+		Delta int    `long:"delta" base:"2"`
+		Port  int    `long:"port"`
+		Bad   int    `long:"bad" base:"64"`
+	}{Mode: 0o644}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 5)
+
+	assert.Equal(t, "644", flags[0].DefValue)
+	assert.Equal(t, "uint32", flags[0].Value.Type())
+
+	require.NoError(t, flags[0].Value.Set("755"))
+	assert.Equal(t, uint32(0o755), cfg.Mode)
+	require.NoError(t, flags[0].Value.Set("0o700"))
+	assert.Equal(t, uint32(0o700), cfg.Mode)
+
+	require.NoError(t, flags[1].Value.Set("0xFF"))
+	assert.Equal(t, uint8(0xff), cfg.Mask)
+	assert.Equal(t, "ff", flags[1].Value.String())
+	assert.EqualError(t, flags[1].Value.Set("1FF"), `invalid base 16 integer "1FF": value out of range`)
+
+	require.NoError(t, flags[2].Value.Set("-101"))
+	assert.Equal(t, -5, cfg.Delta)
+	assert.EqualError(t, flags[2].Value.Set("0x1F"), `invalid base 2 integer "0x1F": invalid syntax`)
+
+	require.NoError(t, flags[3].Value.Set("0b1010"))
+	assert.Equal(t, 10, cfg.Port)
+
+	assert.Equal(t, "int", flags[4].Value.Type(), "invalid bases are ignored")
+}
+
+type defaultedOpts struct {
+	User string `long:"user"`
+}
+
+func (o *defaultedOpts) SetDefaults() {
+	if o.User == "" {
+		o.User = "root"
+	}
+}
+
+type defaultedCfg struct {
+	Host  string        `long:"host"`
+	Login defaultedOpts `group:"login"`
+}
+
+func (c *defaultedCfg) SetDefaults() { c.Host = "localhost" }
+
+func TestParseStruct_Defaulter(t *testing.T) {
+	cfg := &defaultedCfg{Login: defaultedOpts{User: "admin"}}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	assert.Equal(t, "localhost", flags[0].DefValue)
+	assert.Equal(t, "admin", flags[1].DefValue, "defaults may keep values already set")
+
+	cfg = &defaultedCfg{}
+	flags, err = ParseStruct(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "root", flags[1].DefValue)
+
+	require.NoError(t, flags[0].Value.Set("example.com"))
+	assert.Equal(t, "example.com", cfg.Host)
+}
+
+func TestParseStruct_DefaultTag(t *testing.T) {
+	var warnings []Warning
+
+	cfg := &struct {
+		Port    int           `long:"port" default:"8080"`
+		Host    string        `long:"host" default:"localhost"`
+		Timeout time.Duration `long:"timeout" default:"5s"`
+		Peers   []string      `long:"peer" default:"a,b" default:"c"`
+		Paths   []string      `long:"path" default:"/bin:/usr/bin" sep:":"`
+		Mode    *uint32       `long:"mode" default:"644" base:"8"`
+		Set     string        `long:"set" default:"unused"`
+		Bad     int           `long:"bad" default:"eighty"`
+	}{Set: "preset"}
+
+	flags, err := ParseStruct(cfg, WarningHandler(func(w Warning) { warnings = append(warnings, w) }))
+	require.NoError(t, err)
+	require.Len(t, flags, 8)
+
+	assert.Equal(t, "8080", flags[0].DefValue)
+	assert.Equal(t, "localhost", flags[1].DefValue)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Peers)
+	assert.Equal(t, []string{"/bin", "/usr/bin"}, cfg.Paths)
+	require.NotNil(t, cfg.Mode)
+	assert.Equal(t, uint32(0o644), *cfg.Mode)
+	assert.Equal(t, "preset", cfg.Set, "values already set are kept")
+	assert.Equal(t, 0, cfg.Bad)
+
+	require.Len(t, warnings, 1)
+	assert.Equal(t, WarningIgnored, warnings[0].Kind)
+	assert.Equal(t, "bad", warnings[0].Flag)
+
+	// Flags given on the command line replace the default values.
+	require.NoError(t, flags[3].Value.Set("d"))
+	assert.Equal(t, []string{"d"}, cfg.Peers)
+}
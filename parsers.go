@@ -0,0 +1,83 @@
+package sflags
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/octago/sflags/internal/convert"
+)
+
+// RegisterValueParser registers a parser for a type, so that struct fields
+// of this type (or slices of it) can be used as flags and positional arguments
+// without implementing the Value interface on it, for instance third-party
+// types like decimal.Decimal. The parser must return a value of the type, or a
+// pointer to it. Registered parsers have precedence over any other conversion.
+func RegisterValueParser(typ reflect.Type, parser func(string) (interface{}, error)) {
+	convert.RegisterParser(typ, parser)
+}
+
+// registeredValue is a flag value set with the parser registered for its type.
+// Slices are appended each value, and are reset the first time they are set.
+type registeredValue struct {
+	value   reflect.Value
+	changed bool
+}
+
+var _ RepeatableFlag = (*registeredValue)(nil)
+
+// parseRegistered returns a value for the field, if a parser is registered
+// for its type or, for slices, for the type of their elements.
+func parseRegistered(value reflect.Value) Value {
+	valType := value.Type()
+	if valType.Kind() == reflect.Slice {
+		valType = valType.Elem()
+	}
+
+	if !convert.HasParser(valType) {
+		return nil
+	}
+
+	return &registeredValue{value: value}
+}
+
+func (v *registeredValue) Set(s string) error {
+	if v.value.Kind() != reflect.Slice {
+		_, err := convert.Registered(s, v.value)
+
+		return err
+	}
+
+	elem := reflect.New(v.value.Type().Elem()).Elem()
+	if _, err := convert.Registered(s, elem); err != nil {
+		return err
+	}
+
+	if !v.changed {
+		v.value.Set(reflect.MakeSlice(v.value.Type(), 0, 1))
+	}
+
+	v.value.Set(reflect.Append(v.value, elem))
+	v.changed = true
+
+	return nil
+}
+
+func (v *registeredValue) Get() interface{} {
+	return v.value.Interface()
+}
+
+func (v *registeredValue) String() string {
+	if v.value.Kind() == reflect.Slice && v.value.Len() == 0 {
+		return ""
+	}
+
+	return fmt.Sprint(v.value.Interface())
+}
+
+func (v *registeredValue) Type() string {
+	return v.value.Type().String()
+}
+
+func (v *registeredValue) IsCumulative() bool {
+	return v.value.Kind() == reflect.Slice
+}
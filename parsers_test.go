@@ -0,0 +1,54 @@
+package sflags
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// point is a type without any flag Value implementation.
+type point struct {
+	X, Y int
+}
+
+func (p point) String() string { return fmt.Sprintf("%d,%d", p.X, p.Y) }
+
+func parsePoint(val string) (interface{}, error) {
+	p := &point{}
+	if _, err := fmt.Sscanf(val, "%d,%d", &p.X, &p.Y); err != nil {
+		return nil, fmt.Errorf("invalid point %q", val)
+	}
+
+	return p, nil
+}
+
+func TestRegisterValueParser(t *testing.T) {
+	RegisterValueParser(reflect.TypeOf(point{}), parsePoint)
+
+	cfg := &struct {
+		Origin point   `long:"origin"`
+		Path   []point `long:"path"`
+		Target *point  `long:"target"`
+	}{Origin: point{1, 1}, Path: []point{{0, 0}}}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	assert.Equal(t, "1,1", flags[0].DefValue)
+	assert.Equal(t, "sflags.point", flags[0].Value.Type())
+
+	require.NoError(t, flags[0].Value.Set("2,3"))
+	assert.Equal(t, point{2, 3}, cfg.Origin)
+	assert.EqualError(t, flags[0].Value.Set("here"), `invalid point "here"`)
+
+	require.NoError(t, flags[1].Value.Set("1,2"))
+	require.NoError(t, flags[1].Value.Set("3,4"))
+	assert.Equal(t, []point{{1, 2}, {3, 4}}, cfg.Path)
+
+	require.NoError(t, flags[2].Value.Set("5,6"))
+	assert.Equal(t, &point{5, 6}, cfg.Target)
+}
@@ -0,0 +1,80 @@
+package sflags
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// pathValue expands the file paths given to a flag (see the `type:"path"` tag):
+// a leading ~ is the home directory of the user, environment variables are
+// replaced, and relative paths are made absolute. The paths given to slices
+// are expanded one by one.
+type pathValue struct {
+	Value
+	sep    string
+	splits bool
+}
+
+func (v *pathValue) Set(raw string) error {
+	paths := []string{raw}
+	if v.splits {
+		paths = strings.Split(raw, v.sep)
+	}
+
+	for i, path := range paths {
+		expanded, err := convert.ExpandPath(path)
+		if err != nil {
+			return err
+		}
+
+		paths[i] = expanded
+	}
+
+	return v.Value.Set(strings.Join(paths, v.sep))
+}
+
+func (v *pathValue) Type() string {
+	if v.splits {
+		return v.Value.Type()
+	}
+
+	return "path"
+}
+
+func (v *pathValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+
+	return nil
+}
+
+func (v *pathValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+// parsePath returns a value expanding paths, for string and []string fields.
+func parsePath(val Value, value reflect.Value, mtag tag.MultiTag) Value {
+	typ := value.Type()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ.Kind() == reflect.String:
+		return &pathValue{Value: val}
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.String:
+		sep, splits := convert.Separator(mtag, ",")
+
+		return &pathValue{Value: val, sep: sep, splits: splits}
+	}
+
+	return val
+}
@@ -0,0 +1,47 @@
+package sflags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStruct_PathType(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APP_DIR", "/srv/app")
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	cfg := &struct {
+		Config string   `long:"config" type:"path"`
+		Data   *string  `long:"data" type:"path"`
+		Paths  []string `long:"path" type:"path"`
+		Dirs   []string `long:"dir" type:"path" sep:":"`
+		Cache  string   `long:"cache" type:"path" default:"~/.cache"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 5)
+
+	assert.Equal(t, "path", flags[0].Value.Type())
+	assert.Equal(t, filepath.Join(home, ".cache"), cfg.Cache)
+
+	require.NoError(t, flags[0].Value.Set("~/app.yaml"))
+	assert.Equal(t, filepath.Join(home, "app.yaml"), cfg.Config)
+
+	require.NoError(t, flags[1].Value.Set("$APP_DIR/data"))
+	require.NotNil(t, cfg.Data)
+	assert.Equal(t, "/srv/app/data", *cfg.Data)
+
+	require.NoError(t, flags[2].Value.Set("a,~"))
+	assert.Equal(t, []string{filepath.Join(cwd, "a"), home}, cfg.Paths)
+
+	require.NoError(t, flags[3].Value.Set("/bin:${APP_DIR}/bin"))
+	assert.Equal(t, []string{"/bin", "/srv/app/bin"}, cfg.Dirs)
+}
@@ -0,0 +1,34 @@
+package sflags
+
+import "io"
+
+// Presentation holds how the flag sets built by generators (like gpflag and
+// gflag) print their usage, as set by the Output, Usage and SortFlags options,
+// so that applications do not have to modify the flag sets afterwards.
+type Presentation struct {
+	Output    io.Writer // Where usage and errors are printed, if not nil.
+	Usage     func()    // The function printing the usage, if not nil.
+	SortFlags *bool     // Whether flags are sorted in usage, if not nil.
+}
+
+// Output sets where the flag sets print their usage and errors.
+func Output(w io.Writer) OptFunc {
+	return func(opt *opts) { opt.presentation.Output = w }
+}
+
+// Usage sets the function printing the usage of the flag sets.
+func Usage(usage func()) OptFunc {
+	return func(opt *opts) { opt.presentation.Usage = usage }
+}
+
+// SortFlags sets whether flags are sorted by name in the usage of
+// the flag sets, or kept in the order of their struct fields.
+func SortFlags(sort bool) OptFunc {
+	return func(opt *opts) { opt.presentation.SortFlags = &sort }
+}
+
+// PresentationOf returns the presentation of flag sets set by options,
+// for generators to apply it on the flag sets they build.
+func PresentationOf(optFuncs ...OptFunc) Presentation {
+	return defOpts().apply(optFuncs...).presentation
+}
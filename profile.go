@@ -0,0 +1,231 @@
+package sflags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrProfile indicates an invalid profile of default values.
+var ErrProfile = errors.New("invalid profile")
+
+// profileFlagName is the name of the flag selecting a profile.
+const profileFlagName = "profile"
+
+// Profiles declares named sets of default values, one of which can be selected
+// on the command line with a --profile flag, added to the flags of the struct.
+// Each profile is a map[string]string or map[string]interface{} of flag names to
+// their default values. Slices and arrays are set element by element.
+//
+// Profile values are applied before the values explicitly given on the command
+// line, whatever the order of the flags: the latter always override the former.
+func Profiles(profiles map[string]interface{}) OptFunc {
+	return func(opt *opts) { opt.profiles = profiles }
+}
+
+// profiledValue tracks whether a flag value has been explicitly
+// set from the command line, or set from a profile of defaults.
+type profiledValue struct {
+	Value
+	field    reflect.Value
+	explicit bool
+	profiled bool
+}
+
+func (v *profiledValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+// Set sets a value explicitly given on the command line.
+func (v *profiledValue) Set(val string) error {
+	if !v.explicit {
+		v.reset()
+	}
+
+	v.explicit = true
+
+	return v.Value.Set(val)
+}
+
+// setProfile sets the values of a profile, unless a value has been explicitly given.
+func (v *profiledValue) setProfile(vals []string) error {
+	if v.explicit {
+		return nil
+	}
+
+	v.reset()
+	v.profiled = true
+
+	// Profile values are not changes made on the command line.
+	for _, val := range vals {
+		if err := unwrapChanged(v.Value).Set(val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reset clears any value previously set from a profile, so that repeatable
+// flags don't append to them.
+func (v *profiledValue) reset() {
+	if !v.profiled {
+		return
+	}
+
+	target := v.field
+	for target.Kind() == reflect.Ptr && !target.IsNil() {
+		target = target.Elem()
+	}
+
+	target.Set(reflect.Zero(target.Type()))
+	v.profiled = false
+}
+
+// profileValue is the value of the --profile flag, which
+// applies a profile of defaults onto the other flags when set.
+type profileValue struct {
+	name     string
+	profiles map[string]interface{}
+	flags    []*Flag
+	warn     func(Warning)
+}
+
+func (v *profileValue) String() string { return v.name }
+
+func (v *profileValue) Type() string { return "string" }
+
+func (v *profileValue) Set(name string) error {
+	profile, found := v.profiles[name]
+	if !found {
+		return newError(ErrProfile, fmt.Sprintf("unknown profile %q", name))
+	}
+
+	values, err := profileValues(profile)
+	if err != nil {
+		return newError(ErrProfile, fmt.Sprintf("%s: %s", name, err))
+	}
+
+	// Values are applied in the same order, whatever that of the map.
+	names := make([]string, 0, len(values))
+	for flagName := range values {
+		names = append(names, flagName)
+	}
+
+	sort.Strings(names)
+
+	for _, flagName := range names {
+		vals := values[flagName]
+
+		flag := findFlag(v.flags, flagName)
+		if flag == nil {
+			return newError(ErrProfile, fmt.Sprintf("%s: no flag named %q", name, flagName))
+		}
+
+		val := flag.Value
+		if boolFlag, isBool := val.(*boolFlagValue); isBool {
+			val = boolFlag.Value
+		}
+
+		value, ok := val.(*profiledValue)
+		if !ok {
+			v.warn(Warning{
+				Kind:    WarningIgnored,
+				Flag:    flagName,
+				Message: fmt.Sprintf("profile %s: ignoring value for flag %s, which cannot be set from profiles", name, flagName),
+			})
+
+			continue
+		}
+
+		if err := value.setProfile(vals); err != nil {
+			return fmt.Errorf("profile %s: invalid value for %s: %w", name, flagName, err)
+		}
+	}
+
+	v.name = name
+
+	return nil
+}
+
+// ProfileFlag returns the --profile flag selecting one of the profiles of defaults
+// given in optFuncs (see Profiles), and applying it to flags, which must have been
+// parsed with the same profiles. ParseStruct adds it to the flags of a struct, but
+// generators parsing fields one at a time (see ParseField) must add it themselves.
+// It returns nil without profiles.
+func ProfileFlag(flags []*Flag, optFuncs ...OptFunc) *Flag {
+	opt := defOpts().apply(optFuncs...)
+	if len(opt.profiles) == 0 {
+		return nil
+	}
+
+	return profileFlag(flags, opt)
+}
+
+// profileFlag returns the flag selecting one of the profiles applied to flags.
+func profileFlag(flags []*Flag, opt opts) *Flag {
+	names := make([]string, 0, len(opt.profiles))
+	for name := range opt.profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return &Flag{
+		Name:    profileFlagName,
+		Usage:   fmt.Sprintf("profile of default values (%s)", strings.Join(names, ", ")),
+		Value:   &profileValue{profiles: opt.profiles, flags: flags, warn: opt.warner()},
+		Choices: names,
+	}
+}
+
+// profileValues returns the values of a profile, as strings to set onto their flags.
+func profileValues(profile interface{}) (map[string][]string, error) {
+	values := map[string][]string{}
+
+	switch profile := profile.(type) {
+	case map[string]string:
+		for name, val := range profile {
+			values[name] = []string{val}
+		}
+	case map[string]interface{}:
+		for name, val := range profile {
+			values[name] = profileStrings(reflect.ValueOf(val))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported profile type %T", profile)
+	}
+
+	return values, nil
+}
+
+// profileStrings returns the string representation of a value,
+// or of each of its elements if the value is a slice or an array.
+func profileStrings(val reflect.Value) []string {
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return []string{fmt.Sprint(val.Interface())}
+	}
+
+	vals := make([]string, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		vals = append(vals, fmt.Sprint(val.Index(i).Interface()))
+	}
+
+	return vals
+}
+
+func findFlag(flags []*Flag, name string) *Flag {
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag
+		}
+	}
+
+	return nil
+}
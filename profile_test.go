@@ -0,0 +1,136 @@
+package sflags
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type profileCfg struct {
+	Port  int      `long:"port"`
+	Debug bool     `long:"debug"`
+	Tags  []string `long:"tag"`
+}
+
+func TestProfiles(t *testing.T) {
+	profiles := map[string]interface{}{
+		"dev":  map[string]interface{}{"port": 8080, "debug": true, "tag": []string{"a", "b"}},
+		"prod": map[string]string{"port": "443"},
+		"bad":  map[string]string{"unknown": "1"},
+		"int":  map[string]string{"port": "port"},
+		"both": map[string]string{"port": "port", "debug": "debug"},
+	}
+
+	parse := func(t *testing.T, cfg *profileCfg) map[string]*Flag {
+		t.Helper()
+
+		flags, err := ParseStruct(cfg, Profiles(profiles))
+		require.NoError(t, err)
+
+		byName := map[string]*Flag{}
+		for _, flag := range flags {
+			byName[flag.Name] = flag
+		}
+
+		require.Contains(t, byName, "profile")
+		assert.Equal(t, []string{"bad", "both", "dev", "int", "prod"}, byName["profile"].Choices)
+
+		return byName
+	}
+
+	t.Run("Profile values", func(t *testing.T) {
+		cfg := &profileCfg{}
+		flags := parse(t, cfg)
+
+		require.NoError(t, flags["profile"].Value.Set("dev"))
+		assert.Equal(t, &profileCfg{Port: 8080, Debug: true, Tags: []string{"a", "b"}}, cfg)
+
+		// Selecting another profile replaces repeatable values.
+		require.NoError(t, flags["tag"].Value.(*profiledValue).setProfile([]string{"c"}))
+		assert.Equal(t, []string{"c"}, cfg.Tags)
+	})
+
+	t.Run("Explicit values before profile", func(t *testing.T) {
+		cfg := &profileCfg{}
+		flags := parse(t, cfg)
+
+		require.NoError(t, flags["port"].Value.Set("22"))
+		require.NoError(t, flags["tag"].Value.Set("x"))
+		require.NoError(t, flags["profile"].Value.Set("dev"))
+		assert.Equal(t, &profileCfg{Port: 22, Debug: true, Tags: []string{"x"}}, cfg)
+	})
+
+	t.Run("Explicit values after profile", func(t *testing.T) {
+		cfg := &profileCfg{}
+		flags := parse(t, cfg)
+
+		require.NoError(t, flags["profile"].Value.Set("dev"))
+		require.NoError(t, flags["port"].Value.Set("22"))
+		require.NoError(t, flags["tag"].Value.Set("x"))
+		assert.Equal(t, &profileCfg{Port: 22, Debug: true, Tags: []string{"x"}}, cfg)
+	})
+
+	t.Run("Profile after reset", func(t *testing.T) {
+		cfg := &profileCfg{}
+		flags := parse(t, cfg)
+
+		all := make([]*Flag, 0, len(flags))
+		for _, flag := range flags {
+			all = append(all, flag)
+		}
+
+		// Values given before a reset do not override the profile anymore.
+		require.NoError(t, flags["port"].Value.Set("22"))
+		require.NoError(t, Reset(cfg, all))
+		require.NoError(t, flags["profile"].Value.Set("prod"))
+		assert.Equal(t, 443, cfg.Port)
+
+		// Nor do those set from a previous profile.
+		require.NoError(t, Reset(cfg, all))
+		require.NoError(t, flags["profile"].Value.Set("dev"))
+		assert.Equal(t, 8080, cfg.Port)
+
+		// Neither when the flag is unset without its field.
+		require.NoError(t, flags["port"].Value.Set("22"))
+		assert.True(t, Unset(flags["port"].Value))
+		require.NoError(t, flags["profile"].Value.Set("prod"))
+		assert.Equal(t, 443, cfg.Port)
+		assert.False(t, Unset(flags["profile"].Value))
+	})
+
+	t.Run("Invalid profiles", func(t *testing.T) {
+		flags := parse(t, &profileCfg{})
+
+		assert.ErrorIs(t, flags["profile"].Value.Set("staging"), ErrProfile)
+		assert.ErrorIs(t, flags["profile"].Value.Set("bad"), ErrProfile)
+		assert.Error(t, flags["profile"].Value.Set("int"))
+
+		// Values are applied in the order of their flag names.
+		for i := 0; i < 10; i++ {
+			assert.ErrorContains(t, flags["profile"].Value.Set("both"), "invalid value for debug")
+		}
+	})
+
+	t.Run("Fields parsed one at a time", func(t *testing.T) {
+		cfg := &profileCfg{}
+		val := reflect.ValueOf(cfg).Elem()
+
+		var flags []*Flag
+
+		for i := 0; i < val.NumField(); i++ {
+			fieldFlags, found := ParseField(val.Field(i), val.Type().Field(i), Profiles(profiles))
+			require.True(t, found)
+
+			flags = append(flags, fieldFlags...)
+		}
+
+		profile := ProfileFlag(flags, Profiles(profiles))
+		require.NotNil(t, profile)
+		require.NoError(t, profile.Value.Set("dev"))
+		assert.Equal(t, &profileCfg{Port: 8080, Debug: true, Tags: []string{"a", "b"}}, cfg)
+
+		assert.Nil(t, ProfileFlag(flags))
+	})
+}
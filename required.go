@@ -0,0 +1,149 @@
+package sflags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// CheckRequired checks the conditional requirements of a parsed struct: a
+// field tagged with `required-if:"mode=server"` must be set (non-zero) when
+// its sibling field mode (either its flag name or field name) has the value
+// "server". Several comma-separated conditions must all be true for the field
+// to be required, and nested option groups are checked recursively.
+// Fields marked as subcommands are not checked: their own struct is checked
+// by the generators, once they are executed.
+func CheckRequired(cfg interface{}, optFuncs ...OptFunc) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotPointerToStruct
+	}
+
+	return checkRequired(v.Elem(), defOpts().apply(optFuncs...))
+}
+
+// checkRequired checks the conditional requirements of all fields of a struct.
+func checkRequired(val reflect.Value, opt opts) error {
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		mtag, _, _ := tag.GetFieldTag(field)
+		if _, isCmd := mtag.Get("command"); isCmd {
+			continue
+		}
+
+		fieldVal := val.Field(i)
+
+		// Recurse into groups of options.
+		if inner := reflect.Indirect(fieldVal); inner.Kind() == reflect.Struct && !isValue(fieldVal) {
+			if err := checkRequired(inner, opt); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		conditions, isSet := mtag.Get("required-if")
+		if !isSet || conditions == "" || !fieldVal.IsZero() {
+			continue
+		}
+
+		required, err := conditionsMet(val, conditions, opt)
+		if err != nil {
+			return err
+		}
+
+		if required {
+			return newError(ErrRequired, fmt.Sprintf("%s must be set when %s",
+				fieldName(field, opt), conditions))
+		}
+	}
+
+	return nil
+}
+
+// conditionsMet returns true if all the name=value conditions
+// are true with respect to the fields of the struct.
+func conditionsMet(val reflect.Value, conditions string, opt opts) (bool, error) {
+	for _, condition := range strings.Split(conditions, ",") {
+		name, want, found := strings.Cut(strings.TrimSpace(condition), "=")
+		if !found {
+			return false, newError(ErrInvalidTag, fmt.Sprintf("required-if: %q is not a name=value condition", condition))
+		}
+
+		sibling, found := siblingField(val, name, opt)
+		if !found {
+			return false, newError(ErrInvalidTag, fmt.Sprintf("required-if: no field named %q", name))
+		}
+
+		if valueString(sibling) != want {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// siblingField finds a struct field by either its flag name or its field name.
+func siblingField(val reflect.Value, name string, opt opts) (reflect.Value, bool) {
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if field.Name == name || fieldName(field, opt) == name {
+			return val.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// fieldName returns the flag name of a struct field, or its name if not a flag.
+func fieldName(field reflect.StructField, opt opts) string {
+	if flag, _ := parseFlagTag(field, opt); flag != nil {
+		return flag.Name
+	}
+
+	return field.Name
+}
+
+// valueString returns the string representation of a struct field value.
+func valueString(val reflect.Value) string {
+	if val.CanAddr() {
+		if value, ok := val.Addr().Interface().(Value); ok {
+			return value.String()
+		}
+	}
+
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return ""
+		}
+
+		val = val.Elem()
+	}
+
+	return fmt.Sprint(val.Interface())
+}
+
+// isValue returns true if the field type implements the Value interface.
+func isValue(val reflect.Value) bool {
+	if _, ok := val.Interface().(Value); ok {
+		return true
+	}
+
+	if val.CanAddr() {
+		_, ok := val.Addr().Interface().(Value)
+
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,72 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tlsOpts struct {
+	TLS  bool   `long:"tls"`
+	Cert string `long:"cert" required-if:"tls=true"`
+}
+
+type requiredCfg struct {
+	Mode   string   `long:"mode"`
+	Port   int      `long:"port" required-if:"mode=server"`
+	Listen string   `long:"listen" required-if:"Mode=server,port=443"`
+	TLS    tlsOpts  `group:"tls"`
+	Peers  []string `long:"peer" required-if:"mode=client"`
+}
+
+func TestCheckRequired(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *requiredCfg
+		expErr string
+	}{
+		{name: "No condition met", cfg: &requiredCfg{Mode: "standalone"}},
+		{name: "Condition met and set", cfg: &requiredCfg{Mode: "server", Port: 80}},
+		{
+			name:   "Condition met and not set",
+			cfg:    &requiredCfg{Mode: "server"},
+			expErr: "required flag: port must be set when mode=server",
+		},
+		{
+			name:   "All conditions met",
+			cfg:    &requiredCfg{Mode: "server", Port: 443},
+			expErr: "required flag: listen must be set when Mode=server,port=443",
+		},
+		{
+			name:   "Nested group",
+			cfg:    &requiredCfg{TLS: tlsOpts{TLS: true}},
+			expErr: "required flag: cert must be set when tls=true",
+		},
+		{
+			name:   "Slice",
+			cfg:    &requiredCfg{Mode: "client"},
+			expErr: "required flag: peer must be set when mode=client",
+		},
+		{name: "Slice set", cfg: &requiredCfg{Mode: "client", Peers: []string{"a"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckRequired(test.cfg)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrRequired)
+			assert.EqualError(t, err, test.expErr)
+		})
+	}
+
+	invalid := &struct {
+		Port int `required-if:"unknown=1"`
+	}{}
+	assert.ErrorIs(t, CheckRequired(invalid), ErrInvalidTag)
+	assert.ErrorIs(t, CheckRequired(requiredCfg{}), ErrNotPointerToStruct)
+}
@@ -0,0 +1,120 @@
+package sflags
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// Reset restores the fields of data, a pointer to a parsed struct, to their
+// default values, so that closed-loop shells can execute the same commands
+// again without the values of previous executions. The fields of the flags,
+// as returned when parsing data, get back the values they had when the flags
+// were generated, while positional arguments (and fields for which none of
+// the flags was generated) are zeroed and given their `default` tags.
+// Groups of options are reset recursively, the SetDefaults method of the
+// structs implementing Defaulter is called again, and the flags are not
+// considered as changed anymore (see Changed). Subcommands are left as they
+// are, since their flags are parsed separately: reset them with their own.
+func Reset(data interface{}, flags []*Flag) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotPointerToStruct
+	}
+
+	values := map[fieldKey]*changedValue{}
+
+	for _, flag := range flags {
+		if value, found := parsedValue(flag.Value); found {
+			values[fieldKey{addr: value.field.Addr().Pointer(), typ: value.field.Type()}] = value
+		}
+	}
+
+	return resetFields(v.Elem(), false, values)
+}
+
+// resetFields restores the tagged fields of a struct to their default values,
+// or all of its fields if the struct holds positional arguments.
+func resetFields(val reflect.Value, positional bool, values map[fieldKey]*changedValue) error {
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		fieldVal := val.Field(i)
+
+		if !fieldVal.CanSet() || tag.Ignored(field.Type) {
+			continue
+		}
+
+		mtag, none, err := tag.GetFieldTag(field)
+		if err != nil || (none && !positional) {
+			continue
+		}
+
+		if _, isCmd := mtag.Get("command"); isCmd {
+			continue
+		}
+
+		// Recurse into positionals and groups of options.
+		if isGroup(field.Type) {
+			_, isArgs := mtag.Get("positional-args")
+
+			if inner := reflect.Indirect(fieldVal); inner.IsValid() {
+				if err := resetFields(inner, isArgs, values); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if err := resetField(fieldVal, mtag, values); err != nil {
+			return err
+		}
+	}
+
+	ApplyDefaults(val)
+
+	return nil
+}
+
+// resetField restores a field to the value it had when its flag was
+// generated, if any, or to its zero value and its `default` tags.
+func resetField(field reflect.Value, mtag tag.MultiTag, values map[fieldKey]*changedValue) error {
+	if value, found := values[fieldKey{addr: field.Addr().Pointer(), typ: field.Type()}]; found {
+		field.Set(cloneValue(value.def))
+		value.unset()
+
+		return nil
+	}
+
+	field.Set(reflect.Zero(field.Type()))
+
+	return convert.Default(field, mtag)
+}
+
+// cloneValue returns a copy of a value not sharing its slice,
+// map or pointed values, so that they can be modified.
+func cloneValue(val reflect.Value) reflect.Value {
+	clone := reflect.New(val.Type()).Elem()
+
+	switch {
+	case val.Kind() == reflect.Slice && !val.IsNil():
+		elems := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		reflect.Copy(elems, val)
+		clone.Set(elems)
+	case val.Kind() == reflect.Map && !val.IsNil():
+		entries := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for iter := val.MapRange(); iter.Next(); {
+			entries.SetMapIndex(iter.Key(), iter.Value())
+		}
+		clone.Set(entries)
+	case val.Kind() == reflect.Ptr && !val.IsNil():
+		elem := reflect.New(val.Type().Elem())
+		elem.Elem().Set(val.Elem())
+		clone.Set(elem)
+	default:
+		clone.Set(val)
+	}
+
+	return clone
+}
@@ -0,0 +1,59 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReset(t *testing.T) {
+	cfg := &struct {
+		Host  string            `long:"host" default:"localhost"`
+		Port  int               `long:"port"`
+		Tags  []string          `long:"tag"`
+		Attrs map[string]string `long:"attr"`
+		Log   struct {
+			Level string `long:"level" default:"info"`
+		} `flag:"log"`
+		Args struct {
+			Target string `default:"all"`
+			Extra  []string
+		} `positional-args:"yes"`
+	}{Port: 80, Tags: []string{"a"}}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	byName := map[string]*Flag{}
+	for _, flag := range flags {
+		byName[flag.Name] = flag
+	}
+
+	require.NoError(t, byName["host"].Value.Set("example.com"))
+	require.NoError(t, byName["port"].Value.Set("8080"))
+	require.NoError(t, byName["tag"].Value.Set("b"))
+	require.NoError(t, byName["attr"].Value.Set("k=v"))
+	require.NoError(t, byName["log-level"].Value.Set("debug"))
+
+	cfg.Args.Target = "web"
+	cfg.Args.Extra = []string{"x", "y"}
+
+	require.NoError(t, Reset(cfg, flags))
+
+	assert.Equal(t, "localhost", cfg.Host)
+	assert.Equal(t, 80, cfg.Port)
+	assert.Equal(t, []string{"a"}, cfg.Tags)
+	assert.Empty(t, cfg.Attrs)
+	assert.Equal(t, "info", cfg.Log.Level)
+	assert.Equal(t, "all", cfg.Args.Target)
+	assert.Empty(t, cfg.Args.Extra)
+	assert.Empty(t, Changed(flags))
+
+	// Values set after a reset do not modify the defaults.
+	require.NoError(t, byName["tag"].Value.Set("c"))
+	require.NoError(t, Reset(cfg, flags))
+	assert.Equal(t, []string{"a"}, cfg.Tags)
+
+	assert.ErrorIs(t, Reset(*cfg, flags), ErrNotPointerToStruct)
+}
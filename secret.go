@@ -0,0 +1,123 @@
+package sflags
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/octago/sflags/internal/term"
+)
+
+const (
+	// secretRedacted replaces the value of secret flags in help and errors.
+	secretRedacted = "********"
+
+	// secretPromptValue is the optional value of secret flags, which
+	// is set when they are given without a value on the command line.
+	secretPromptValue = "<prompt>"
+)
+
+// SecretPrompt sets the function prompting for the value of secret flags (tagged with
+// `secret:"true"`) when they are given without a value on the command line, like
+// `--password`. By default, the value is read from the terminal with echo disabled.
+// Note that values of secret flags must then be given as `--password=value`.
+func SecretPrompt(prompt func(flag string) (string, error)) OptFunc {
+	return func(opt *opts) { opt.secretPrompt = prompt }
+}
+
+// promptSecret reads the value of a secret flag from the terminal.
+func promptSecret(flag string) (string, error) {
+	return term.ReadSecret(os.Stdin, os.Stderr, fmt.Sprintf("%s: ", flag))
+}
+
+// secretValue redacts the value of a sensitive flag, and prompts
+// for it when the flag is given without a value.
+type secretValue struct {
+	Value
+	name   string
+	prompt func(flag string) (string, error)
+}
+
+// Set sets the value, prompting for it if none was given.
+// The value never appears in the errors returned.
+func (v *secretValue) Set(val string) error {
+	if val == secretPromptValue {
+		prompt := v.prompt
+		if prompt == nil {
+			prompt = promptSecret
+		}
+
+		answer, err := prompt(v.name)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", v.name, err)
+		}
+
+		val = answer
+	}
+
+	if err := v.Value.Set(val); err != nil {
+		var convErr *ConvertError
+		if errors.As(err, &convErr) {
+			convErr.Word = secretRedacted
+		}
+
+		return &redactedError{err: err, secret: val}
+	}
+
+	return nil
+}
+
+// String returns a redacted value, unless empty.
+func (v *secretValue) String() string {
+	if v.Value.String() == "" {
+		return ""
+	}
+
+	return secretRedacted
+}
+
+// redactedError hides a secret value from an error message.
+type redactedError struct {
+	err    error
+	secret string
+}
+
+func (e *redactedError) Error() string {
+	if e.secret == "" {
+		return e.err.Error()
+	}
+
+	return strings.ReplaceAll(e.err.Error(), e.secret, secretRedacted)
+}
+
+// Unwrap returns the wrapped error, redacted as well,
+// so that the secret is not found in the chain of errors.
+func (e *redactedError) Unwrap() error {
+	inner := errors.Unwrap(e.err)
+	if inner == nil {
+		return nil
+	}
+
+	return &redactedError{err: inner, secret: e.secret}
+}
+
+// Is reports whether the wrapped error matches target, without exposing it.
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// secretFlag redacts and prompts for the value of a secret string flag.
+func secretFlag(flag *Flag, val Value, opt opts) Value {
+	if val.Type() != "string" {
+		return val
+	}
+
+	if len(flag.OptionalValue) == 0 {
+		flag.OptionalValue = []string{secretPromptValue}
+	}
+
+	name := flagName(flag)
+
+	return &secretValue{Value: val, name: name, prompt: opt.secretPrompt}
+}
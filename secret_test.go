@@ -0,0 +1,68 @@
+package sflags
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/octago/sflags/internal/validation"
+)
+
+func TestParseStruct_Secret(t *testing.T) {
+	cfg := &struct {
+		Password string `long:"password" secret:"true" choices:"hunter2 letmein"`
+		Token    string `long:"token" secret:"true"`
+		Port     int    `long:"port" secret:"true"`
+	}{Password: "hunter2"}
+
+	prompted := ""
+	prompt := func(flag string) (string, error) {
+		prompted = flag
+
+		return "letmein", nil
+	}
+
+	flags, err := ParseStruct(cfg, SecretPrompt(prompt))
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+
+	assert.Equal(t, secretRedacted, flags[0].DefValue)
+	assert.Equal(t, []string{secretPromptValue}, flags[0].OptionalValue)
+	assert.Empty(t, flags[1].DefValue, "empty secrets are not redacted")
+	assert.Empty(t, flags[2].OptionalValue, "only string flags are prompted for")
+
+	require.NoError(t, flags[0].Value.Set(secretPromptValue))
+	assert.Equal(t, "password", prompted)
+	assert.Equal(t, "letmein", cfg.Password)
+	assert.Equal(t, secretRedacted, flags[0].Value.String())
+
+	err = flags[0].Value.Set("s3cr3t")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+	assert.Contains(t, err.Error(), secretRedacted)
+
+	// Nor in the errors it wraps, which can still be matched.
+	for inner := errors.Unwrap(err); inner != nil; inner = errors.Unwrap(inner) {
+		assert.NotContains(t, inner.Error(), "s3cr3t")
+	}
+
+	assert.ErrorIs(t, err, validation.ErrInvalidChoice)
+}
+
+func TestParseStruct_SecretPromptError(t *testing.T) {
+	cfg := &struct {
+		Password string `long:"password" secret:"true"`
+	}{}
+
+	errPrompt := errors.New("no terminal")
+	prompt := func(flag string) (string, error) { return "", errPrompt }
+
+	flags, err := ParseStruct(cfg, SecretPrompt(prompt))
+	require.NoError(t, err)
+
+	err = flags[0].Value.Set(secretPromptValue)
+	assert.True(t, errors.Is(err, errPrompt))
+	assert.Empty(t, cfg.Password)
+}
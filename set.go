@@ -0,0 +1,39 @@
+package sflags
+
+import "fmt"
+
+// Set sets the field bound to the flag with the given name (as generated,
+// eg. "log-level" for the Level field of a Log group), among the flags returned
+// when parsing a struct, as if the flag was given with the value on the command
+// line: the value is converted, validated and checked against choices the same
+// way, and the flag is reported by Changed. This is meant for runtime
+// configuration APIs, like admin endpoints or `set` commands of closed-loop
+// shells. Aliases and --no-<flag> forms are not found by their names.
+func Set(flags []*Flag, name, value string) error {
+	flag := parsedFlag(flags, name)
+	if flag == nil {
+		return newError(ErrUnknownFlag, name)
+	}
+
+	if err := flag.Set(value); err != nil {
+		if flag.Secret {
+			value = "********"
+		}
+
+		return fmt.Errorf("invalid argument %q for %q flag: %w", value, name, err)
+	}
+
+	return nil
+}
+
+// parsedFlag returns the flag with some name
+// parsed for a struct field, if any.
+func parsedFlag(flags []*Flag, name string) *Flag {
+	for _, flag := range flags {
+		if value, found := parsedValue(flag.Value); found && value.flag == flag && flagName(flag) == name {
+			return flag
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package sflags
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	cfg := &struct {
+		Port int `long:"port"`
+		Log  struct {
+			Level string `long:"level" choice:"info" choice:"debug"`
+		} `flag:"log"`
+		Run struct {
+			Dry bool `long:"dry"`
+		} `command:"run"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, Set(flags, "port", "8080"))
+	assert.Equal(t, 8080, cfg.Port)
+
+	require.NoError(t, Set(flags, "log-level", "debug"))
+	assert.Equal(t, "debug", cfg.Log.Level)
+
+	assert.Equal(t, []string{"port", "log-level"}, Changed(flags))
+
+	err = Set(flags, "port", "eighty")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Contains(t, err.Error(), `"eighty" for "port" flag`)
+	assert.Equal(t, 8080, cfg.Port)
+
+	assert.Error(t, Set(flags, "log-level", "trace"))
+	assert.Equal(t, "debug", cfg.Log.Level)
+
+	assert.ErrorIs(t, Set(flags, "dry", "true"), ErrUnknownFlag)
+	assert.ErrorIs(t, Set(flags, "level", "info"), ErrUnknownFlag)
+	assert.ErrorIs(t, Set(nil, "port", "80"), ErrUnknownFlag)
+}
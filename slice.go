@@ -0,0 +1,94 @@
+package sflags
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+)
+
+// sliceValue sets the values of a slice flag split on a custom separator
+// (`sep` tag), and might replace its previous values instead of appending
+// to them (`accumulate:"false"`). The first values always replace the
+// default ones, like for all slice flags.
+type sliceValue struct {
+	Value
+	field      reflect.Value
+	sep        string
+	splits     bool
+	accumulate bool
+	changed    bool
+}
+
+func (v *sliceValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+func (v *sliceValue) IsCumulative() bool { return true }
+
+// Set parses each of the values onto a new element of the slice.
+func (v *sliceValue) Set(raw string) error {
+	field := reflect.Indirect(v.field)
+
+	vals := []string{raw}
+	if v.splits {
+		vals = strings.Split(raw, v.sep)
+	}
+
+	elems := reflect.MakeSlice(field.Type(), 0, len(vals))
+
+	for _, val := range vals {
+		elem := reflect.New(field.Type().Elem()).Elem()
+
+		_, value := parseVal(elem)
+		if err := value.Set(val); err != nil {
+			return err
+		}
+
+		elems = reflect.Append(elems, elem)
+	}
+
+	if v.changed && v.accumulate {
+		elems = reflect.AppendSlice(field, elems)
+	}
+
+	field.Set(elems)
+	v.changed = true
+
+	return nil
+}
+
+// parseSlice wraps the value of a slice flag if its separator or its
+// accumulation is set in its tag, and if the slice elements can be parsed.
+func parseSlice(val Value, value reflect.Value, mtag tag.MultiTag) Value {
+	field := reflect.Indirect(value)
+	if field.Kind() != reflect.Slice || isValue(value) {
+		return val
+	}
+
+	_, sepSet := mtag.Get("sep")
+	_, accumulateSet := mtag.Get("accumulate")
+
+	if !sepSet && !accumulateSet {
+		return val
+	}
+
+	if _, elem := parseVal(reflect.New(field.Type().Elem()).Elem()); elem == nil {
+		return val
+	}
+
+	sep, splits := convert.Separator(mtag, ",")
+
+	return &sliceValue{
+		Value:      val,
+		field:      field,
+		sep:        sep,
+		splits:     splits,
+		accumulate: convert.Accumulates(mtag),
+	}
+}
@@ -0,0 +1,47 @@
+package sflags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStruct_SliceSeparators(t *testing.T) {
+	cfg := &struct {
+		Tags      []string        `long:"tags"`
+		Exprs     []string        `long:"exprs" sep:"none"`
+		Paths     []string        `long:"paths" sep:":"`
+		Ports     []int           `long:"ports" accumulate:"false"`
+		Intervals []time.Duration `long:"intervals" sep:";"`
+	}{
+		Tags:  []string{"default"},
+		Ports: []int{80},
+	}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 5)
+
+	require.NoError(t, flags[0].Value.Set("a,b"))
+	require.NoError(t, flags[0].Value.Set("c"))
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Tags)
+
+	require.NoError(t, flags[1].Value.Set("a,b"))
+	require.NoError(t, flags[1].Value.Set("c"))
+	assert.Equal(t, []string{"a,b", "c"}, cfg.Exprs)
+
+	require.NoError(t, flags[2].Value.Set("/bin:/usr/bin"))
+	assert.Equal(t, []string{"/bin", "/usr/bin"}, cfg.Paths)
+
+	require.NoError(t, flags[3].Value.Set("8080,8081"))
+	assert.Equal(t, []int{8080, 8081}, cfg.Ports)
+	require.NoError(t, flags[3].Value.Set("9090"))
+	assert.Equal(t, []int{9090}, cfg.Ports)
+	assert.Error(t, flags[3].Value.Set("http"))
+	assert.Equal(t, []int{9090}, cfg.Ports, "invalid values leave the slice unchanged")
+
+	require.NoError(t, flags[4].Value.Set("1s;1m"))
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, cfg.Intervals)
+}
@@ -0,0 +1,66 @@
+package sflags
+
+import (
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/octago/sflags/internal/convert"
+)
+
+// Stdin sets the reader from which the values of flags given as their stdin
+// placeholder (`stdin:"-"`) are read, instead of os.Stdin. Such flags read
+// all of the input: []byte fields are set to it as is, other slices get one
+// value per line, and other fields the input without its final newline.
+func Stdin(in io.Reader) OptFunc {
+	return func(opt *opts) { opt.stdin = in }
+}
+
+// stdinValue reads the value of a flag from the standard input,
+// when it is given the stdin placeholder of the flag.
+type stdinValue struct {
+	Value
+	field       reflect.Value
+	placeholder string
+	in          io.Reader
+}
+
+func (v *stdinValue) Set(val string) error {
+	if val != v.placeholder {
+		return v.Value.Set(val)
+	}
+
+	in := v.in
+	if in == nil {
+		in = os.Stdin
+	}
+
+	vals, err := convert.Stdin(in, v.field)
+	if err != nil {
+		return err
+	}
+
+	for _, val := range vals {
+		if err := v.Value.Set(val); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *stdinValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+
+	return nil
+}
+
+func (v *stdinValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package sflags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stdinCfg struct {
+	Text  string   `long:"text" stdin:"-"`
+	Data  []byte   `long:"data" stdin:"-"`
+	Lines []string `long:"line" stdin:"-" sep:"none"`
+}
+
+func TestStdinValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		flag   int
+		word   string
+		expCfg stdinCfg
+	}{
+		{name: "String", input: "hello\nworld\n", flag: 0, word: "-", expCfg: stdinCfg{Text: "hello\nworld"}},
+		{name: "Bytes", input: "raw\n", flag: 1, word: "-", expCfg: stdinCfg{Data: []byte("raw\n")}},
+		{name: "Slice", input: "a\r\nb\n", flag: 2, word: "-", expCfg: stdinCfg{Lines: []string{"a", "b"}}},
+		{name: "Other word", input: "unread", flag: 0, word: "--", expCfg: stdinCfg{Text: "--"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &stdinCfg{}
+			flags, err := ParseStruct(cfg, Stdin(strings.NewReader(test.input)))
+			require.NoError(t, err)
+			require.Len(t, flags, 3)
+
+			require.NoError(t, flags[test.flag].Value.Set(test.word))
+			assert.Equal(t, test.expCfg, *cfg)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package sflags
+
+import (
+	"reflect"
+
+	"github.com/octago/sflags/internal/tag"
+)
+
+// RequireTags makes ParseStruct fail with ErrUntagged when an exported field of
+// the struct (or of its groups of options and subcommands) has no tags, instead
+// of ignoring it: each field must be either tagged as an option, or explicitly
+// excluded with `flag:"-"` or `no-flag:"true"`. Fields of positional arguments
+// need no tags.
+func RequireTags() OptFunc {
+	return func(opt *opts) { opt.requireTags = true }
+}
+
+// CheckTags returns ErrUntagged errors (as Errors if several) for the exported
+// fields of data, a pointer to a struct, which have no tags (see RequireTags).
+func CheckTags(data interface{}) error {
+	typ := reflect.TypeOf(data)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return ErrNotPointerToStruct
+	}
+
+	return untaggedFields(typ.Elem(), "", map[reflect.Type]bool{}).Err()
+}
+
+// untaggedFields returns the errors of the untagged fields of a struct type.
+func untaggedFields(typ reflect.Type, path string, seen map[reflect.Type]bool) Errors {
+	if seen[typ] {
+		return nil
+	}
+
+	seen[typ] = true
+
+	var errs Errors
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if (field.PkgPath != "" && !field.Anonymous) || tag.Ignored(field.Type) {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Tag == "" && !field.Anonymous {
+			errs = append(errs, newError(ErrUntagged, path+field.Name))
+
+			continue
+		}
+
+		mtag, skip, _ := tag.GetFieldTag(field)
+		if flagTag, _ := mtag.Get(defaultFlagTag); skip || flagTag == "-" {
+			continue
+		}
+
+		// Positional arguments need no tags.
+		if _, isArgs := mtag.Get("positional-args"); isArgs {
+			continue
+		}
+
+		if fieldType.Kind() == reflect.Struct && isGroup(fieldType) {
+			errs = append(errs, untaggedFields(fieldType, path+field.Name+".", seen)...)
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,54 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strictGroup struct {
+	Level int `long:"level"`
+	Debug bool
+}
+
+type strictArgs struct {
+	Target string
+}
+
+type strictCfg struct {
+	Name     string      `long:"name"`
+	Group    strictGroup `group:"logs"`
+	Args     strictArgs  `positional-args:"yes"`
+	Excluded string      `flag:"-"`
+	Hidden   string      `no-flag:"true"`
+	Cache    map[string]string
+	internal string
+}
+
+func TestRequireTags(t *testing.T) {
+	cfg := &strictCfg{}
+
+	// Untagged fields are ignored by default.
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, flags)
+
+	_, err = ParseStruct(cfg, RequireTags())
+	require.ErrorIs(t, err, ErrUntagged)
+
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "untagged field: Group.Debug")
+	assert.EqualError(t, errs[1], "untagged field: Cache")
+
+	tagged := &struct {
+		Name     string `long:"name"`
+		Excluded string `flag:"-"`
+	}{}
+	_, err = ParseStruct(tagged, RequireTags())
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, CheckTags(strictCfg{}), ErrNotPointerToStruct)
+}
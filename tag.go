@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/octago/sflags/internal/tag"
+	"github.com/octago/sflags/internal/validation"
 )
 
 // parseFlagTag now also handles some of the tags used in jessevdk/go-flags.
@@ -20,6 +21,22 @@ func parseFlagTag(field reflect.StructField, opt opts) (*Flag, *tag.MultiTag) {
 		return nil, nil
 	}
 
+	// Fields receiving the remaining (or passed through) command-line arguments are not flags.
+	remaining, _ := flagTags.Get("remaining")
+	passthrough, _ := flagTags.Get("passthrough-args")
+
+	if remaining != "" || passthrough != "" {
+		return nil, nil
+	}
+
+	// Tags might be inherited from the group of options of the field.
+	opt.applyInherited(&flagTags)
+	flag.Group = opt.group
+
+	if rules, _ := flagTags.Get("validate"); rules != "" {
+		flag.Validators = validation.Rules(rules)
+	}
+
 	sflagsTag, _ := flagTags.Get(opt.flagTag)
 	sflagValues := strings.Split(sflagsTag, ",")
 
@@ -41,13 +58,37 @@ func parseFlagTag(field reflect.StructField, opt opts) (*Flag, *tag.MultiTag) {
 		flag.Usage = desc
 	}
 
+	// Deprecation, possibly with a message.
+	if msg, deprecated := tag.Deprecation(flagTags); deprecated {
+		flag.Deprecated = true
+		flag.DeprecatedMsg = msg
+	}
+
 	// Requirements
 	if required, _ := flagTags.Get("required"); !isStringFalsy(required) {
 		flag.Required = true
 	}
 
-	// flag.DefValue = flagTags.GetMany("default")
-	flag.Choices = flagTags.GetMany("choice")
+	// Hidden options, also set by the legacy sflags tag.
+	if hidden, _ := flagTags.Get("hidden"); !isStringFalsy(hidden) {
+		flag.Hidden = true
+	}
+
+	// Options of commands also available to their subcommands.
+	if persistent, _ := flagTags.Get("persistent"); !isStringFalsy(persistent) {
+		flag.Persistent = true
+	}
+
+	// Sensitive values
+	if secret, _ := flagTags.Get("secret"); !isStringFalsy(secret) {
+		flag.Secret = true
+	}
+
+	flag.Choices = validation.ParseChoices(flagTags)
+	flag.Exclusive, _ = flagTags.Get("xor")
+	flag.RequiredWith, _ = flagTags.Get("required-with")
+	flag.Example, _ = flagTags.Get("example-value")
+	flag.Display, _ = flagTags.Get("display")
 	flag.OptionalValue = flagTags.GetMany("optional-value")
 
 	if opt.prefix != "" && !ignoreFlagPrefix {
@@ -0,0 +1,352 @@
+package sflags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
+	"github.com/octago/sflags/internal/validation"
+)
+
+// ValidatorFunc validates a value after it has been converted onto its struct
+// field, as opposed to ValidateFunc, which validates the raw command-line string.
+// The param is the parameter of the validator in the `validate` tag, eg. "1" for
+// a `validate:"min=1"` tag.
+type ValidatorFunc func(value reflect.Value, param string) error
+
+// RegisterValidator registers a named validator, to be used in `validate` struct
+// tags of both option flags and positional arguments, like `validate:"name=param"`.
+// Registering a validator with the name of an existing one replaces it.
+//
+// Builtin validators are:
+//   - min=N, max=N: numbers bounds, or strings minimum/maximum length.
+//   - len=N: exact string length.
+//   - regexp=EXPR: the value must match a regular expression.
+//   - oneof=a b c: the value must be one of a space-separated list.
+//   - nonzero: the value cannot be its type zero value.
+//
+// When validating slices, each of their elements is validated in turn.
+func RegisterValidator(name string, fn ValidatorFunc) {
+	validation.Register(name, validation.Func(fn))
+}
+
+// Choices declares a named set of allowed values, which can be shared by
+// several flags and positional arguments with a `choices-ref:"name"` tag,
+// for both validation and completion. Sets are resolved when structs are
+// parsed, so they must be declared beforehand (eg. in an init function).
+// Declaring a set with the name of an existing one replaces it.
+func Choices(name string, choices ...string) {
+	validation.RegisterChoices(name, choices)
+}
+
+// choiceValidator returns a function checking that a flag value is one of the
+// allowed choices. For slice fields, each value is checked once split on the
+// separator of the field (see the `sep` tag).
+func choiceValidator(choices []string, field reflect.Value, mtag tag.MultiTag) func(val string) error {
+	isSlice := reflect.Indirect(field).Kind() == reflect.Slice
+
+	return func(val string) error {
+		values := []string{val}
+		if isSlice {
+			values = convert.Split(val, mtag, ",")
+		}
+
+		for _, value := range values {
+			if err := validation.CheckChoice(value, choices); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// validatedValue runs the validators specified in a `validate` tag
+// against a struct field, after the flag value has been set on it.
+// The field is restored to its previous value if validation fails.
+type validatedValue struct {
+	Value
+	field reflect.Value
+	rules string
+}
+
+func (v *validatedValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+func (v *validatedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+func (v *validatedValue) Set(val string) error {
+	// Flag values of pointer fields are set on the pointed value.
+	target := v.field
+	for target.Kind() == reflect.Ptr && !target.IsNil() {
+		target = target.Elem()
+	}
+
+	previous := reflect.New(target.Type()).Elem()
+	previous.Set(target)
+
+	if err := v.Value.Set(val); err != nil {
+		return err
+	}
+
+	if err := validation.Check(v.field, v.rules); err != nil {
+		target.Set(previous)
+
+		return err
+	}
+
+	return nil
+}
+
+// Choice is a value allowed for a flag or a positional argument,
+// with an optional description used in completions.
+type Choice = validation.Choice
+
+// ChoiceProvider is implemented by field types whose allowed values are only known
+// at runtime (eg. from a server or the filesystem), as opposed to the ones declared
+// with `choices` tags. Choices are checked when values are set, and completed.
+type ChoiceProvider interface {
+	Choices() []Choice
+}
+
+// providerValidator returns a function checking that a
+// flag value is one of the choices of a ChoiceProvider field.
+func providerValidator(field reflect.Value) func(val string) error {
+	return func(val string) error {
+		provider, ok := validation.Provider(choiceElem(field))
+		if !ok {
+			return nil
+		}
+
+		return validation.CheckChoice(val, validation.ChoiceValues(provider.Choices()))
+	}
+}
+
+// isChoiceProvider returns true if the field provides its own choices.
+func isChoiceProvider(field reflect.Value) bool {
+	_, ok := validation.Provider(choiceElem(field))
+
+	return ok
+}
+
+// choiceElem returns the value of a field on which to call Choices():
+// the field itself if possible, or a zero value for nil pointers.
+func choiceElem(field reflect.Value) reflect.Value {
+	elem := field
+	for elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			return reflect.New(elem.Type().Elem()).Elem()
+		}
+
+		elem = elem.Elem()
+	}
+
+	return elem
+}
+
+// ValidationWorkers sets the maximum number of fields validated concurrently
+// by Validate. It is the number of usable CPUs (GOMAXPROCS) by default.
+func ValidationWorkers(n int) OptFunc { return func(opt *opts) { opt.workers = n } }
+
+// ValidationErrors are all the errors found by Validate, in the order of the struct fields.
+type ValidationErrors []error
+
+// Error returns all the validation errors, one per line.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Is returns true if any of the validation errors matches target.
+func (e ValidationErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the values of all the fields of cfg, a pointer to a struct, against
+// their `validate` tags and their allowed choices (from tags or ChoiceProvider types),
+// as opposed to the values being checked when set on the command line. This is useful
+// for values loaded from elsewhere, like configuration files.
+//
+// Fields are validated concurrently (see ValidationWorkers), so custom validators and
+// choice providers must be safe for concurrent use. All errors are returned at once as
+// ValidationErrors, in the order of the struct fields. Nested groups of options are
+// validated recursively, and choices are not checked on fields left to their zero value.
+func Validate(cfg interface{}, optFuncs ...OptFunc) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotPointerToStruct
+	}
+
+	opt := defOpts().apply(optFuncs...)
+
+	jobs := validationJobs(v.Elem(), opt)
+	errs := make([]error, len(jobs))
+
+	workers := opt.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers && i < len(jobs); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range indexes {
+				errs[index] = jobs[index].run()
+			}
+		}()
+	}
+
+	for index := range jobs {
+		indexes <- index
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	var validationErrs ValidationErrors
+
+	for _, err := range errs {
+		if err != nil {
+			validationErrs = append(validationErrs, err)
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	return nil
+}
+
+// validationJob validates a single struct field.
+type validationJob struct {
+	name    string
+	field   reflect.Value
+	rules   string
+	choices []string
+}
+
+// validationJobs returns the validation jobs of all fields of a struct.
+func validationJobs(val reflect.Value, opt opts) []validationJob {
+	var jobs []validationJob
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		mtag, _, _ := tag.GetFieldTag(field)
+		if _, isCmd := mtag.Get("command"); isCmd {
+			continue
+		}
+
+		fieldVal := val.Field(i)
+
+		// Recurse into groups of options, whose fields inherit their tags.
+		if inner := reflect.Indirect(fieldVal); inner.Kind() == reflect.Struct && !isValue(fieldVal) {
+			jobs = append(jobs, validationJobs(inner, opt.inherit(mtag))...)
+
+			continue
+		}
+
+		opt.applyInherited(&mtag)
+
+		rules, _ := mtag.Get("validate")
+		choices := validation.ParseChoices(mtag)
+
+		if _, ok := validation.Provider(providerElem(fieldVal)); !ok && rules == "" && len(choices) == 0 {
+			continue
+		}
+
+		jobs = append(jobs, validationJob{
+			name:    fieldName(field, opt),
+			field:   fieldVal,
+			rules:   rules,
+			choices: choices,
+		})
+	}
+
+	return jobs
+}
+
+// run checks the field choices and validators.
+func (j validationJob) run() error {
+	if !j.field.IsZero() {
+		choices := j.choices
+		if provider, ok := validation.Provider(providerElem(j.field)); ok {
+			choices = append(choices, validation.ChoiceValues(provider.Choices())...)
+		}
+
+		for _, value := range choiceStrings(j.field) {
+			if err := validation.CheckChoice(value, choices); err != nil {
+				return newError(ErrInvalidValue, fmt.Sprintf("%s: %s", j.name, err))
+			}
+		}
+	}
+
+	if err := validation.Check(j.field, j.rules); err != nil {
+		return newError(ErrInvalidValue, fmt.Sprintf("%s: %s", j.name, err))
+	}
+
+	return nil
+}
+
+// providerElem returns the value on which to call Choices(),
+// including a zero element for slices and arrays.
+func providerElem(field reflect.Value) reflect.Value {
+	elem := choiceElem(field)
+	if elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array {
+		return reflect.New(elem.Type().Elem()).Elem()
+	}
+
+	return elem
+}
+
+// choiceStrings returns the string representation of a field
+// value, or of each of its elements for slices and arrays.
+func choiceStrings(field reflect.Value) []string {
+	elem := reflect.Indirect(field)
+	if elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array {
+		values := make([]string, 0, elem.Len())
+		for i := 0; i < elem.Len(); i++ {
+			values = append(values, valueString(elem.Index(i)))
+		}
+
+		return values
+	}
+
+	return []string{valueString(field)}
+}
@@ -0,0 +1,119 @@
+package sflags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTag(t *testing.T) {
+	RegisterValidator("even", func(value reflect.Value, _ string) error {
+		if value.Int()%2 != 0 {
+			return errors.New("value must be even")
+		}
+
+		return nil
+	})
+
+	cfg := &struct {
+		Port  int      `long:"port" validate:"min=1,max=65535"`
+		Name  string   `long:"name" validate:"regexp=^[a-z]{2,8}$"`
+		Tags  []string `long:"tag" validate:"max=3"`
+		Even  *int     `long:"even" validate:"even"`
+		Level string   `long:"level" validate:"oneof=debug info"`
+		Bad   string   `long:"bad" validate:"unknown"`
+	}{Port: 80}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 6)
+
+	tests := []struct {
+		flag   int
+		value  string
+		expErr string
+	}{
+		{flag: 0, value: "8080"},
+		{flag: 0, value: "0", expErr: "0 must be greater than or equal to 1"},
+		{flag: 0, value: "70000", expErr: "70000 must be lower than or equal to 65535"},
+		{flag: 0, value: "port", expErr: "invalid syntax"},
+		{flag: 1, value: "bob"},
+		{flag: 1, value: "Bob", expErr: `"Bob" does not match ^[a-z]{2,8}$`},
+		{flag: 2, value: "abc"},
+		{flag: 2, value: "abcd", expErr: `"abcd" must be at most 3 characters long`},
+		{flag: 3, value: "4"},
+		{flag: 3, value: "5", expErr: "value must be even"},
+		{flag: 4, value: "info"},
+		{flag: 4, value: "warn", expErr: `"warn" must be one of debug, info`},
+		{flag: 5, value: "value", expErr: `unknown validator: "unknown"`},
+	}
+
+	for _, test := range tests {
+		err := flags[test.flag].Value.Set(test.value)
+		if test.expErr == "" {
+			assert.NoError(t, err, test.value)
+
+			continue
+		}
+
+		if assert.Error(t, err, test.value) {
+			assert.True(t, strings.Contains(err.Error(), test.expErr), err.Error())
+		}
+	}
+
+	// Failed validations leave the field unchanged.
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, "bob", cfg.Name)
+	assert.Equal(t, []string{"abc"}, cfg.Tags)
+	assert.Equal(t, 4, *cfg.Even)
+	assert.Equal(t, "info", cfg.Level)
+}
+
+func TestValidate(t *testing.T) {
+	type config struct {
+		Port    int      `long:"port" validate:"min=1,max=65535"`
+		Format  string   `long:"format" choices:"json yaml"`
+		Region  region   `long:"region"`
+		Regions []region `long:"regions"`
+		Unset   string   `long:"unset" choices:"a b"`
+		Log     struct {
+			Level string `long:"level" validate:"oneof=debug info"`
+		} `group:"log"`
+		Run struct {
+			Jobs int `long:"jobs" validate:"min=1"`
+		} `command:"run"`
+	}
+
+	valid := &config{Port: 80, Format: "json", Region: "eu-west", Regions: []region{"us-east"}}
+	valid.Log.Level = "info"
+
+	for _, workers := range []int{0, 1, 4} {
+		require.NoError(t, Validate(valid, ValidationWorkers(workers)))
+	}
+
+	invalid := &config{Port: 0, Format: "xml", Region: "ap-south", Regions: []region{"eu-west", "mars"}}
+	invalid.Log.Level = "trace"
+
+	for _, workers := range []int{0, 1, 2, 16} {
+		err := Validate(invalid, ValidationWorkers(workers))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidValue))
+
+		var validationErrs ValidationErrors
+		require.True(t, errors.As(err, &validationErrs))
+		require.Len(t, validationErrs, 5, fmt.Sprintf("%d workers", workers))
+
+		assert.EqualError(t, validationErrs[0], "invalid value: port: 0 must be greater than or equal to 1")
+		assert.EqualError(t, validationErrs[1], `invalid value: format: invalid choice "xml": must be one of json, yaml`)
+		assert.EqualError(t, validationErrs[2], `invalid value: region: invalid choice "ap-south": must be one of eu-west, us-east`)
+		assert.EqualError(t, validationErrs[3], `invalid value: regions: invalid choice "mars": must be one of eu-west, us-east`)
+		assert.EqualError(t, validationErrs[4], `invalid value: level: "trace" must be one of debug, info`)
+	}
+
+	assert.Equal(t, ErrNotPointerToStruct, Validate(config{}))
+}
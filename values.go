@@ -3,10 +3,19 @@ package sflags
 //go:generate go run ./cmd/genvalues/main.go
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
+	"math"
 	"net"
+	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/octago/sflags/internal/convert"
+	"github.com/octago/sflags/internal/tag"
 )
 
 // Value is the interface to the dynamic value stored in v flag.
@@ -135,6 +144,684 @@ func (v Counter) IsCumulative() bool { return true }
 // Type returns `count` for Counter, it's mostly for pflag compatibility.
 func (v Counter) Type() string { return "count" }
 
+// TriBool is a boolean which can also be left unset, for "auto unless
+// specified" semantics without using pointers to booleans. It accepts
+// the values "true", "false" and "auto" (unset), and can be used without
+// value, like booleans (eg. --color is equivalent to --color=true).
+// Implements Value, Getter, BoolFlag interfaces.
+type TriBool int8
+
+const (
+	// TriAuto is the unset (zero) value of a TriBool.
+	TriAuto TriBool = iota
+	// TriTrue is the true value of a TriBool.
+	TriTrue
+	// TriFalse is the false value of a TriBool.
+	TriFalse
+)
+
+var _ BoolFlag = (*TriBool)(nil)
+
+// Set method parses string from command line.
+func (v *TriBool) Set(s string) error {
+	switch s {
+	case "auto":
+		*v = TriAuto
+		return nil
+	case "":
+		s = "true"
+	}
+	parsed, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("invalid value %q, must be true, false or auto", s)
+	}
+	if parsed {
+		*v = TriTrue
+	} else {
+		*v = TriFalse
+	}
+	return nil
+}
+
+// Get method returns inner value for TriBool.
+func (v TriBool) Get() interface{} { return v }
+
+// IsBoolFlag returns true, because TriBool might be used without value.
+func (v TriBool) IsBoolFlag() bool { return true }
+
+// IsSet returns true if the value is either true or false.
+func (v TriBool) IsSet() bool { return v != TriAuto }
+
+// Bool returns the boolean value, or def if the value is unset.
+func (v TriBool) Bool(def bool) bool {
+	switch v {
+	case TriTrue:
+		return true
+	case TriFalse:
+		return false
+	default:
+		return def
+	}
+}
+
+// String returns string representation of TriBool.
+func (v TriBool) String() string {
+	switch v {
+	case TriTrue:
+		return "true"
+	case TriFalse:
+		return "false"
+	default:
+		return "auto"
+	}
+}
+
+// Type returns `tribool` for TriBool.
+func (v TriBool) Type() string { return "tribool" }
+
+// DurationOrOff is a duration which can also be disabled, with the values
+// "off", "none" or "0", as is common for timeouts or TTLs. Its zero value is
+// the disabled state, and negative durations are refused.
+// Implements Value, Getter interfaces.
+type DurationOrOff time.Duration
+
+// Set method parses string from command line.
+func (v *DurationOrOff) Set(s string) error {
+	switch s {
+	case "off", "none", "0":
+		*v = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid value %q, must be a duration or off", s)
+	}
+	if parsed < 0 {
+		return fmt.Errorf("invalid value %q, duration cannot be negative", s)
+	}
+	*v = DurationOrOff(parsed)
+	return nil
+}
+
+// Get method returns inner value for DurationOrOff.
+func (v DurationOrOff) Get() interface{} { return time.Duration(v) }
+
+// IsOff returns true if the duration is disabled.
+func (v DurationOrOff) IsOff() bool { return v == 0 }
+
+// Duration returns the duration, which is zero if disabled.
+func (v DurationOrOff) Duration() time.Duration { return time.Duration(v) }
+
+// String returns string representation of DurationOrOff.
+func (v DurationOrOff) String() string {
+	if v.IsOff() {
+		return "off"
+	}
+	return time.Duration(v).String()
+}
+
+// Type returns `durationOrOff` for DurationOrOff.
+func (v DurationOrOff) Type() string { return "durationOrOff" }
+
+// Ratio is a float64 between 0 and 1, which can be given as a percentage
+// (50%), a decimal number (0.5) or a fraction (1/2), as is common for
+// sampling rates, quotas or resources. Values out of range are refused.
+// Implements Value, Getter interfaces.
+type Ratio float64
+
+// Set method parses string from command line.
+func (v *Ratio) Set(s string) error {
+	var parsed float64
+	var err error
+	switch {
+	case strings.HasSuffix(s, "%"):
+		parsed, err = strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		parsed /= 100
+	case strings.Contains(s, "/"):
+		parsed, err = parseFraction(s)
+	default:
+		parsed, err = strconv.ParseFloat(s, 64)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid ratio %q, use 50%%, 0.5 or 1/2", s)
+	}
+	// NaN is neither below 0 nor above 1, but not between them either.
+	if math.IsNaN(parsed) || !(parsed >= 0 && parsed <= 1) {
+		return fmt.Errorf("invalid ratio %q, must be between 0 and 1 (0%% and 100%%)", s)
+	}
+	*v = Ratio(parsed)
+	return nil
+}
+
+// parseFraction parses a fraction like 1/2.
+func parseFraction(s string) (float64, error) {
+	num, den, _ := strings.Cut(s, "/")
+	numerator, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return 0, err
+	}
+	denominator, err := strconv.ParseFloat(strings.TrimSpace(den), 64)
+	if err != nil {
+		return 0, err
+	}
+	if denominator == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return numerator / denominator, nil
+}
+
+// Get method returns inner value for Ratio.
+func (v Ratio) Get() interface{} { return float64(v) }
+
+// Percent returns the ratio as a percentage.
+func (v Ratio) Percent() float64 { return float64(v) * 100 }
+
+// String returns string representation of Ratio.
+func (v Ratio) String() string { return strconv.FormatFloat(float64(v), 'g', -1, 64) }
+
+// Type returns `ratio` for Ratio.
+func (v Ratio) Type() string { return "ratio" }
+
+// Size is a number of bytes, which can be given with a decimal (10MB)
+// or binary (1.5GiB) unit, and is rendered back in human form.
+// Implements Value, Getter interfaces.
+type Size uint64
+
+// sizeUnits are the units of sizes, from the largest to the smallest.
+var sizeUnits = []struct {
+	name  string
+	bytes uint64
+}{
+	{"EiB", 1 << 60}, {"EB", 1e18},
+	{"PiB", 1 << 50}, {"PB", 1e15},
+	{"TiB", 1 << 40}, {"TB", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6},
+	{"KiB", 1 << 10}, {"KB", 1e3},
+	{"B", 1},
+}
+
+// Set method parses string from command line.
+func (v *Size) Set(s string) error {
+	size, err := parseBytes(s)
+	if err != nil {
+		return err
+	}
+	*v = Size(size)
+	return nil
+}
+
+// parseBytes parses a number of bytes with an optional unit.
+// Units are case-insensitive, and K, Ki, M, Mi, etc. are accepted too.
+func parseBytes(s string) (uint64, error) {
+	trimmed := strings.TrimSpace(s)
+	number := strings.TrimRight(trimmed, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ ")
+	unit := strings.ToLower(strings.TrimSpace(trimmed[len(number):]))
+
+	if unit != "" && !strings.HasSuffix(unit, "b") {
+		unit += "b"
+	}
+
+	multiplier := uint64(1)
+	if unit != "" {
+		multiplier = 0
+		for _, sizeUnit := range sizeUnits {
+			if strings.ToLower(sizeUnit.name) == unit {
+				multiplier = sizeUnit.bytes
+			}
+		}
+	}
+
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil || multiplier == 0 || parsed < 0 {
+		return 0, fmt.Errorf("invalid size %q, use a number of bytes with an optional unit (eg. 10MB, 1.5GiB)", s)
+	}
+
+	bytes := parsed * float64(multiplier)
+	if bytes >= math.MaxUint64 {
+		return 0, fmt.Errorf("invalid size %q, value out of range", s)
+	}
+
+	return uint64(bytes), nil
+}
+
+// Get method returns inner value for Size.
+func (v Size) Get() interface{} { return uint64(v) }
+
+// String returns the size with the largest unit it is a multiple of,
+// so that it can be parsed back without any loss of precision.
+func (v Size) String() string {
+	size := uint64(v)
+	if size == 0 {
+		return "0B"
+	}
+	for _, unit := range sizeUnits {
+		if size%unit.bytes == 0 {
+			return strconv.FormatUint(size/unit.bytes, 10) + unit.name
+		}
+	}
+	return strconv.FormatUint(size, 10) + "B"
+}
+
+// Type returns `size` for Size.
+func (v Size) Type() string { return "size" }
+
+// intSizeValue binds a size to an int64 field.
+type intSizeValue struct {
+	value *int64
+}
+
+func (v *intSizeValue) Set(s string) error {
+	size, err := parseBytes(s)
+	if err != nil {
+		return err
+	}
+	if size > math.MaxInt64 {
+		return fmt.Errorf("invalid size %q, value out of range", s)
+	}
+	*v.value = int64(size)
+	return nil
+}
+
+func (v *intSizeValue) Get() interface{} { return *v.value }
+
+func (v *intSizeValue) String() string {
+	if v.value == nil {
+		return Size(0).String()
+	}
+	return Size(*v.value).String()
+}
+
+func (v *intSizeValue) Type() string { return "size" }
+
+// baseValue binds an integer field, signed or not, parsed and
+// printed in the base given by the `base` tag of the field.
+type baseValue struct {
+	value reflect.Value
+	tag   tag.MultiTag
+	base  int
+}
+
+func (v *baseValue) Set(s string) error {
+	switch v.value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := convert.ParseInt(s, v.value.Type().Bits(), v.tag)
+		if err != nil {
+			return err
+		}
+		v.value.SetInt(parsed)
+	default:
+		parsed, err := convert.ParseUint(s, v.value.Type().Bits(), v.tag)
+		if err != nil {
+			return err
+		}
+		v.value.SetUint(parsed)
+	}
+	return nil
+}
+
+func (v *baseValue) Get() interface{} { return v.value.Interface() }
+
+func (v *baseValue) String() string {
+	switch v.value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.value.Int(), v.base)
+	default:
+		return strconv.FormatUint(v.value.Uint(), v.base)
+	}
+}
+
+func (v *baseValue) Type() string { return v.value.Kind().String() }
+
+// === Standard library types
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	urlType  = reflect.TypeOf(url.URL{})
+)
+
+// timeLayout is implemented by time values, for which the
+// parsing layout can be set with the `layout` tag.
+type timeLayout interface {
+	setTimeLayout(layout string)
+}
+
+// parseStdlib returns a value for time.Time and url.URL
+// fields, or slices of them, or nil for any other type.
+func parseStdlib(value reflect.Value) Value {
+	switch value.Type() {
+	case timeType:
+		return &timeValue{value: value.Addr().Interface().(*time.Time)}
+	case urlType:
+		return &urlValue{value: value.Addr().Interface().(*url.URL)}
+	}
+	if value.Kind() == reflect.Slice && isStdlib(value.Type().Elem()) {
+		return &stdlibSliceValue{value: value}
+	}
+	return nil
+}
+
+func isStdlib(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ == timeType || typ == urlType
+}
+
+// -- time.Time Value, parsed with RFC3339 by default.
+type timeValue struct {
+	value  *time.Time
+	layout string
+}
+
+var _ Getter = (*timeValue)(nil)
+
+func (v *timeValue) Set(s string) error {
+	parsed, err := time.Parse(v.getLayout(), s)
+	if err != nil {
+		return err
+	}
+	*v.value = parsed
+	return nil
+}
+
+func (v *timeValue) Get() interface{} { return *v.value }
+
+func (v *timeValue) String() string {
+	if v.value == nil || v.value.IsZero() {
+		return ""
+	}
+	return v.value.Format(v.getLayout())
+}
+
+func (v *timeValue) Type() string { return "time" }
+
+func (v *timeValue) setTimeLayout(layout string) { v.layout = layout }
+
+func (v *timeValue) getLayout() string {
+	if v.layout == "" {
+		return time.RFC3339
+	}
+	return v.layout
+}
+
+// -- url.URL Value.
+type urlValue struct {
+	value *url.URL
+}
+
+var _ Getter = (*urlValue)(nil)
+
+func (v *urlValue) Set(s string) error {
+	parsed, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	*v.value = *parsed
+	return nil
+}
+
+func (v *urlValue) Get() interface{} { return *v.value }
+
+func (v *urlValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+	return v.value.String()
+}
+
+func (v *urlValue) Type() string { return "url" }
+
+// -- Slices of time.Time or url.URL (or pointers to them). As those
+// values might contain commas, they are not split: flags must be repeated.
+type stdlibSliceValue struct {
+	value   reflect.Value
+	layout  string
+	changed bool
+}
+
+var _ RepeatableFlag = (*stdlibSliceValue)(nil)
+
+func (v *stdlibSliceValue) Set(s string) error {
+	elemType := v.value.Type().Elem()
+	elem := reflect.New(elemType).Elem()
+	target := elem
+	if elemType.Kind() == reflect.Ptr {
+		elem.Set(reflect.New(elemType.Elem()))
+		target = elem.Elem()
+	}
+	if err := v.elemValue(target).Set(s); err != nil {
+		return err
+	}
+	if !v.changed {
+		v.value.Set(reflect.MakeSlice(v.value.Type(), 0, 1))
+	}
+	v.value.Set(reflect.Append(v.value, elem))
+	v.changed = true
+	return nil
+}
+
+func (v *stdlibSliceValue) Get() interface{} { return v.value.Interface() }
+
+func (v *stdlibSliceValue) String() string {
+	out := make([]string, 0, v.value.Len())
+	for i := 0; i < v.value.Len(); i++ {
+		out = append(out, v.elemValue(reflect.Indirect(v.value.Index(i))).String())
+	}
+	return "[" + strings.Join(out, ",") + "]"
+}
+
+func (v *stdlibSliceValue) Type() string {
+	elemType := v.value.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	return v.elemValue(reflect.New(elemType).Elem()).Type() + "Slice"
+}
+
+func (v *stdlibSliceValue) IsCumulative() bool { return true }
+
+func (v *stdlibSliceValue) setTimeLayout(layout string) { v.layout = layout }
+
+// elemValue returns the Value of a single element of the slice.
+func (v *stdlibSliceValue) elemValue(elem reflect.Value) Value {
+	val := parseStdlib(elem)
+	if layout, ok := val.(timeLayout); ok {
+		layout.setTimeLayout(v.layout)
+	}
+	return val
+}
+
+// textValue is the value of fields implementing encoding.TextUnmarshaler,
+// and optionally encoding.TextMarshaler for their string representation.
+type textValue struct {
+	value reflect.Value
+}
+
+var _ Getter = (*textValue)(nil)
+
+// Set method unmarshals string from command line.
+func (v *textValue) Set(s string) error {
+	unmarshaler, _ := v.value.Addr().Interface().(encoding.TextUnmarshaler)
+	return unmarshaler.UnmarshalText([]byte(s))
+}
+
+// Get method returns inner value for textValue.
+func (v *textValue) Get() interface{} { return v.value.Interface() }
+
+// String returns the marshaled text of the value, if possible.
+func (v *textValue) String() string {
+	if marshaler, ok := v.value.Addr().Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(text)
+	}
+	if stringer, ok := v.value.Addr().Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprint(v.value.Interface())
+}
+
+// Type returns the name of the value type.
+func (v *textValue) Type() string { return v.value.Type().String() }
+
+// optionalValue is the value of nil pointer fields to basic types, which
+// are only allocated once the flag is set: a nil field means the flag has
+// not been given, as opposed to having been given its type zero value.
+type optionalValue struct {
+	field reflect.Value // The pointer field
+	alloc reflect.Value // The pointer assigned to the field once set
+	value Value         // The value bound to the allocated pointer
+}
+
+var _ Getter = (*optionalValue)(nil)
+
+// Set method parses the value, and assigns it to the field if valid.
+func (v *optionalValue) Set(s string) error {
+	if err := v.value.Set(s); err != nil {
+		return err
+	}
+	v.field.Set(v.alloc)
+	return nil
+}
+
+// Get method returns the field value, nil if the flag has not been set.
+func (v *optionalValue) Get() interface{} {
+	if v.field.IsNil() {
+		return nil
+	}
+	return v.alloc.Elem().Interface()
+}
+
+// String returns an empty string if the flag has not been set.
+func (v *optionalValue) String() string {
+	if v.field.IsNil() {
+		return ""
+	}
+	return v.value.String()
+}
+
+// Type returns the type of the value pointed to.
+func (v *optionalValue) Type() string { return v.value.Type() }
+
+func (v *optionalValue) IsBoolFlag() bool {
+	if boolFlag, casted := v.value.(BoolFlag); casted {
+		return boolFlag.IsBoolFlag()
+	}
+	return false
+}
+
+func (v *optionalValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+	return false
+}
+
+// negatedValue is the value of the --no-<flag> counterpart
+// of a negatable boolean flag, setting the opposite value.
+type negatedValue struct {
+	Value
+}
+
+var _ BoolFlag = (*negatedValue)(nil)
+
+// Set method sets the opposite of the parsed boolean.
+func (v *negatedValue) Set(s string) error {
+	if s == "" {
+		s = "true"
+	}
+	parsed, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	return v.Value.Set(strconv.FormatBool(!parsed))
+}
+
+// String returns the opposite of the negated value, if it is set.
+func (v *negatedValue) String() string {
+	parsed, err := strconv.ParseBool(v.Value.String())
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatBool(!parsed)
+}
+
+// IsBoolFlag returns true, negated flags being used without value.
+func (v *negatedValue) IsBoolFlag() bool { return true }
+
+// Type returns `bool` for negatedValue.
+func (v *negatedValue) Type() string { return "bool" }
+
+// boolFlagValue wraps the value of a boolean flag. The flag packages consider
+// any value with an IsBoolFlag method as boolean when printing defaults, so
+// the other wrappers do not have one, and this one is only used for values
+// which really are boolean flags.
+type boolFlagValue struct {
+	Value
+}
+
+// IsBoolFlag returns true, the wrapped value being a boolean flag.
+func (v *boolFlagValue) IsBoolFlag() bool { return true }
+
+// String returns the inner value as a string, or an empty string for
+// the zero values built by the flag package to print default values.
+func (v *boolFlagValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+	return ""
+}
+
+func (v *boolFlagValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+	return nil
+}
+
+func (v *boolFlagValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+	return false
+}
+
+// isBoolFlag returns true if a value is a boolean flag.
+func isBoolFlag(val Value) bool {
+	boolFlag, casted := val.(BoolFlag)
+	return casted && boolFlag.IsBoolFlag()
+}
+
+// === Map values
+
+// keyValueDelimiter is implemented by map values, for which the delimiter
+// between keys and values can be set with the `key-value-delimiter` tag.
+type keyValueDelimiter interface {
+	setKeyValueDelimiter(delimiter string)
+}
+
+// splitKeyValue splits a map flag value into its key and value. Without
+// a delimiter, the first of either '=' or ':' is used (eg. key=val, key:val).
+func splitKeyValue(s, delimiter string) ([]string, error) {
+	if delimiter != "" {
+		ss := strings.SplitN(s, delimiter, 2)
+		if len(ss) < 2 {
+			return nil, fmt.Errorf("invalid map flag syntax, use -map=key1%sval1", delimiter)
+		}
+
+		return ss, nil
+	}
+
+	if idx := strings.IndexAny(s, "=:"); idx != -1 {
+		return []string{s[:idx], s[idx+1:]}, nil
+	}
+
+	return nil, errors.New("invalid map flag syntax, use -map=key1:val1")
+}
+
 // === Some patches for generated flags
 
 // IsBoolFlag returns true. boolValue implements BoolFlag interface.
@@ -165,3 +852,32 @@ func parseIPNet(s string) (net.IPNet, error) {
 	}
 	return *ipNet, nil
 }
+
+// convertedValue returns the errors of the value it wraps, which
+// fail to convert the words given to the flag, as *ConvertError.
+type convertedValue struct {
+	Value
+	name string
+	typ  reflect.Type
+}
+
+func (v *convertedValue) Set(s string) error {
+	if err := v.Value.Set(s); err != nil {
+		return &ConvertError{Field: v.name, Word: s, TargetType: v.typ, Err: err}
+	}
+	return nil
+}
+
+func (v *convertedValue) Get() interface{} {
+	if getter, casted := v.Value.(Getter); casted {
+		return getter.Get()
+	}
+	return nil
+}
+
+func (v *convertedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+	return false
+}
@@ -4,7 +4,6 @@ package sflags
 
 import (
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -640,7 +639,8 @@ func (v *stringSliceValue) IsCumulative() bool {
 
 // -- stringStringMapValue.
 type stringStringMapValue struct {
-	value *map[string]string
+	value     *map[string]string
+	delimiter string
 }
 
 var (
@@ -656,9 +656,9 @@ func newStringStringMapValue(m *map[string]string) *stringStringMapValue {
 }
 
 func (v *stringStringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -694,9 +694,14 @@ func (v *stringStringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringStringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intStringMapValue.
 type intStringMapValue struct {
-	value *map[int]string
+	value     *map[int]string
+	delimiter string
 }
 
 var (
@@ -712,9 +717,9 @@ func newIntStringMapValue(m *map[int]string) *intStringMapValue {
 }
 
 func (v *intStringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -755,9 +760,14 @@ func (v *intStringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intStringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8StringMapValue.
 type int8StringMapValue struct {
-	value *map[int8]string
+	value     *map[int8]string
+	delimiter string
 }
 
 var (
@@ -773,9 +783,9 @@ func newInt8StringMapValue(m *map[int8]string) *int8StringMapValue {
 }
 
 func (v *int8StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -816,9 +826,14 @@ func (v *int8StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16StringMapValue.
 type int16StringMapValue struct {
-	value *map[int16]string
+	value     *map[int16]string
+	delimiter string
 }
 
 var (
@@ -834,9 +849,9 @@ func newInt16StringMapValue(m *map[int16]string) *int16StringMapValue {
 }
 
 func (v *int16StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -877,9 +892,14 @@ func (v *int16StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32StringMapValue.
 type int32StringMapValue struct {
-	value *map[int32]string
+	value     *map[int32]string
+	delimiter string
 }
 
 var (
@@ -895,9 +915,9 @@ func newInt32StringMapValue(m *map[int32]string) *int32StringMapValue {
 }
 
 func (v *int32StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -938,9 +958,14 @@ func (v *int32StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64StringMapValue.
 type int64StringMapValue struct {
-	value *map[int64]string
+	value     *map[int64]string
+	delimiter string
 }
 
 var (
@@ -956,9 +981,9 @@ func newInt64StringMapValue(m *map[int64]string) *int64StringMapValue {
 }
 
 func (v *int64StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -999,9 +1024,14 @@ func (v *int64StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintStringMapValue.
 type uintStringMapValue struct {
-	value *map[uint]string
+	value     *map[uint]string
+	delimiter string
 }
 
 var (
@@ -1017,9 +1047,9 @@ func newUintStringMapValue(m *map[uint]string) *uintStringMapValue {
 }
 
 func (v *uintStringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1060,9 +1090,14 @@ func (v *uintStringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintStringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8StringMapValue.
 type uint8StringMapValue struct {
-	value *map[uint8]string
+	value     *map[uint8]string
+	delimiter string
 }
 
 var (
@@ -1078,9 +1113,9 @@ func newUint8StringMapValue(m *map[uint8]string) *uint8StringMapValue {
 }
 
 func (v *uint8StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1121,9 +1156,14 @@ func (v *uint8StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16StringMapValue.
 type uint16StringMapValue struct {
-	value *map[uint16]string
+	value     *map[uint16]string
+	delimiter string
 }
 
 var (
@@ -1139,9 +1179,9 @@ func newUint16StringMapValue(m *map[uint16]string) *uint16StringMapValue {
 }
 
 func (v *uint16StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1182,9 +1222,14 @@ func (v *uint16StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32StringMapValue.
 type uint32StringMapValue struct {
-	value *map[uint32]string
+	value     *map[uint32]string
+	delimiter string
 }
 
 var (
@@ -1200,9 +1245,9 @@ func newUint32StringMapValue(m *map[uint32]string) *uint32StringMapValue {
 }
 
 func (v *uint32StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1243,9 +1288,14 @@ func (v *uint32StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64StringMapValue.
 type uint64StringMapValue struct {
-	value *map[uint64]string
+	value     *map[uint64]string
+	delimiter string
 }
 
 var (
@@ -1261,9 +1311,9 @@ func newUint64StringMapValue(m *map[uint64]string) *uint64StringMapValue {
 }
 
 func (v *uint64StringMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1304,6 +1354,10 @@ func (v *uint64StringMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64StringMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- bool Value.
 type boolValue struct {
 	value *bool
@@ -1409,7 +1463,8 @@ func (v *boolSliceValue) IsCumulative() bool {
 
 // -- stringBoolMapValue.
 type stringBoolMapValue struct {
-	value *map[string]bool
+	value     *map[string]bool
+	delimiter string
 }
 
 var (
@@ -1425,9 +1480,9 @@ func newStringBoolMapValue(m *map[string]bool) *stringBoolMapValue {
 }
 
 func (v *stringBoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1468,9 +1523,14 @@ func (v *stringBoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringBoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intBoolMapValue.
 type intBoolMapValue struct {
-	value *map[int]bool
+	value     *map[int]bool
+	delimiter string
 }
 
 var (
@@ -1486,9 +1546,9 @@ func newIntBoolMapValue(m *map[int]bool) *intBoolMapValue {
 }
 
 func (v *intBoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1534,9 +1594,14 @@ func (v *intBoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intBoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8BoolMapValue.
 type int8BoolMapValue struct {
-	value *map[int8]bool
+	value     *map[int8]bool
+	delimiter string
 }
 
 var (
@@ -1552,9 +1617,9 @@ func newInt8BoolMapValue(m *map[int8]bool) *int8BoolMapValue {
 }
 
 func (v *int8BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1600,9 +1665,14 @@ func (v *int8BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16BoolMapValue.
 type int16BoolMapValue struct {
-	value *map[int16]bool
+	value     *map[int16]bool
+	delimiter string
 }
 
 var (
@@ -1618,9 +1688,9 @@ func newInt16BoolMapValue(m *map[int16]bool) *int16BoolMapValue {
 }
 
 func (v *int16BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1666,9 +1736,14 @@ func (v *int16BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32BoolMapValue.
 type int32BoolMapValue struct {
-	value *map[int32]bool
+	value     *map[int32]bool
+	delimiter string
 }
 
 var (
@@ -1684,9 +1759,9 @@ func newInt32BoolMapValue(m *map[int32]bool) *int32BoolMapValue {
 }
 
 func (v *int32BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1732,9 +1807,14 @@ func (v *int32BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64BoolMapValue.
 type int64BoolMapValue struct {
-	value *map[int64]bool
+	value     *map[int64]bool
+	delimiter string
 }
 
 var (
@@ -1750,9 +1830,9 @@ func newInt64BoolMapValue(m *map[int64]bool) *int64BoolMapValue {
 }
 
 func (v *int64BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1798,9 +1878,14 @@ func (v *int64BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintBoolMapValue.
 type uintBoolMapValue struct {
-	value *map[uint]bool
+	value     *map[uint]bool
+	delimiter string
 }
 
 var (
@@ -1816,9 +1901,9 @@ func newUintBoolMapValue(m *map[uint]bool) *uintBoolMapValue {
 }
 
 func (v *uintBoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1864,9 +1949,14 @@ func (v *uintBoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintBoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8BoolMapValue.
 type uint8BoolMapValue struct {
-	value *map[uint8]bool
+	value     *map[uint8]bool
+	delimiter string
 }
 
 var (
@@ -1882,9 +1972,9 @@ func newUint8BoolMapValue(m *map[uint8]bool) *uint8BoolMapValue {
 }
 
 func (v *uint8BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1930,9 +2020,14 @@ func (v *uint8BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16BoolMapValue.
 type uint16BoolMapValue struct {
-	value *map[uint16]bool
+	value     *map[uint16]bool
+	delimiter string
 }
 
 var (
@@ -1948,9 +2043,9 @@ func newUint16BoolMapValue(m *map[uint16]bool) *uint16BoolMapValue {
 }
 
 func (v *uint16BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -1996,9 +2091,14 @@ func (v *uint16BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32BoolMapValue.
 type uint32BoolMapValue struct {
-	value *map[uint32]bool
+	value     *map[uint32]bool
+	delimiter string
 }
 
 var (
@@ -2014,9 +2114,9 @@ func newUint32BoolMapValue(m *map[uint32]bool) *uint32BoolMapValue {
 }
 
 func (v *uint32BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2062,9 +2162,14 @@ func (v *uint32BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64BoolMapValue.
 type uint64BoolMapValue struct {
-	value *map[uint64]bool
+	value     *map[uint64]bool
+	delimiter string
 }
 
 var (
@@ -2080,9 +2185,9 @@ func newUint64BoolMapValue(m *map[uint64]bool) *uint64BoolMapValue {
 }
 
 func (v *uint64BoolMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2128,6 +2233,10 @@ func (v *uint64BoolMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64BoolMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint Value.
 type uintValue struct {
 	value *uint
@@ -2233,7 +2342,8 @@ func (v *uintSliceValue) IsCumulative() bool {
 
 // -- stringUintMapValue.
 type stringUintMapValue struct {
-	value *map[string]uint
+	value     *map[string]uint
+	delimiter string
 }
 
 var (
@@ -2249,9 +2359,9 @@ func newStringUintMapValue(m *map[string]uint) *stringUintMapValue {
 }
 
 func (v *stringUintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2292,9 +2402,14 @@ func (v *stringUintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringUintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intUintMapValue.
 type intUintMapValue struct {
-	value *map[int]uint
+	value     *map[int]uint
+	delimiter string
 }
 
 var (
@@ -2310,9 +2425,9 @@ func newIntUintMapValue(m *map[int]uint) *intUintMapValue {
 }
 
 func (v *intUintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2358,9 +2473,14 @@ func (v *intUintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intUintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8UintMapValue.
 type int8UintMapValue struct {
-	value *map[int8]uint
+	value     *map[int8]uint
+	delimiter string
 }
 
 var (
@@ -2376,9 +2496,9 @@ func newInt8UintMapValue(m *map[int8]uint) *int8UintMapValue {
 }
 
 func (v *int8UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2424,9 +2544,14 @@ func (v *int8UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16UintMapValue.
 type int16UintMapValue struct {
-	value *map[int16]uint
+	value     *map[int16]uint
+	delimiter string
 }
 
 var (
@@ -2442,9 +2567,9 @@ func newInt16UintMapValue(m *map[int16]uint) *int16UintMapValue {
 }
 
 func (v *int16UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2490,9 +2615,14 @@ func (v *int16UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32UintMapValue.
 type int32UintMapValue struct {
-	value *map[int32]uint
+	value     *map[int32]uint
+	delimiter string
 }
 
 var (
@@ -2508,9 +2638,9 @@ func newInt32UintMapValue(m *map[int32]uint) *int32UintMapValue {
 }
 
 func (v *int32UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2556,9 +2686,14 @@ func (v *int32UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64UintMapValue.
 type int64UintMapValue struct {
-	value *map[int64]uint
+	value     *map[int64]uint
+	delimiter string
 }
 
 var (
@@ -2574,9 +2709,9 @@ func newInt64UintMapValue(m *map[int64]uint) *int64UintMapValue {
 }
 
 func (v *int64UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2622,9 +2757,14 @@ func (v *int64UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintUintMapValue.
 type uintUintMapValue struct {
-	value *map[uint]uint
+	value     *map[uint]uint
+	delimiter string
 }
 
 var (
@@ -2640,9 +2780,9 @@ func newUintUintMapValue(m *map[uint]uint) *uintUintMapValue {
 }
 
 func (v *uintUintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2688,9 +2828,14 @@ func (v *uintUintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintUintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8UintMapValue.
 type uint8UintMapValue struct {
-	value *map[uint8]uint
+	value     *map[uint8]uint
+	delimiter string
 }
 
 var (
@@ -2706,9 +2851,9 @@ func newUint8UintMapValue(m *map[uint8]uint) *uint8UintMapValue {
 }
 
 func (v *uint8UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2754,9 +2899,14 @@ func (v *uint8UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16UintMapValue.
 type uint16UintMapValue struct {
-	value *map[uint16]uint
+	value     *map[uint16]uint
+	delimiter string
 }
 
 var (
@@ -2772,9 +2922,9 @@ func newUint16UintMapValue(m *map[uint16]uint) *uint16UintMapValue {
 }
 
 func (v *uint16UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2820,9 +2970,14 @@ func (v *uint16UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32UintMapValue.
 type uint32UintMapValue struct {
-	value *map[uint32]uint
+	value     *map[uint32]uint
+	delimiter string
 }
 
 var (
@@ -2838,9 +2993,9 @@ func newUint32UintMapValue(m *map[uint32]uint) *uint32UintMapValue {
 }
 
 func (v *uint32UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2886,9 +3041,14 @@ func (v *uint32UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64UintMapValue.
 type uint64UintMapValue struct {
-	value *map[uint64]uint
+	value     *map[uint64]uint
+	delimiter string
 }
 
 var (
@@ -2904,9 +3064,9 @@ func newUint64UintMapValue(m *map[uint64]uint) *uint64UintMapValue {
 }
 
 func (v *uint64UintMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -2952,6 +3112,10 @@ func (v *uint64UintMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64UintMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8 Value.
 type uint8Value struct {
 	value *uint8
@@ -3057,7 +3221,8 @@ func (v *uint8SliceValue) IsCumulative() bool {
 
 // -- stringUint8MapValue.
 type stringUint8MapValue struct {
-	value *map[string]uint8
+	value     *map[string]uint8
+	delimiter string
 }
 
 var (
@@ -3073,9 +3238,9 @@ func newStringUint8MapValue(m *map[string]uint8) *stringUint8MapValue {
 }
 
 func (v *stringUint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3116,9 +3281,14 @@ func (v *stringUint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringUint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intUint8MapValue.
 type intUint8MapValue struct {
-	value *map[int]uint8
+	value     *map[int]uint8
+	delimiter string
 }
 
 var (
@@ -3134,9 +3304,9 @@ func newIntUint8MapValue(m *map[int]uint8) *intUint8MapValue {
 }
 
 func (v *intUint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3182,9 +3352,14 @@ func (v *intUint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intUint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Uint8MapValue.
 type int8Uint8MapValue struct {
-	value *map[int8]uint8
+	value     *map[int8]uint8
+	delimiter string
 }
 
 var (
@@ -3200,9 +3375,9 @@ func newInt8Uint8MapValue(m *map[int8]uint8) *int8Uint8MapValue {
 }
 
 func (v *int8Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3248,9 +3423,14 @@ func (v *int8Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Uint8MapValue.
 type int16Uint8MapValue struct {
-	value *map[int16]uint8
+	value     *map[int16]uint8
+	delimiter string
 }
 
 var (
@@ -3266,9 +3446,9 @@ func newInt16Uint8MapValue(m *map[int16]uint8) *int16Uint8MapValue {
 }
 
 func (v *int16Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3314,9 +3494,14 @@ func (v *int16Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Uint8MapValue.
 type int32Uint8MapValue struct {
-	value *map[int32]uint8
+	value     *map[int32]uint8
+	delimiter string
 }
 
 var (
@@ -3332,9 +3517,9 @@ func newInt32Uint8MapValue(m *map[int32]uint8) *int32Uint8MapValue {
 }
 
 func (v *int32Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3380,9 +3565,14 @@ func (v *int32Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Uint8MapValue.
 type int64Uint8MapValue struct {
-	value *map[int64]uint8
+	value     *map[int64]uint8
+	delimiter string
 }
 
 var (
@@ -3398,9 +3588,9 @@ func newInt64Uint8MapValue(m *map[int64]uint8) *int64Uint8MapValue {
 }
 
 func (v *int64Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3446,9 +3636,14 @@ func (v *int64Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintUint8MapValue.
 type uintUint8MapValue struct {
-	value *map[uint]uint8
+	value     *map[uint]uint8
+	delimiter string
 }
 
 var (
@@ -3464,9 +3659,9 @@ func newUintUint8MapValue(m *map[uint]uint8) *uintUint8MapValue {
 }
 
 func (v *uintUint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3512,9 +3707,14 @@ func (v *uintUint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintUint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Uint8MapValue.
 type uint8Uint8MapValue struct {
-	value *map[uint8]uint8
+	value     *map[uint8]uint8
+	delimiter string
 }
 
 var (
@@ -3530,9 +3730,9 @@ func newUint8Uint8MapValue(m *map[uint8]uint8) *uint8Uint8MapValue {
 }
 
 func (v *uint8Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3578,9 +3778,14 @@ func (v *uint8Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Uint8MapValue.
 type uint16Uint8MapValue struct {
-	value *map[uint16]uint8
+	value     *map[uint16]uint8
+	delimiter string
 }
 
 var (
@@ -3596,9 +3801,9 @@ func newUint16Uint8MapValue(m *map[uint16]uint8) *uint16Uint8MapValue {
 }
 
 func (v *uint16Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3644,9 +3849,14 @@ func (v *uint16Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Uint8MapValue.
 type uint32Uint8MapValue struct {
-	value *map[uint32]uint8
+	value     *map[uint32]uint8
+	delimiter string
 }
 
 var (
@@ -3662,9 +3872,9 @@ func newUint32Uint8MapValue(m *map[uint32]uint8) *uint32Uint8MapValue {
 }
 
 func (v *uint32Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3710,9 +3920,14 @@ func (v *uint32Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Uint8MapValue.
 type uint64Uint8MapValue struct {
-	value *map[uint64]uint8
+	value     *map[uint64]uint8
+	delimiter string
 }
 
 var (
@@ -3728,9 +3943,9 @@ func newUint64Uint8MapValue(m *map[uint64]uint8) *uint64Uint8MapValue {
 }
 
 func (v *uint64Uint8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3776,6 +3991,10 @@ func (v *uint64Uint8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Uint8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16 Value.
 type uint16Value struct {
 	value *uint16
@@ -3881,7 +4100,8 @@ func (v *uint16SliceValue) IsCumulative() bool {
 
 // -- stringUint16MapValue.
 type stringUint16MapValue struct {
-	value *map[string]uint16
+	value     *map[string]uint16
+	delimiter string
 }
 
 var (
@@ -3897,9 +4117,9 @@ func newStringUint16MapValue(m *map[string]uint16) *stringUint16MapValue {
 }
 
 func (v *stringUint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -3940,9 +4160,14 @@ func (v *stringUint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringUint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intUint16MapValue.
 type intUint16MapValue struct {
-	value *map[int]uint16
+	value     *map[int]uint16
+	delimiter string
 }
 
 var (
@@ -3958,9 +4183,9 @@ func newIntUint16MapValue(m *map[int]uint16) *intUint16MapValue {
 }
 
 func (v *intUint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4006,9 +4231,14 @@ func (v *intUint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intUint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Uint16MapValue.
 type int8Uint16MapValue struct {
-	value *map[int8]uint16
+	value     *map[int8]uint16
+	delimiter string
 }
 
 var (
@@ -4024,9 +4254,9 @@ func newInt8Uint16MapValue(m *map[int8]uint16) *int8Uint16MapValue {
 }
 
 func (v *int8Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4072,9 +4302,14 @@ func (v *int8Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Uint16MapValue.
 type int16Uint16MapValue struct {
-	value *map[int16]uint16
+	value     *map[int16]uint16
+	delimiter string
 }
 
 var (
@@ -4090,9 +4325,9 @@ func newInt16Uint16MapValue(m *map[int16]uint16) *int16Uint16MapValue {
 }
 
 func (v *int16Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4138,9 +4373,14 @@ func (v *int16Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Uint16MapValue.
 type int32Uint16MapValue struct {
-	value *map[int32]uint16
+	value     *map[int32]uint16
+	delimiter string
 }
 
 var (
@@ -4156,9 +4396,9 @@ func newInt32Uint16MapValue(m *map[int32]uint16) *int32Uint16MapValue {
 }
 
 func (v *int32Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4204,9 +4444,14 @@ func (v *int32Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Uint16MapValue.
 type int64Uint16MapValue struct {
-	value *map[int64]uint16
+	value     *map[int64]uint16
+	delimiter string
 }
 
 var (
@@ -4222,9 +4467,9 @@ func newInt64Uint16MapValue(m *map[int64]uint16) *int64Uint16MapValue {
 }
 
 func (v *int64Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4270,9 +4515,14 @@ func (v *int64Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintUint16MapValue.
 type uintUint16MapValue struct {
-	value *map[uint]uint16
+	value     *map[uint]uint16
+	delimiter string
 }
 
 var (
@@ -4288,9 +4538,9 @@ func newUintUint16MapValue(m *map[uint]uint16) *uintUint16MapValue {
 }
 
 func (v *uintUint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4336,9 +4586,14 @@ func (v *uintUint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintUint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Uint16MapValue.
 type uint8Uint16MapValue struct {
-	value *map[uint8]uint16
+	value     *map[uint8]uint16
+	delimiter string
 }
 
 var (
@@ -4354,9 +4609,9 @@ func newUint8Uint16MapValue(m *map[uint8]uint16) *uint8Uint16MapValue {
 }
 
 func (v *uint8Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4402,9 +4657,14 @@ func (v *uint8Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Uint16MapValue.
 type uint16Uint16MapValue struct {
-	value *map[uint16]uint16
+	value     *map[uint16]uint16
+	delimiter string
 }
 
 var (
@@ -4420,9 +4680,9 @@ func newUint16Uint16MapValue(m *map[uint16]uint16) *uint16Uint16MapValue {
 }
 
 func (v *uint16Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4468,9 +4728,14 @@ func (v *uint16Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Uint16MapValue.
 type uint32Uint16MapValue struct {
-	value *map[uint32]uint16
+	value     *map[uint32]uint16
+	delimiter string
 }
 
 var (
@@ -4486,9 +4751,9 @@ func newUint32Uint16MapValue(m *map[uint32]uint16) *uint32Uint16MapValue {
 }
 
 func (v *uint32Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4534,9 +4799,14 @@ func (v *uint32Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Uint16MapValue.
 type uint64Uint16MapValue struct {
-	value *map[uint64]uint16
+	value     *map[uint64]uint16
+	delimiter string
 }
 
 var (
@@ -4552,9 +4822,9 @@ func newUint64Uint16MapValue(m *map[uint64]uint16) *uint64Uint16MapValue {
 }
 
 func (v *uint64Uint16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4600,6 +4870,10 @@ func (v *uint64Uint16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Uint16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32 Value.
 type uint32Value struct {
 	value *uint32
@@ -4705,7 +4979,8 @@ func (v *uint32SliceValue) IsCumulative() bool {
 
 // -- stringUint32MapValue.
 type stringUint32MapValue struct {
-	value *map[string]uint32
+	value     *map[string]uint32
+	delimiter string
 }
 
 var (
@@ -4721,9 +4996,9 @@ func newStringUint32MapValue(m *map[string]uint32) *stringUint32MapValue {
 }
 
 func (v *stringUint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4764,9 +5039,14 @@ func (v *stringUint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringUint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intUint32MapValue.
 type intUint32MapValue struct {
-	value *map[int]uint32
+	value     *map[int]uint32
+	delimiter string
 }
 
 var (
@@ -4782,9 +5062,9 @@ func newIntUint32MapValue(m *map[int]uint32) *intUint32MapValue {
 }
 
 func (v *intUint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4830,9 +5110,14 @@ func (v *intUint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intUint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Uint32MapValue.
 type int8Uint32MapValue struct {
-	value *map[int8]uint32
+	value     *map[int8]uint32
+	delimiter string
 }
 
 var (
@@ -4848,9 +5133,9 @@ func newInt8Uint32MapValue(m *map[int8]uint32) *int8Uint32MapValue {
 }
 
 func (v *int8Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4896,9 +5181,14 @@ func (v *int8Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Uint32MapValue.
 type int16Uint32MapValue struct {
-	value *map[int16]uint32
+	value     *map[int16]uint32
+	delimiter string
 }
 
 var (
@@ -4914,9 +5204,9 @@ func newInt16Uint32MapValue(m *map[int16]uint32) *int16Uint32MapValue {
 }
 
 func (v *int16Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -4962,9 +5252,14 @@ func (v *int16Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Uint32MapValue.
 type int32Uint32MapValue struct {
-	value *map[int32]uint32
+	value     *map[int32]uint32
+	delimiter string
 }
 
 var (
@@ -4980,9 +5275,9 @@ func newInt32Uint32MapValue(m *map[int32]uint32) *int32Uint32MapValue {
 }
 
 func (v *int32Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5028,9 +5323,14 @@ func (v *int32Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Uint32MapValue.
 type int64Uint32MapValue struct {
-	value *map[int64]uint32
+	value     *map[int64]uint32
+	delimiter string
 }
 
 var (
@@ -5046,9 +5346,9 @@ func newInt64Uint32MapValue(m *map[int64]uint32) *int64Uint32MapValue {
 }
 
 func (v *int64Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5094,9 +5394,14 @@ func (v *int64Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintUint32MapValue.
 type uintUint32MapValue struct {
-	value *map[uint]uint32
+	value     *map[uint]uint32
+	delimiter string
 }
 
 var (
@@ -5112,9 +5417,9 @@ func newUintUint32MapValue(m *map[uint]uint32) *uintUint32MapValue {
 }
 
 func (v *uintUint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5160,9 +5465,14 @@ func (v *uintUint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintUint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Uint32MapValue.
 type uint8Uint32MapValue struct {
-	value *map[uint8]uint32
+	value     *map[uint8]uint32
+	delimiter string
 }
 
 var (
@@ -5178,9 +5488,9 @@ func newUint8Uint32MapValue(m *map[uint8]uint32) *uint8Uint32MapValue {
 }
 
 func (v *uint8Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5226,9 +5536,14 @@ func (v *uint8Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Uint32MapValue.
 type uint16Uint32MapValue struct {
-	value *map[uint16]uint32
+	value     *map[uint16]uint32
+	delimiter string
 }
 
 var (
@@ -5244,9 +5559,9 @@ func newUint16Uint32MapValue(m *map[uint16]uint32) *uint16Uint32MapValue {
 }
 
 func (v *uint16Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5292,9 +5607,14 @@ func (v *uint16Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Uint32MapValue.
 type uint32Uint32MapValue struct {
-	value *map[uint32]uint32
+	value     *map[uint32]uint32
+	delimiter string
 }
 
 var (
@@ -5310,9 +5630,9 @@ func newUint32Uint32MapValue(m *map[uint32]uint32) *uint32Uint32MapValue {
 }
 
 func (v *uint32Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5358,9 +5678,14 @@ func (v *uint32Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Uint32MapValue.
 type uint64Uint32MapValue struct {
-	value *map[uint64]uint32
+	value     *map[uint64]uint32
+	delimiter string
 }
 
 var (
@@ -5376,9 +5701,9 @@ func newUint64Uint32MapValue(m *map[uint64]uint32) *uint64Uint32MapValue {
 }
 
 func (v *uint64Uint32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5424,6 +5749,10 @@ func (v *uint64Uint32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Uint32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64 Value.
 type uint64Value struct {
 	value *uint64
@@ -5529,7 +5858,8 @@ func (v *uint64SliceValue) IsCumulative() bool {
 
 // -- stringUint64MapValue.
 type stringUint64MapValue struct {
-	value *map[string]uint64
+	value     *map[string]uint64
+	delimiter string
 }
 
 var (
@@ -5545,9 +5875,9 @@ func newStringUint64MapValue(m *map[string]uint64) *stringUint64MapValue {
 }
 
 func (v *stringUint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5588,9 +5918,14 @@ func (v *stringUint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringUint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intUint64MapValue.
 type intUint64MapValue struct {
-	value *map[int]uint64
+	value     *map[int]uint64
+	delimiter string
 }
 
 var (
@@ -5606,9 +5941,9 @@ func newIntUint64MapValue(m *map[int]uint64) *intUint64MapValue {
 }
 
 func (v *intUint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5654,9 +5989,14 @@ func (v *intUint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intUint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Uint64MapValue.
 type int8Uint64MapValue struct {
-	value *map[int8]uint64
+	value     *map[int8]uint64
+	delimiter string
 }
 
 var (
@@ -5672,9 +6012,9 @@ func newInt8Uint64MapValue(m *map[int8]uint64) *int8Uint64MapValue {
 }
 
 func (v *int8Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5720,9 +6060,14 @@ func (v *int8Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Uint64MapValue.
 type int16Uint64MapValue struct {
-	value *map[int16]uint64
+	value     *map[int16]uint64
+	delimiter string
 }
 
 var (
@@ -5738,9 +6083,9 @@ func newInt16Uint64MapValue(m *map[int16]uint64) *int16Uint64MapValue {
 }
 
 func (v *int16Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5786,9 +6131,14 @@ func (v *int16Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Uint64MapValue.
 type int32Uint64MapValue struct {
-	value *map[int32]uint64
+	value     *map[int32]uint64
+	delimiter string
 }
 
 var (
@@ -5804,9 +6154,9 @@ func newInt32Uint64MapValue(m *map[int32]uint64) *int32Uint64MapValue {
 }
 
 func (v *int32Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5852,9 +6202,14 @@ func (v *int32Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Uint64MapValue.
 type int64Uint64MapValue struct {
-	value *map[int64]uint64
+	value     *map[int64]uint64
+	delimiter string
 }
 
 var (
@@ -5870,9 +6225,9 @@ func newInt64Uint64MapValue(m *map[int64]uint64) *int64Uint64MapValue {
 }
 
 func (v *int64Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5918,9 +6273,14 @@ func (v *int64Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintUint64MapValue.
 type uintUint64MapValue struct {
-	value *map[uint]uint64
+	value     *map[uint]uint64
+	delimiter string
 }
 
 var (
@@ -5936,9 +6296,9 @@ func newUintUint64MapValue(m *map[uint]uint64) *uintUint64MapValue {
 }
 
 func (v *uintUint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -5984,9 +6344,14 @@ func (v *uintUint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintUint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Uint64MapValue.
 type uint8Uint64MapValue struct {
-	value *map[uint8]uint64
+	value     *map[uint8]uint64
+	delimiter string
 }
 
 var (
@@ -6002,9 +6367,9 @@ func newUint8Uint64MapValue(m *map[uint8]uint64) *uint8Uint64MapValue {
 }
 
 func (v *uint8Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6050,9 +6415,14 @@ func (v *uint8Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Uint64MapValue.
 type uint16Uint64MapValue struct {
-	value *map[uint16]uint64
+	value     *map[uint16]uint64
+	delimiter string
 }
 
 var (
@@ -6068,9 +6438,9 @@ func newUint16Uint64MapValue(m *map[uint16]uint64) *uint16Uint64MapValue {
 }
 
 func (v *uint16Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6116,9 +6486,14 @@ func (v *uint16Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Uint64MapValue.
 type uint32Uint64MapValue struct {
-	value *map[uint32]uint64
+	value     *map[uint32]uint64
+	delimiter string
 }
 
 var (
@@ -6134,9 +6509,9 @@ func newUint32Uint64MapValue(m *map[uint32]uint64) *uint32Uint64MapValue {
 }
 
 func (v *uint32Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6182,9 +6557,14 @@ func (v *uint32Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Uint64MapValue.
 type uint64Uint64MapValue struct {
-	value *map[uint64]uint64
+	value     *map[uint64]uint64
+	delimiter string
 }
 
 var (
@@ -6200,9 +6580,9 @@ func newUint64Uint64MapValue(m *map[uint64]uint64) *uint64Uint64MapValue {
 }
 
 func (v *uint64Uint64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6248,6 +6628,10 @@ func (v *uint64Uint64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Uint64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int Value.
 type intValue struct {
 	value *int
@@ -6353,7 +6737,8 @@ func (v *intSliceValue) IsCumulative() bool {
 
 // -- stringIntMapValue.
 type stringIntMapValue struct {
-	value *map[string]int
+	value     *map[string]int
+	delimiter string
 }
 
 var (
@@ -6369,9 +6754,9 @@ func newStringIntMapValue(m *map[string]int) *stringIntMapValue {
 }
 
 func (v *stringIntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6412,9 +6797,14 @@ func (v *stringIntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringIntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intIntMapValue.
 type intIntMapValue struct {
-	value *map[int]int
+	value     *map[int]int
+	delimiter string
 }
 
 var (
@@ -6430,9 +6820,9 @@ func newIntIntMapValue(m *map[int]int) *intIntMapValue {
 }
 
 func (v *intIntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6478,9 +6868,14 @@ func (v *intIntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intIntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8IntMapValue.
 type int8IntMapValue struct {
-	value *map[int8]int
+	value     *map[int8]int
+	delimiter string
 }
 
 var (
@@ -6496,9 +6891,9 @@ func newInt8IntMapValue(m *map[int8]int) *int8IntMapValue {
 }
 
 func (v *int8IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6544,9 +6939,14 @@ func (v *int8IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16IntMapValue.
 type int16IntMapValue struct {
-	value *map[int16]int
+	value     *map[int16]int
+	delimiter string
 }
 
 var (
@@ -6562,9 +6962,9 @@ func newInt16IntMapValue(m *map[int16]int) *int16IntMapValue {
 }
 
 func (v *int16IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6610,9 +7010,14 @@ func (v *int16IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32IntMapValue.
 type int32IntMapValue struct {
-	value *map[int32]int
+	value     *map[int32]int
+	delimiter string
 }
 
 var (
@@ -6628,9 +7033,9 @@ func newInt32IntMapValue(m *map[int32]int) *int32IntMapValue {
 }
 
 func (v *int32IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6676,9 +7081,14 @@ func (v *int32IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64IntMapValue.
 type int64IntMapValue struct {
-	value *map[int64]int
+	value     *map[int64]int
+	delimiter string
 }
 
 var (
@@ -6694,9 +7104,9 @@ func newInt64IntMapValue(m *map[int64]int) *int64IntMapValue {
 }
 
 func (v *int64IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6742,9 +7152,14 @@ func (v *int64IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintIntMapValue.
 type uintIntMapValue struct {
-	value *map[uint]int
+	value     *map[uint]int
+	delimiter string
 }
 
 var (
@@ -6760,9 +7175,9 @@ func newUintIntMapValue(m *map[uint]int) *uintIntMapValue {
 }
 
 func (v *uintIntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6808,9 +7223,14 @@ func (v *uintIntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintIntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8IntMapValue.
 type uint8IntMapValue struct {
-	value *map[uint8]int
+	value     *map[uint8]int
+	delimiter string
 }
 
 var (
@@ -6826,9 +7246,9 @@ func newUint8IntMapValue(m *map[uint8]int) *uint8IntMapValue {
 }
 
 func (v *uint8IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6874,9 +7294,14 @@ func (v *uint8IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16IntMapValue.
 type uint16IntMapValue struct {
-	value *map[uint16]int
+	value     *map[uint16]int
+	delimiter string
 }
 
 var (
@@ -6892,9 +7317,9 @@ func newUint16IntMapValue(m *map[uint16]int) *uint16IntMapValue {
 }
 
 func (v *uint16IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -6940,9 +7365,14 @@ func (v *uint16IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32IntMapValue.
 type uint32IntMapValue struct {
-	value *map[uint32]int
+	value     *map[uint32]int
+	delimiter string
 }
 
 var (
@@ -6958,9 +7388,9 @@ func newUint32IntMapValue(m *map[uint32]int) *uint32IntMapValue {
 }
 
 func (v *uint32IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7006,9 +7436,14 @@ func (v *uint32IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64IntMapValue.
 type uint64IntMapValue struct {
-	value *map[uint64]int
+	value     *map[uint64]int
+	delimiter string
 }
 
 var (
@@ -7024,9 +7459,9 @@ func newUint64IntMapValue(m *map[uint64]int) *uint64IntMapValue {
 }
 
 func (v *uint64IntMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7072,6 +7507,10 @@ func (v *uint64IntMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64IntMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8 Value.
 type int8Value struct {
 	value *int8
@@ -7177,7 +7616,8 @@ func (v *int8SliceValue) IsCumulative() bool {
 
 // -- stringInt8MapValue.
 type stringInt8MapValue struct {
-	value *map[string]int8
+	value     *map[string]int8
+	delimiter string
 }
 
 var (
@@ -7193,9 +7633,9 @@ func newStringInt8MapValue(m *map[string]int8) *stringInt8MapValue {
 }
 
 func (v *stringInt8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7236,9 +7676,14 @@ func (v *stringInt8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringInt8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intInt8MapValue.
 type intInt8MapValue struct {
-	value *map[int]int8
+	value     *map[int]int8
+	delimiter string
 }
 
 var (
@@ -7254,9 +7699,9 @@ func newIntInt8MapValue(m *map[int]int8) *intInt8MapValue {
 }
 
 func (v *intInt8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7302,9 +7747,14 @@ func (v *intInt8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intInt8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Int8MapValue.
 type int8Int8MapValue struct {
-	value *map[int8]int8
+	value     *map[int8]int8
+	delimiter string
 }
 
 var (
@@ -7320,9 +7770,9 @@ func newInt8Int8MapValue(m *map[int8]int8) *int8Int8MapValue {
 }
 
 func (v *int8Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7368,9 +7818,14 @@ func (v *int8Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Int8MapValue.
 type int16Int8MapValue struct {
-	value *map[int16]int8
+	value     *map[int16]int8
+	delimiter string
 }
 
 var (
@@ -7386,9 +7841,9 @@ func newInt16Int8MapValue(m *map[int16]int8) *int16Int8MapValue {
 }
 
 func (v *int16Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7434,9 +7889,14 @@ func (v *int16Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Int8MapValue.
 type int32Int8MapValue struct {
-	value *map[int32]int8
+	value     *map[int32]int8
+	delimiter string
 }
 
 var (
@@ -7452,9 +7912,9 @@ func newInt32Int8MapValue(m *map[int32]int8) *int32Int8MapValue {
 }
 
 func (v *int32Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7500,9 +7960,14 @@ func (v *int32Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Int8MapValue.
 type int64Int8MapValue struct {
-	value *map[int64]int8
+	value     *map[int64]int8
+	delimiter string
 }
 
 var (
@@ -7518,9 +7983,9 @@ func newInt64Int8MapValue(m *map[int64]int8) *int64Int8MapValue {
 }
 
 func (v *int64Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7566,9 +8031,14 @@ func (v *int64Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintInt8MapValue.
 type uintInt8MapValue struct {
-	value *map[uint]int8
+	value     *map[uint]int8
+	delimiter string
 }
 
 var (
@@ -7584,9 +8054,9 @@ func newUintInt8MapValue(m *map[uint]int8) *uintInt8MapValue {
 }
 
 func (v *uintInt8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7632,9 +8102,14 @@ func (v *uintInt8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintInt8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Int8MapValue.
 type uint8Int8MapValue struct {
-	value *map[uint8]int8
+	value     *map[uint8]int8
+	delimiter string
 }
 
 var (
@@ -7650,9 +8125,9 @@ func newUint8Int8MapValue(m *map[uint8]int8) *uint8Int8MapValue {
 }
 
 func (v *uint8Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7698,9 +8173,14 @@ func (v *uint8Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Int8MapValue.
 type uint16Int8MapValue struct {
-	value *map[uint16]int8
+	value     *map[uint16]int8
+	delimiter string
 }
 
 var (
@@ -7716,9 +8196,9 @@ func newUint16Int8MapValue(m *map[uint16]int8) *uint16Int8MapValue {
 }
 
 func (v *uint16Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7764,9 +8244,14 @@ func (v *uint16Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Int8MapValue.
 type uint32Int8MapValue struct {
-	value *map[uint32]int8
+	value     *map[uint32]int8
+	delimiter string
 }
 
 var (
@@ -7782,9 +8267,9 @@ func newUint32Int8MapValue(m *map[uint32]int8) *uint32Int8MapValue {
 }
 
 func (v *uint32Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7830,9 +8315,14 @@ func (v *uint32Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Int8MapValue.
 type uint64Int8MapValue struct {
-	value *map[uint64]int8
+	value     *map[uint64]int8
+	delimiter string
 }
 
 var (
@@ -7848,9 +8338,9 @@ func newUint64Int8MapValue(m *map[uint64]int8) *uint64Int8MapValue {
 }
 
 func (v *uint64Int8MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -7896,6 +8386,10 @@ func (v *uint64Int8MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Int8MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16 Value.
 type int16Value struct {
 	value *int16
@@ -8001,7 +8495,8 @@ func (v *int16SliceValue) IsCumulative() bool {
 
 // -- stringInt16MapValue.
 type stringInt16MapValue struct {
-	value *map[string]int16
+	value     *map[string]int16
+	delimiter string
 }
 
 var (
@@ -8017,9 +8512,9 @@ func newStringInt16MapValue(m *map[string]int16) *stringInt16MapValue {
 }
 
 func (v *stringInt16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8060,9 +8555,14 @@ func (v *stringInt16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringInt16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intInt16MapValue.
 type intInt16MapValue struct {
-	value *map[int]int16
+	value     *map[int]int16
+	delimiter string
 }
 
 var (
@@ -8078,9 +8578,9 @@ func newIntInt16MapValue(m *map[int]int16) *intInt16MapValue {
 }
 
 func (v *intInt16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8126,9 +8626,14 @@ func (v *intInt16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intInt16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Int16MapValue.
 type int8Int16MapValue struct {
-	value *map[int8]int16
+	value     *map[int8]int16
+	delimiter string
 }
 
 var (
@@ -8144,9 +8649,9 @@ func newInt8Int16MapValue(m *map[int8]int16) *int8Int16MapValue {
 }
 
 func (v *int8Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8192,9 +8697,14 @@ func (v *int8Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Int16MapValue.
 type int16Int16MapValue struct {
-	value *map[int16]int16
+	value     *map[int16]int16
+	delimiter string
 }
 
 var (
@@ -8210,9 +8720,9 @@ func newInt16Int16MapValue(m *map[int16]int16) *int16Int16MapValue {
 }
 
 func (v *int16Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8258,9 +8768,14 @@ func (v *int16Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Int16MapValue.
 type int32Int16MapValue struct {
-	value *map[int32]int16
+	value     *map[int32]int16
+	delimiter string
 }
 
 var (
@@ -8276,9 +8791,9 @@ func newInt32Int16MapValue(m *map[int32]int16) *int32Int16MapValue {
 }
 
 func (v *int32Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8324,9 +8839,14 @@ func (v *int32Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Int16MapValue.
 type int64Int16MapValue struct {
-	value *map[int64]int16
+	value     *map[int64]int16
+	delimiter string
 }
 
 var (
@@ -8342,9 +8862,9 @@ func newInt64Int16MapValue(m *map[int64]int16) *int64Int16MapValue {
 }
 
 func (v *int64Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8390,9 +8910,14 @@ func (v *int64Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintInt16MapValue.
 type uintInt16MapValue struct {
-	value *map[uint]int16
+	value     *map[uint]int16
+	delimiter string
 }
 
 var (
@@ -8408,9 +8933,9 @@ func newUintInt16MapValue(m *map[uint]int16) *uintInt16MapValue {
 }
 
 func (v *uintInt16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8456,9 +8981,14 @@ func (v *uintInt16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintInt16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Int16MapValue.
 type uint8Int16MapValue struct {
-	value *map[uint8]int16
+	value     *map[uint8]int16
+	delimiter string
 }
 
 var (
@@ -8474,9 +9004,9 @@ func newUint8Int16MapValue(m *map[uint8]int16) *uint8Int16MapValue {
 }
 
 func (v *uint8Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8522,9 +9052,14 @@ func (v *uint8Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Int16MapValue.
 type uint16Int16MapValue struct {
-	value *map[uint16]int16
+	value     *map[uint16]int16
+	delimiter string
 }
 
 var (
@@ -8540,9 +9075,9 @@ func newUint16Int16MapValue(m *map[uint16]int16) *uint16Int16MapValue {
 }
 
 func (v *uint16Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8588,9 +9123,14 @@ func (v *uint16Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Int16MapValue.
 type uint32Int16MapValue struct {
-	value *map[uint32]int16
+	value     *map[uint32]int16
+	delimiter string
 }
 
 var (
@@ -8606,9 +9146,9 @@ func newUint32Int16MapValue(m *map[uint32]int16) *uint32Int16MapValue {
 }
 
 func (v *uint32Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8654,9 +9194,14 @@ func (v *uint32Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Int16MapValue.
 type uint64Int16MapValue struct {
-	value *map[uint64]int16
+	value     *map[uint64]int16
+	delimiter string
 }
 
 var (
@@ -8672,9 +9217,9 @@ func newUint64Int16MapValue(m *map[uint64]int16) *uint64Int16MapValue {
 }
 
 func (v *uint64Int16MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8720,6 +9265,10 @@ func (v *uint64Int16MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Int16MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32 Value.
 type int32Value struct {
 	value *int32
@@ -8825,7 +9374,8 @@ func (v *int32SliceValue) IsCumulative() bool {
 
 // -- stringInt32MapValue.
 type stringInt32MapValue struct {
-	value *map[string]int32
+	value     *map[string]int32
+	delimiter string
 }
 
 var (
@@ -8841,9 +9391,9 @@ func newStringInt32MapValue(m *map[string]int32) *stringInt32MapValue {
 }
 
 func (v *stringInt32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8884,9 +9434,14 @@ func (v *stringInt32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringInt32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intInt32MapValue.
 type intInt32MapValue struct {
-	value *map[int]int32
+	value     *map[int]int32
+	delimiter string
 }
 
 var (
@@ -8902,9 +9457,9 @@ func newIntInt32MapValue(m *map[int]int32) *intInt32MapValue {
 }
 
 func (v *intInt32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -8950,9 +9505,14 @@ func (v *intInt32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intInt32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Int32MapValue.
 type int8Int32MapValue struct {
-	value *map[int8]int32
+	value     *map[int8]int32
+	delimiter string
 }
 
 var (
@@ -8968,9 +9528,9 @@ func newInt8Int32MapValue(m *map[int8]int32) *int8Int32MapValue {
 }
 
 func (v *int8Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9016,9 +9576,14 @@ func (v *int8Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Int32MapValue.
 type int16Int32MapValue struct {
-	value *map[int16]int32
+	value     *map[int16]int32
+	delimiter string
 }
 
 var (
@@ -9034,9 +9599,9 @@ func newInt16Int32MapValue(m *map[int16]int32) *int16Int32MapValue {
 }
 
 func (v *int16Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9082,9 +9647,14 @@ func (v *int16Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Int32MapValue.
 type int32Int32MapValue struct {
-	value *map[int32]int32
+	value     *map[int32]int32
+	delimiter string
 }
 
 var (
@@ -9100,9 +9670,9 @@ func newInt32Int32MapValue(m *map[int32]int32) *int32Int32MapValue {
 }
 
 func (v *int32Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9148,9 +9718,14 @@ func (v *int32Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Int32MapValue.
 type int64Int32MapValue struct {
-	value *map[int64]int32
+	value     *map[int64]int32
+	delimiter string
 }
 
 var (
@@ -9166,9 +9741,9 @@ func newInt64Int32MapValue(m *map[int64]int32) *int64Int32MapValue {
 }
 
 func (v *int64Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9214,9 +9789,14 @@ func (v *int64Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintInt32MapValue.
 type uintInt32MapValue struct {
-	value *map[uint]int32
+	value     *map[uint]int32
+	delimiter string
 }
 
 var (
@@ -9232,9 +9812,9 @@ func newUintInt32MapValue(m *map[uint]int32) *uintInt32MapValue {
 }
 
 func (v *uintInt32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9280,9 +9860,14 @@ func (v *uintInt32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintInt32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Int32MapValue.
 type uint8Int32MapValue struct {
-	value *map[uint8]int32
+	value     *map[uint8]int32
+	delimiter string
 }
 
 var (
@@ -9298,9 +9883,9 @@ func newUint8Int32MapValue(m *map[uint8]int32) *uint8Int32MapValue {
 }
 
 func (v *uint8Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9346,9 +9931,14 @@ func (v *uint8Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Int32MapValue.
 type uint16Int32MapValue struct {
-	value *map[uint16]int32
+	value     *map[uint16]int32
+	delimiter string
 }
 
 var (
@@ -9364,9 +9954,9 @@ func newUint16Int32MapValue(m *map[uint16]int32) *uint16Int32MapValue {
 }
 
 func (v *uint16Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9412,9 +10002,14 @@ func (v *uint16Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Int32MapValue.
 type uint32Int32MapValue struct {
-	value *map[uint32]int32
+	value     *map[uint32]int32
+	delimiter string
 }
 
 var (
@@ -9430,9 +10025,9 @@ func newUint32Int32MapValue(m *map[uint32]int32) *uint32Int32MapValue {
 }
 
 func (v *uint32Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9478,9 +10073,14 @@ func (v *uint32Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Int32MapValue.
 type uint64Int32MapValue struct {
-	value *map[uint64]int32
+	value     *map[uint64]int32
+	delimiter string
 }
 
 var (
@@ -9496,9 +10096,9 @@ func newUint64Int32MapValue(m *map[uint64]int32) *uint64Int32MapValue {
 }
 
 func (v *uint64Int32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9544,6 +10144,10 @@ func (v *uint64Int32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Int32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64 Value.
 type int64Value struct {
 	value *int64
@@ -9649,7 +10253,8 @@ func (v *int64SliceValue) IsCumulative() bool {
 
 // -- stringInt64MapValue.
 type stringInt64MapValue struct {
-	value *map[string]int64
+	value     *map[string]int64
+	delimiter string
 }
 
 var (
@@ -9665,9 +10270,9 @@ func newStringInt64MapValue(m *map[string]int64) *stringInt64MapValue {
 }
 
 func (v *stringInt64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9708,9 +10313,14 @@ func (v *stringInt64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringInt64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intInt64MapValue.
 type intInt64MapValue struct {
-	value *map[int]int64
+	value     *map[int]int64
+	delimiter string
 }
 
 var (
@@ -9726,9 +10336,9 @@ func newIntInt64MapValue(m *map[int]int64) *intInt64MapValue {
 }
 
 func (v *intInt64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9774,9 +10384,14 @@ func (v *intInt64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intInt64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Int64MapValue.
 type int8Int64MapValue struct {
-	value *map[int8]int64
+	value     *map[int8]int64
+	delimiter string
 }
 
 var (
@@ -9792,9 +10407,9 @@ func newInt8Int64MapValue(m *map[int8]int64) *int8Int64MapValue {
 }
 
 func (v *int8Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9840,9 +10455,14 @@ func (v *int8Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Int64MapValue.
 type int16Int64MapValue struct {
-	value *map[int16]int64
+	value     *map[int16]int64
+	delimiter string
 }
 
 var (
@@ -9858,9 +10478,9 @@ func newInt16Int64MapValue(m *map[int16]int64) *int16Int64MapValue {
 }
 
 func (v *int16Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9906,9 +10526,14 @@ func (v *int16Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Int64MapValue.
 type int32Int64MapValue struct {
-	value *map[int32]int64
+	value     *map[int32]int64
+	delimiter string
 }
 
 var (
@@ -9924,9 +10549,9 @@ func newInt32Int64MapValue(m *map[int32]int64) *int32Int64MapValue {
 }
 
 func (v *int32Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -9972,9 +10597,14 @@ func (v *int32Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Int64MapValue.
 type int64Int64MapValue struct {
-	value *map[int64]int64
+	value     *map[int64]int64
+	delimiter string
 }
 
 var (
@@ -9990,9 +10620,9 @@ func newInt64Int64MapValue(m *map[int64]int64) *int64Int64MapValue {
 }
 
 func (v *int64Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10038,9 +10668,14 @@ func (v *int64Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintInt64MapValue.
 type uintInt64MapValue struct {
-	value *map[uint]int64
+	value     *map[uint]int64
+	delimiter string
 }
 
 var (
@@ -10056,9 +10691,9 @@ func newUintInt64MapValue(m *map[uint]int64) *uintInt64MapValue {
 }
 
 func (v *uintInt64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10104,9 +10739,14 @@ func (v *uintInt64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintInt64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Int64MapValue.
 type uint8Int64MapValue struct {
-	value *map[uint8]int64
+	value     *map[uint8]int64
+	delimiter string
 }
 
 var (
@@ -10122,9 +10762,9 @@ func newUint8Int64MapValue(m *map[uint8]int64) *uint8Int64MapValue {
 }
 
 func (v *uint8Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10170,9 +10810,14 @@ func (v *uint8Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Int64MapValue.
 type uint16Int64MapValue struct {
-	value *map[uint16]int64
+	value     *map[uint16]int64
+	delimiter string
 }
 
 var (
@@ -10188,9 +10833,9 @@ func newUint16Int64MapValue(m *map[uint16]int64) *uint16Int64MapValue {
 }
 
 func (v *uint16Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10236,9 +10881,14 @@ func (v *uint16Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Int64MapValue.
 type uint32Int64MapValue struct {
-	value *map[uint32]int64
+	value     *map[uint32]int64
+	delimiter string
 }
 
 var (
@@ -10254,9 +10904,9 @@ func newUint32Int64MapValue(m *map[uint32]int64) *uint32Int64MapValue {
 }
 
 func (v *uint32Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10302,9 +10952,14 @@ func (v *uint32Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Int64MapValue.
 type uint64Int64MapValue struct {
-	value *map[uint64]int64
+	value     *map[uint64]int64
+	delimiter string
 }
 
 var (
@@ -10320,9 +10975,9 @@ func newUint64Int64MapValue(m *map[uint64]int64) *uint64Int64MapValue {
 }
 
 func (v *uint64Int64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10368,6 +11023,10 @@ func (v *uint64Int64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Int64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- float64 Value.
 type float64Value struct {
 	value *float64
@@ -10473,7 +11132,8 @@ func (v *float64SliceValue) IsCumulative() bool {
 
 // -- stringFloat64MapValue.
 type stringFloat64MapValue struct {
-	value *map[string]float64
+	value     *map[string]float64
+	delimiter string
 }
 
 var (
@@ -10489,9 +11149,9 @@ func newStringFloat64MapValue(m *map[string]float64) *stringFloat64MapValue {
 }
 
 func (v *stringFloat64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10532,9 +11192,14 @@ func (v *stringFloat64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringFloat64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intFloat64MapValue.
 type intFloat64MapValue struct {
-	value *map[int]float64
+	value     *map[int]float64
+	delimiter string
 }
 
 var (
@@ -10550,9 +11215,9 @@ func newIntFloat64MapValue(m *map[int]float64) *intFloat64MapValue {
 }
 
 func (v *intFloat64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10598,9 +11263,14 @@ func (v *intFloat64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intFloat64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Float64MapValue.
 type int8Float64MapValue struct {
-	value *map[int8]float64
+	value     *map[int8]float64
+	delimiter string
 }
 
 var (
@@ -10616,9 +11286,9 @@ func newInt8Float64MapValue(m *map[int8]float64) *int8Float64MapValue {
 }
 
 func (v *int8Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10664,9 +11334,14 @@ func (v *int8Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Float64MapValue.
 type int16Float64MapValue struct {
-	value *map[int16]float64
+	value     *map[int16]float64
+	delimiter string
 }
 
 var (
@@ -10682,9 +11357,9 @@ func newInt16Float64MapValue(m *map[int16]float64) *int16Float64MapValue {
 }
 
 func (v *int16Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10730,9 +11405,14 @@ func (v *int16Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Float64MapValue.
 type int32Float64MapValue struct {
-	value *map[int32]float64
+	value     *map[int32]float64
+	delimiter string
 }
 
 var (
@@ -10748,9 +11428,9 @@ func newInt32Float64MapValue(m *map[int32]float64) *int32Float64MapValue {
 }
 
 func (v *int32Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10796,9 +11476,14 @@ func (v *int32Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Float64MapValue.
 type int64Float64MapValue struct {
-	value *map[int64]float64
+	value     *map[int64]float64
+	delimiter string
 }
 
 var (
@@ -10814,9 +11499,9 @@ func newInt64Float64MapValue(m *map[int64]float64) *int64Float64MapValue {
 }
 
 func (v *int64Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10862,9 +11547,14 @@ func (v *int64Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintFloat64MapValue.
 type uintFloat64MapValue struct {
-	value *map[uint]float64
+	value     *map[uint]float64
+	delimiter string
 }
 
 var (
@@ -10880,9 +11570,9 @@ func newUintFloat64MapValue(m *map[uint]float64) *uintFloat64MapValue {
 }
 
 func (v *uintFloat64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10928,9 +11618,14 @@ func (v *uintFloat64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintFloat64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Float64MapValue.
 type uint8Float64MapValue struct {
-	value *map[uint8]float64
+	value     *map[uint8]float64
+	delimiter string
 }
 
 var (
@@ -10946,9 +11641,9 @@ func newUint8Float64MapValue(m *map[uint8]float64) *uint8Float64MapValue {
 }
 
 func (v *uint8Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -10994,9 +11689,14 @@ func (v *uint8Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Float64MapValue.
 type uint16Float64MapValue struct {
-	value *map[uint16]float64
+	value     *map[uint16]float64
+	delimiter string
 }
 
 var (
@@ -11012,9 +11712,9 @@ func newUint16Float64MapValue(m *map[uint16]float64) *uint16Float64MapValue {
 }
 
 func (v *uint16Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11060,9 +11760,14 @@ func (v *uint16Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Float64MapValue.
 type uint32Float64MapValue struct {
-	value *map[uint32]float64
+	value     *map[uint32]float64
+	delimiter string
 }
 
 var (
@@ -11078,9 +11783,9 @@ func newUint32Float64MapValue(m *map[uint32]float64) *uint32Float64MapValue {
 }
 
 func (v *uint32Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11126,9 +11831,14 @@ func (v *uint32Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Float64MapValue.
 type uint64Float64MapValue struct {
-	value *map[uint64]float64
+	value     *map[uint64]float64
+	delimiter string
 }
 
 var (
@@ -11144,9 +11854,9 @@ func newUint64Float64MapValue(m *map[uint64]float64) *uint64Float64MapValue {
 }
 
 func (v *uint64Float64MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11192,6 +11902,10 @@ func (v *uint64Float64MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Float64MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- float32 Value.
 type float32Value struct {
 	value *float32
@@ -11297,7 +12011,8 @@ func (v *float32SliceValue) IsCumulative() bool {
 
 // -- stringFloat32MapValue.
 type stringFloat32MapValue struct {
-	value *map[string]float32
+	value     *map[string]float32
+	delimiter string
 }
 
 var (
@@ -11313,9 +12028,9 @@ func newStringFloat32MapValue(m *map[string]float32) *stringFloat32MapValue {
 }
 
 func (v *stringFloat32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11356,9 +12071,14 @@ func (v *stringFloat32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringFloat32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intFloat32MapValue.
 type intFloat32MapValue struct {
-	value *map[int]float32
+	value     *map[int]float32
+	delimiter string
 }
 
 var (
@@ -11374,9 +12094,9 @@ func newIntFloat32MapValue(m *map[int]float32) *intFloat32MapValue {
 }
 
 func (v *intFloat32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11422,9 +12142,14 @@ func (v *intFloat32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intFloat32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8Float32MapValue.
 type int8Float32MapValue struct {
-	value *map[int8]float32
+	value     *map[int8]float32
+	delimiter string
 }
 
 var (
@@ -11440,9 +12165,9 @@ func newInt8Float32MapValue(m *map[int8]float32) *int8Float32MapValue {
 }
 
 func (v *int8Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11488,9 +12213,14 @@ func (v *int8Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16Float32MapValue.
 type int16Float32MapValue struct {
-	value *map[int16]float32
+	value     *map[int16]float32
+	delimiter string
 }
 
 var (
@@ -11506,9 +12236,9 @@ func newInt16Float32MapValue(m *map[int16]float32) *int16Float32MapValue {
 }
 
 func (v *int16Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11554,9 +12284,14 @@ func (v *int16Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32Float32MapValue.
 type int32Float32MapValue struct {
-	value *map[int32]float32
+	value     *map[int32]float32
+	delimiter string
 }
 
 var (
@@ -11572,9 +12307,9 @@ func newInt32Float32MapValue(m *map[int32]float32) *int32Float32MapValue {
 }
 
 func (v *int32Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11620,9 +12355,14 @@ func (v *int32Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64Float32MapValue.
 type int64Float32MapValue struct {
-	value *map[int64]float32
+	value     *map[int64]float32
+	delimiter string
 }
 
 var (
@@ -11638,9 +12378,9 @@ func newInt64Float32MapValue(m *map[int64]float32) *int64Float32MapValue {
 }
 
 func (v *int64Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11686,9 +12426,14 @@ func (v *int64Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintFloat32MapValue.
 type uintFloat32MapValue struct {
-	value *map[uint]float32
+	value     *map[uint]float32
+	delimiter string
 }
 
 var (
@@ -11704,9 +12449,9 @@ func newUintFloat32MapValue(m *map[uint]float32) *uintFloat32MapValue {
 }
 
 func (v *uintFloat32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11752,9 +12497,14 @@ func (v *uintFloat32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintFloat32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8Float32MapValue.
 type uint8Float32MapValue struct {
-	value *map[uint8]float32
+	value     *map[uint8]float32
+	delimiter string
 }
 
 var (
@@ -11770,9 +12520,9 @@ func newUint8Float32MapValue(m *map[uint8]float32) *uint8Float32MapValue {
 }
 
 func (v *uint8Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11818,9 +12568,14 @@ func (v *uint8Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16Float32MapValue.
 type uint16Float32MapValue struct {
-	value *map[uint16]float32
+	value     *map[uint16]float32
+	delimiter string
 }
 
 var (
@@ -11836,9 +12591,9 @@ func newUint16Float32MapValue(m *map[uint16]float32) *uint16Float32MapValue {
 }
 
 func (v *uint16Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11884,9 +12639,14 @@ func (v *uint16Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32Float32MapValue.
 type uint32Float32MapValue struct {
-	value *map[uint32]float32
+	value     *map[uint32]float32
+	delimiter string
 }
 
 var (
@@ -11902,9 +12662,9 @@ func newUint32Float32MapValue(m *map[uint32]float32) *uint32Float32MapValue {
 }
 
 func (v *uint32Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -11950,9 +12710,14 @@ func (v *uint32Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64Float32MapValue.
 type uint64Float32MapValue struct {
-	value *map[uint64]float32
+	value     *map[uint64]float32
+	delimiter string
 }
 
 var (
@@ -11968,9 +12733,9 @@ func newUint64Float32MapValue(m *map[uint64]float32) *uint64Float32MapValue {
 }
 
 func (v *uint64Float32MapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12016,6 +12781,10 @@ func (v *uint64Float32MapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64Float32MapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- time.Duration Value.
 type durationValue struct {
 	value *time.Duration
@@ -12121,7 +12890,8 @@ func (v *durationSliceValue) IsCumulative() bool {
 
 // -- stringDurationMapValue.
 type stringDurationMapValue struct {
-	value *map[string]time.Duration
+	value     *map[string]time.Duration
+	delimiter string
 }
 
 var (
@@ -12137,9 +12907,9 @@ func newStringDurationMapValue(m *map[string]time.Duration) *stringDurationMapVa
 }
 
 func (v *stringDurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12180,9 +12950,14 @@ func (v *stringDurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringDurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intDurationMapValue.
 type intDurationMapValue struct {
-	value *map[int]time.Duration
+	value     *map[int]time.Duration
+	delimiter string
 }
 
 var (
@@ -12198,9 +12973,9 @@ func newIntDurationMapValue(m *map[int]time.Duration) *intDurationMapValue {
 }
 
 func (v *intDurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12246,9 +13021,14 @@ func (v *intDurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intDurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8DurationMapValue.
 type int8DurationMapValue struct {
-	value *map[int8]time.Duration
+	value     *map[int8]time.Duration
+	delimiter string
 }
 
 var (
@@ -12264,9 +13044,9 @@ func newInt8DurationMapValue(m *map[int8]time.Duration) *int8DurationMapValue {
 }
 
 func (v *int8DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12312,9 +13092,14 @@ func (v *int8DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16DurationMapValue.
 type int16DurationMapValue struct {
-	value *map[int16]time.Duration
+	value     *map[int16]time.Duration
+	delimiter string
 }
 
 var (
@@ -12330,9 +13115,9 @@ func newInt16DurationMapValue(m *map[int16]time.Duration) *int16DurationMapValue
 }
 
 func (v *int16DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12378,9 +13163,14 @@ func (v *int16DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32DurationMapValue.
 type int32DurationMapValue struct {
-	value *map[int32]time.Duration
+	value     *map[int32]time.Duration
+	delimiter string
 }
 
 var (
@@ -12396,9 +13186,9 @@ func newInt32DurationMapValue(m *map[int32]time.Duration) *int32DurationMapValue
 }
 
 func (v *int32DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12444,9 +13234,14 @@ func (v *int32DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64DurationMapValue.
 type int64DurationMapValue struct {
-	value *map[int64]time.Duration
+	value     *map[int64]time.Duration
+	delimiter string
 }
 
 var (
@@ -12462,9 +13257,9 @@ func newInt64DurationMapValue(m *map[int64]time.Duration) *int64DurationMapValue
 }
 
 func (v *int64DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12510,9 +13305,14 @@ func (v *int64DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintDurationMapValue.
 type uintDurationMapValue struct {
-	value *map[uint]time.Duration
+	value     *map[uint]time.Duration
+	delimiter string
 }
 
 var (
@@ -12528,9 +13328,9 @@ func newUintDurationMapValue(m *map[uint]time.Duration) *uintDurationMapValue {
 }
 
 func (v *uintDurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12576,9 +13376,14 @@ func (v *uintDurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintDurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8DurationMapValue.
 type uint8DurationMapValue struct {
-	value *map[uint8]time.Duration
+	value     *map[uint8]time.Duration
+	delimiter string
 }
 
 var (
@@ -12594,9 +13399,9 @@ func newUint8DurationMapValue(m *map[uint8]time.Duration) *uint8DurationMapValue
 }
 
 func (v *uint8DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12642,9 +13447,14 @@ func (v *uint8DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16DurationMapValue.
 type uint16DurationMapValue struct {
-	value *map[uint16]time.Duration
+	value     *map[uint16]time.Duration
+	delimiter string
 }
 
 var (
@@ -12660,9 +13470,9 @@ func newUint16DurationMapValue(m *map[uint16]time.Duration) *uint16DurationMapVa
 }
 
 func (v *uint16DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12708,9 +13518,14 @@ func (v *uint16DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32DurationMapValue.
 type uint32DurationMapValue struct {
-	value *map[uint32]time.Duration
+	value     *map[uint32]time.Duration
+	delimiter string
 }
 
 var (
@@ -12726,9 +13541,9 @@ func newUint32DurationMapValue(m *map[uint32]time.Duration) *uint32DurationMapVa
 }
 
 func (v *uint32DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12774,9 +13589,14 @@ func (v *uint32DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64DurationMapValue.
 type uint64DurationMapValue struct {
-	value *map[uint64]time.Duration
+	value     *map[uint64]time.Duration
+	delimiter string
 }
 
 var (
@@ -12792,9 +13612,9 @@ func newUint64DurationMapValue(m *map[uint64]time.Duration) *uint64DurationMapVa
 }
 
 func (v *uint64DurationMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -12840,6 +13660,10 @@ func (v *uint64DurationMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64DurationMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- net.IP Value.
 type ipValue struct {
 	value *net.IP
@@ -12945,7 +13769,8 @@ func (v *ipSliceValue) IsCumulative() bool {
 
 // -- stringIPMapValue.
 type stringIPMapValue struct {
-	value *map[string]net.IP
+	value     *map[string]net.IP
+	delimiter string
 }
 
 var (
@@ -12961,9 +13786,9 @@ func newStringIPMapValue(m *map[string]net.IP) *stringIPMapValue {
 }
 
 func (v *stringIPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13004,9 +13829,14 @@ func (v *stringIPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringIPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intIPMapValue.
 type intIPMapValue struct {
-	value *map[int]net.IP
+	value     *map[int]net.IP
+	delimiter string
 }
 
 var (
@@ -13022,9 +13852,9 @@ func newIntIPMapValue(m *map[int]net.IP) *intIPMapValue {
 }
 
 func (v *intIPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13070,9 +13900,14 @@ func (v *intIPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intIPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8IPMapValue.
 type int8IPMapValue struct {
-	value *map[int8]net.IP
+	value     *map[int8]net.IP
+	delimiter string
 }
 
 var (
@@ -13088,9 +13923,9 @@ func newInt8IPMapValue(m *map[int8]net.IP) *int8IPMapValue {
 }
 
 func (v *int8IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13136,9 +13971,14 @@ func (v *int8IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16IPMapValue.
 type int16IPMapValue struct {
-	value *map[int16]net.IP
+	value     *map[int16]net.IP
+	delimiter string
 }
 
 var (
@@ -13154,9 +13994,9 @@ func newInt16IPMapValue(m *map[int16]net.IP) *int16IPMapValue {
 }
 
 func (v *int16IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13202,9 +14042,14 @@ func (v *int16IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32IPMapValue.
 type int32IPMapValue struct {
-	value *map[int32]net.IP
+	value     *map[int32]net.IP
+	delimiter string
 }
 
 var (
@@ -13220,9 +14065,9 @@ func newInt32IPMapValue(m *map[int32]net.IP) *int32IPMapValue {
 }
 
 func (v *int32IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13268,9 +14113,14 @@ func (v *int32IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64IPMapValue.
 type int64IPMapValue struct {
-	value *map[int64]net.IP
+	value     *map[int64]net.IP
+	delimiter string
 }
 
 var (
@@ -13286,9 +14136,9 @@ func newInt64IPMapValue(m *map[int64]net.IP) *int64IPMapValue {
 }
 
 func (v *int64IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13334,9 +14184,14 @@ func (v *int64IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintIPMapValue.
 type uintIPMapValue struct {
-	value *map[uint]net.IP
+	value     *map[uint]net.IP
+	delimiter string
 }
 
 var (
@@ -13352,9 +14207,9 @@ func newUintIPMapValue(m *map[uint]net.IP) *uintIPMapValue {
 }
 
 func (v *uintIPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13400,9 +14255,14 @@ func (v *uintIPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintIPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8IPMapValue.
 type uint8IPMapValue struct {
-	value *map[uint8]net.IP
+	value     *map[uint8]net.IP
+	delimiter string
 }
 
 var (
@@ -13418,9 +14278,9 @@ func newUint8IPMapValue(m *map[uint8]net.IP) *uint8IPMapValue {
 }
 
 func (v *uint8IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13466,9 +14326,14 @@ func (v *uint8IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16IPMapValue.
 type uint16IPMapValue struct {
-	value *map[uint16]net.IP
+	value     *map[uint16]net.IP
+	delimiter string
 }
 
 var (
@@ -13484,9 +14349,9 @@ func newUint16IPMapValue(m *map[uint16]net.IP) *uint16IPMapValue {
 }
 
 func (v *uint16IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13532,9 +14397,14 @@ func (v *uint16IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32IPMapValue.
 type uint32IPMapValue struct {
-	value *map[uint32]net.IP
+	value     *map[uint32]net.IP
+	delimiter string
 }
 
 var (
@@ -13550,9 +14420,9 @@ func newUint32IPMapValue(m *map[uint32]net.IP) *uint32IPMapValue {
 }
 
 func (v *uint32IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13598,9 +14468,14 @@ func (v *uint32IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64IPMapValue.
 type uint64IPMapValue struct {
-	value *map[uint64]net.IP
+	value     *map[uint64]net.IP
+	delimiter string
 }
 
 var (
@@ -13616,9 +14491,9 @@ func newUint64IPMapValue(m *map[uint64]net.IP) *uint64IPMapValue {
 }
 
 func (v *uint64IPMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13664,6 +14539,10 @@ func (v *uint64IPMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64IPMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- HexBytes Value.
 type hexBytesValue struct {
 	value *HexBytes
@@ -13769,7 +14648,8 @@ func (v *hexBytesSliceValue) IsCumulative() bool {
 
 // -- stringHexBytesMapValue.
 type stringHexBytesMapValue struct {
-	value *map[string]HexBytes
+	value     *map[string]HexBytes
+	delimiter string
 }
 
 var (
@@ -13785,9 +14665,9 @@ func newStringHexBytesMapValue(m *map[string]HexBytes) *stringHexBytesMapValue {
 }
 
 func (v *stringHexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13828,9 +14708,14 @@ func (v *stringHexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringHexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intHexBytesMapValue.
 type intHexBytesMapValue struct {
-	value *map[int]HexBytes
+	value     *map[int]HexBytes
+	delimiter string
 }
 
 var (
@@ -13846,9 +14731,9 @@ func newIntHexBytesMapValue(m *map[int]HexBytes) *intHexBytesMapValue {
 }
 
 func (v *intHexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13894,9 +14779,14 @@ func (v *intHexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intHexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8HexBytesMapValue.
 type int8HexBytesMapValue struct {
-	value *map[int8]HexBytes
+	value     *map[int8]HexBytes
+	delimiter string
 }
 
 var (
@@ -13912,9 +14802,9 @@ func newInt8HexBytesMapValue(m *map[int8]HexBytes) *int8HexBytesMapValue {
 }
 
 func (v *int8HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -13960,9 +14850,14 @@ func (v *int8HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16HexBytesMapValue.
 type int16HexBytesMapValue struct {
-	value *map[int16]HexBytes
+	value     *map[int16]HexBytes
+	delimiter string
 }
 
 var (
@@ -13978,9 +14873,9 @@ func newInt16HexBytesMapValue(m *map[int16]HexBytes) *int16HexBytesMapValue {
 }
 
 func (v *int16HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14026,9 +14921,14 @@ func (v *int16HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32HexBytesMapValue.
 type int32HexBytesMapValue struct {
-	value *map[int32]HexBytes
+	value     *map[int32]HexBytes
+	delimiter string
 }
 
 var (
@@ -14044,9 +14944,9 @@ func newInt32HexBytesMapValue(m *map[int32]HexBytes) *int32HexBytesMapValue {
 }
 
 func (v *int32HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14092,9 +14992,14 @@ func (v *int32HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64HexBytesMapValue.
 type int64HexBytesMapValue struct {
-	value *map[int64]HexBytes
+	value     *map[int64]HexBytes
+	delimiter string
 }
 
 var (
@@ -14110,9 +15015,9 @@ func newInt64HexBytesMapValue(m *map[int64]HexBytes) *int64HexBytesMapValue {
 }
 
 func (v *int64HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14158,9 +15063,14 @@ func (v *int64HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintHexBytesMapValue.
 type uintHexBytesMapValue struct {
-	value *map[uint]HexBytes
+	value     *map[uint]HexBytes
+	delimiter string
 }
 
 var (
@@ -14176,9 +15086,9 @@ func newUintHexBytesMapValue(m *map[uint]HexBytes) *uintHexBytesMapValue {
 }
 
 func (v *uintHexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14224,9 +15134,14 @@ func (v *uintHexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintHexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8HexBytesMapValue.
 type uint8HexBytesMapValue struct {
-	value *map[uint8]HexBytes
+	value     *map[uint8]HexBytes
+	delimiter string
 }
 
 var (
@@ -14242,9 +15157,9 @@ func newUint8HexBytesMapValue(m *map[uint8]HexBytes) *uint8HexBytesMapValue {
 }
 
 func (v *uint8HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14290,9 +15205,14 @@ func (v *uint8HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16HexBytesMapValue.
 type uint16HexBytesMapValue struct {
-	value *map[uint16]HexBytes
+	value     *map[uint16]HexBytes
+	delimiter string
 }
 
 var (
@@ -14308,9 +15228,9 @@ func newUint16HexBytesMapValue(m *map[uint16]HexBytes) *uint16HexBytesMapValue {
 }
 
 func (v *uint16HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14356,9 +15276,14 @@ func (v *uint16HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32HexBytesMapValue.
 type uint32HexBytesMapValue struct {
-	value *map[uint32]HexBytes
+	value     *map[uint32]HexBytes
+	delimiter string
 }
 
 var (
@@ -14374,9 +15299,9 @@ func newUint32HexBytesMapValue(m *map[uint32]HexBytes) *uint32HexBytesMapValue {
 }
 
 func (v *uint32HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14422,9 +15347,14 @@ func (v *uint32HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64HexBytesMapValue.
 type uint64HexBytesMapValue struct {
-	value *map[uint64]HexBytes
+	value     *map[uint64]HexBytes
+	delimiter string
 }
 
 var (
@@ -14440,9 +15370,9 @@ func newUint64HexBytesMapValue(m *map[uint64]HexBytes) *uint64HexBytesMapValue {
 }
 
 func (v *uint64HexBytesMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14488,6 +15418,10 @@ func (v *uint64HexBytesMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64HexBytesMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- *regexp.Regexp Value.
 type regexpValue struct {
 	value **regexp.Regexp
@@ -14593,7 +15527,8 @@ func (v *regexpSliceValue) IsCumulative() bool {
 
 // -- stringRegexpMapValue.
 type stringRegexpMapValue struct {
-	value *map[string]*regexp.Regexp
+	value     *map[string]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -14609,9 +15544,9 @@ func newStringRegexpMapValue(m *map[string]*regexp.Regexp) *stringRegexpMapValue
 }
 
 func (v *stringRegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14652,9 +15587,14 @@ func (v *stringRegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringRegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intRegexpMapValue.
 type intRegexpMapValue struct {
-	value *map[int]*regexp.Regexp
+	value     *map[int]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -14670,9 +15610,9 @@ func newIntRegexpMapValue(m *map[int]*regexp.Regexp) *intRegexpMapValue {
 }
 
 func (v *intRegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14718,9 +15658,14 @@ func (v *intRegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intRegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8RegexpMapValue.
 type int8RegexpMapValue struct {
-	value *map[int8]*regexp.Regexp
+	value     *map[int8]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -14736,9 +15681,9 @@ func newInt8RegexpMapValue(m *map[int8]*regexp.Regexp) *int8RegexpMapValue {
 }
 
 func (v *int8RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14784,9 +15729,14 @@ func (v *int8RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16RegexpMapValue.
 type int16RegexpMapValue struct {
-	value *map[int16]*regexp.Regexp
+	value     *map[int16]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -14802,9 +15752,9 @@ func newInt16RegexpMapValue(m *map[int16]*regexp.Regexp) *int16RegexpMapValue {
 }
 
 func (v *int16RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14850,9 +15800,14 @@ func (v *int16RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32RegexpMapValue.
 type int32RegexpMapValue struct {
-	value *map[int32]*regexp.Regexp
+	value     *map[int32]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -14868,9 +15823,9 @@ func newInt32RegexpMapValue(m *map[int32]*regexp.Regexp) *int32RegexpMapValue {
 }
 
 func (v *int32RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14916,9 +15871,14 @@ func (v *int32RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64RegexpMapValue.
 type int64RegexpMapValue struct {
-	value *map[int64]*regexp.Regexp
+	value     *map[int64]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -14934,9 +15894,9 @@ func newInt64RegexpMapValue(m *map[int64]*regexp.Regexp) *int64RegexpMapValue {
 }
 
 func (v *int64RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -14982,9 +15942,14 @@ func (v *int64RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintRegexpMapValue.
 type uintRegexpMapValue struct {
-	value *map[uint]*regexp.Regexp
+	value     *map[uint]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -15000,9 +15965,9 @@ func newUintRegexpMapValue(m *map[uint]*regexp.Regexp) *uintRegexpMapValue {
 }
 
 func (v *uintRegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15048,9 +16013,14 @@ func (v *uintRegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintRegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8RegexpMapValue.
 type uint8RegexpMapValue struct {
-	value *map[uint8]*regexp.Regexp
+	value     *map[uint8]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -15066,9 +16036,9 @@ func newUint8RegexpMapValue(m *map[uint8]*regexp.Regexp) *uint8RegexpMapValue {
 }
 
 func (v *uint8RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15114,9 +16084,14 @@ func (v *uint8RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16RegexpMapValue.
 type uint16RegexpMapValue struct {
-	value *map[uint16]*regexp.Regexp
+	value     *map[uint16]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -15132,9 +16107,9 @@ func newUint16RegexpMapValue(m *map[uint16]*regexp.Regexp) *uint16RegexpMapValue
 }
 
 func (v *uint16RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15180,9 +16155,14 @@ func (v *uint16RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32RegexpMapValue.
 type uint32RegexpMapValue struct {
-	value *map[uint32]*regexp.Regexp
+	value     *map[uint32]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -15198,9 +16178,9 @@ func newUint32RegexpMapValue(m *map[uint32]*regexp.Regexp) *uint32RegexpMapValue
 }
 
 func (v *uint32RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15246,9 +16226,14 @@ func (v *uint32RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64RegexpMapValue.
 type uint64RegexpMapValue struct {
-	value *map[uint64]*regexp.Regexp
+	value     *map[uint64]*regexp.Regexp
+	delimiter string
 }
 
 var (
@@ -15264,9 +16249,9 @@ func newUint64RegexpMapValue(m *map[uint64]*regexp.Regexp) *uint64RegexpMapValue
 }
 
 func (v *uint64RegexpMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15312,6 +16297,10 @@ func (v *uint64RegexpMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint64RegexpMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- net.TCPAddr Value.
 type tcpAddrValue struct {
 	value *net.TCPAddr
@@ -15520,7 +16509,8 @@ func (v *ipNetSliceValue) IsCumulative() bool {
 
 // -- stringIPNetMapValue.
 type stringIPNetMapValue struct {
-	value *map[string]net.IPNet
+	value     *map[string]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15536,9 +16526,9 @@ func newStringIPNetMapValue(m *map[string]net.IPNet) *stringIPNetMapValue {
 }
 
 func (v *stringIPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15579,9 +16569,14 @@ func (v *stringIPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *stringIPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- intIPNetMapValue.
 type intIPNetMapValue struct {
-	value *map[int]net.IPNet
+	value     *map[int]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15597,9 +16592,9 @@ func newIntIPNetMapValue(m *map[int]net.IPNet) *intIPNetMapValue {
 }
 
 func (v *intIPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15645,9 +16640,14 @@ func (v *intIPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *intIPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int8IPNetMapValue.
 type int8IPNetMapValue struct {
-	value *map[int8]net.IPNet
+	value     *map[int8]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15663,9 +16663,9 @@ func newInt8IPNetMapValue(m *map[int8]net.IPNet) *int8IPNetMapValue {
 }
 
 func (v *int8IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15711,9 +16711,14 @@ func (v *int8IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int8IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int16IPNetMapValue.
 type int16IPNetMapValue struct {
-	value *map[int16]net.IPNet
+	value     *map[int16]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15729,9 +16734,9 @@ func newInt16IPNetMapValue(m *map[int16]net.IPNet) *int16IPNetMapValue {
 }
 
 func (v *int16IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15777,9 +16782,14 @@ func (v *int16IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int16IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int32IPNetMapValue.
 type int32IPNetMapValue struct {
-	value *map[int32]net.IPNet
+	value     *map[int32]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15795,9 +16805,9 @@ func newInt32IPNetMapValue(m *map[int32]net.IPNet) *int32IPNetMapValue {
 }
 
 func (v *int32IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15843,9 +16853,14 @@ func (v *int32IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int32IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- int64IPNetMapValue.
 type int64IPNetMapValue struct {
-	value *map[int64]net.IPNet
+	value     *map[int64]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15861,9 +16876,9 @@ func newInt64IPNetMapValue(m *map[int64]net.IPNet) *int64IPNetMapValue {
 }
 
 func (v *int64IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15909,9 +16924,14 @@ func (v *int64IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *int64IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uintIPNetMapValue.
 type uintIPNetMapValue struct {
-	value *map[uint]net.IPNet
+	value     *map[uint]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15927,9 +16947,9 @@ func newUintIPNetMapValue(m *map[uint]net.IPNet) *uintIPNetMapValue {
 }
 
 func (v *uintIPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -15975,9 +16995,14 @@ func (v *uintIPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uintIPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint8IPNetMapValue.
 type uint8IPNetMapValue struct {
-	value *map[uint8]net.IPNet
+	value     *map[uint8]net.IPNet
+	delimiter string
 }
 
 var (
@@ -15993,9 +17018,9 @@ func newUint8IPNetMapValue(m *map[uint8]net.IPNet) *uint8IPNetMapValue {
 }
 
 func (v *uint8IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -16041,9 +17066,14 @@ func (v *uint8IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint8IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint16IPNetMapValue.
 type uint16IPNetMapValue struct {
-	value *map[uint16]net.IPNet
+	value     *map[uint16]net.IPNet
+	delimiter string
 }
 
 var (
@@ -16059,9 +17089,9 @@ func newUint16IPNetMapValue(m *map[uint16]net.IPNet) *uint16IPNetMapValue {
 }
 
 func (v *uint16IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -16107,9 +17137,14 @@ func (v *uint16IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint16IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint32IPNetMapValue.
 type uint32IPNetMapValue struct {
-	value *map[uint32]net.IPNet
+	value     *map[uint32]net.IPNet
+	delimiter string
 }
 
 var (
@@ -16125,9 +17160,9 @@ func newUint32IPNetMapValue(m *map[uint32]net.IPNet) *uint32IPNetMapValue {
 }
 
 func (v *uint32IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -16173,9 +17208,14 @@ func (v *uint32IPNetMapValue) IsCumulative() bool {
 	return true
 }
 
+func (v *uint32IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
+
 // -- uint64IPNetMapValue.
 type uint64IPNetMapValue struct {
-	value *map[uint64]net.IPNet
+	value     *map[uint64]net.IPNet
+	delimiter string
 }
 
 var (
@@ -16191,9 +17231,9 @@ func newUint64IPNetMapValue(m *map[uint64]net.IPNet) *uint64IPNetMapValue {
 }
 
 func (v *uint64IPNetMapValue) Set(s string) error {
-	ss := strings.Split(s, ":")
-	if len(ss) < 2 {
-		return errors.New("invalid map flag syntax, use -map=key1:val1")
+	ss, err := splitKeyValue(s, v.delimiter)
+	if err != nil {
+		return err
 	}
 
 	s = ss[0]
@@ -16238,3 +17278,7 @@ func (v *uint64IPNetMapValue) Type() string { return "map[uint64]net.IPNet" }
 func (v *uint64IPNetMapValue) IsCumulative() bool {
 	return true
 }
+
+func (v *uint64IPNetMapValue) setKeyValueDelimiter(delimiter string) {
+	v.delimiter = delimiter
+}
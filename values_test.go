@@ -1,10 +1,17 @@
 package sflags
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCounter_Set(t *testing.T) {
@@ -40,6 +47,38 @@ func TestCounter_Set(t *testing.T) {
 	assert.Equal(t, "11", counter.String())
 }
 
+func TestTriBool_Set(t *testing.T) {
+	var err error
+	var tribool TriBool
+
+	assert.Equal(t, TriAuto, tribool)
+	assert.Equal(t, "auto", tribool.String())
+	assert.Equal(t, TriAuto, tribool.Get())
+	assert.Equal(t, "tribool", tribool.Type())
+	assert.Equal(t, true, tribool.IsBoolFlag())
+	assert.Equal(t, false, tribool.IsSet())
+	assert.Equal(t, true, tribool.Bool(true))
+
+	err = tribool.Set("")
+	assert.NoError(t, err)
+	assert.Equal(t, TriTrue, tribool)
+	assert.Equal(t, "true", tribool.String())
+
+	err = tribool.Set("false")
+	assert.NoError(t, err)
+	assert.Equal(t, TriFalse, tribool)
+	assert.Equal(t, true, tribool.IsSet())
+	assert.Equal(t, false, tribool.Bool(true))
+
+	err = tribool.Set("auto")
+	assert.NoError(t, err)
+	assert.Equal(t, TriAuto, tribool)
+
+	err = tribool.Set("maybe")
+	assert.EqualError(t, err, `invalid value "maybe", must be true, false or auto`)
+	assert.Equal(t, TriAuto, tribool)
+}
+
 func TestBoolValue_IsBoolFlag(t *testing.T) {
 	b := &boolValue{}
 	assert.True(t, b.IsBoolFlag())
@@ -86,3 +125,301 @@ func TestValidateValue_Set(t *testing.T) {
 	}
 	assert.EqualError(t, v.Set("newVal"), "invalid newVal")
 }
+
+func TestDurationOrOff_Set(t *testing.T) {
+	var err error
+	var duration DurationOrOff
+
+	assert.Equal(t, "off", duration.String())
+	assert.Equal(t, time.Duration(0), duration.Get())
+	assert.Equal(t, "durationOrOff", duration.Type())
+	assert.Equal(t, true, duration.IsOff())
+
+	err = duration.Set("1m30s")
+	assert.NoError(t, err)
+	assert.Equal(t, false, duration.IsOff())
+	assert.Equal(t, 90*time.Second, duration.Duration())
+	assert.Equal(t, "1m30s", duration.String())
+
+	for _, off := range []string{"off", "none", "0"} {
+		duration = DurationOrOff(time.Second)
+		err = duration.Set(off)
+		assert.NoError(t, err)
+		assert.Equal(t, true, duration.IsOff())
+	}
+
+	err = duration.Set("-1s")
+	assert.EqualError(t, err, `invalid value "-1s", duration cannot be negative`)
+
+	err = duration.Set("soon")
+	assert.EqualError(t, err, `invalid value "soon", must be a duration or off`)
+	assert.Equal(t, true, duration.IsOff())
+}
+
+// level is a type only implementing the standard text (un)marshaling interfaces.
+type level struct {
+	name string
+}
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "debug", "info":
+		l.name = string(text)
+		return nil
+	}
+	return fmt.Errorf("unknown level %q", text)
+}
+
+func (l level) MarshalText() ([]byte, error) { return []byte("<" + l.name + ">"), nil }
+
+func TestTextValue(t *testing.T) {
+	cfg := &struct {
+		Level level       `long:"level"`
+		Ptr   *level      `long:"ptr"`
+		Time  time.Time   `long:"time"`
+		Addrs []net.IP    `long:"addr"`
+		Kinds reflectKind `long:"kind"`
+	}{Level: level{name: "info"}}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 5)
+
+	assert.Equal(t, "<info>", flags[0].DefValue)
+	assert.Equal(t, "sflags.level", flags[0].Value.Type())
+	require.NoError(t, flags[0].Value.Set("debug"))
+	assert.Equal(t, level{name: "debug"}, cfg.Level)
+	assert.EqualError(t, flags[0].Value.Set("trace"), `unknown level "trace"`)
+
+	require.NoError(t, flags[1].Value.Set("info"))
+	assert.Equal(t, &level{name: "info"}, cfg.Ptr)
+
+	require.NoError(t, flags[2].Value.Set("2020-01-02T03:04:05Z"))
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), cfg.Time)
+	assert.Equal(t, "2020-01-02T03:04:05Z", flags[2].Value.String())
+
+	// Generated values have precedence.
+	assert.Equal(t, "ipSlice", flags[3].Value.Type())
+
+	require.NoError(t, flags[4].Value.Set("b"))
+	assert.Equal(t, "b", flags[4].Value.String())
+}
+
+// reflectKind only implements encoding.TextUnmarshaler.
+type reflectKind string
+
+func (k *reflectKind) UnmarshalText(text []byte) error {
+	*k = reflectKind(text)
+	return nil
+}
+
+func TestRatio_Set(t *testing.T) {
+	var ratio Ratio
+
+	assert.Equal(t, "0", ratio.String())
+	assert.Equal(t, "ratio", ratio.Type())
+
+	tests := []struct {
+		in     string
+		out    float64
+		expErr string
+	}{
+		{in: "50%", out: 0.5},
+		{in: "12.5%", out: 0.125},
+		{in: "0.25", out: 0.25},
+		{in: "1", out: 1},
+		{in: "1/4", out: 0.25},
+		{in: "3 / 4", out: 0.75},
+		{in: "150%", expErr: `invalid ratio "150%", must be between 0 and 1 (0% and 100%)`},
+		{in: "-0.1", expErr: `invalid ratio "-0.1", must be between 0 and 1 (0% and 100%)`},
+		{in: "NaN", expErr: `invalid ratio "NaN", must be between 0 and 1 (0% and 100%)`},
+		{in: "nan%", expErr: `invalid ratio "nan%", must be between 0 and 1 (0% and 100%)`},
+		{in: "1/0", expErr: `invalid ratio "1/0", use 50%, 0.5 or 1/2`},
+		{in: "half", expErr: `invalid ratio "half", use 50%, 0.5 or 1/2`},
+	}
+
+	for _, test := range tests {
+		ratio = 0
+		err := ratio.Set(test.in)
+		if test.expErr != "" {
+			assert.EqualError(t, err, test.expErr, test.in)
+			assert.Equal(t, Ratio(0), ratio, test.in)
+
+			continue
+		}
+
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.out, ratio.Get(), test.in)
+	}
+
+	ratio = 0.5
+	assert.Equal(t, 50.0, ratio.Percent())
+	assert.Equal(t, "0.5", ratio.String())
+}
+
+func TestRatio_Validate(t *testing.T) {
+	cfg := &struct {
+		Sampling Ratio `long:"sampling" validate:"max=0.5"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+
+	require.NoError(t, flags[0].Value.Set("10%"))
+	assert.Equal(t, Ratio(0.1), cfg.Sampling)
+	assert.Error(t, flags[0].Value.Set("3/4"))
+	assert.Equal(t, Ratio(0.1), cfg.Sampling)
+}
+
+func TestStdlibValues(t *testing.T) {
+	cfg := &struct {
+		Since   time.Time     `long:"since"`
+		Day     time.Time     `long:"day" layout:"2006-01-02"`
+		Days    []time.Time   `long:"days" layout:"Jan 2, 2006"`
+		Timeout time.Duration `long:"timeout"`
+		Addr    net.IP        `long:"addr"`
+		Proxy   url.URL       `long:"proxy"`
+		Mirror  *url.URL      `long:"mirror"`
+		Peers   []*url.URL    `long:"peer"`
+	}{Day: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 8)
+
+	assert.Equal(t, "", flags[0].DefValue)
+	require.NoError(t, flags[0].Value.Set("2020-01-02T03:04:05Z"))
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), cfg.Since)
+
+	assert.Equal(t, "2020-01-02", flags[1].DefValue)
+	require.NoError(t, flags[1].Value.Set("2021-03-04"))
+	assert.Equal(t, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC), cfg.Day)
+	assert.Error(t, flags[1].Value.Set("2021-03-04T00:00:00Z"))
+
+	assert.Equal(t, "timeSlice", flags[2].Value.Type())
+	require.NoError(t, flags[2].Value.Set("Feb 1, 2022"))
+	require.NoError(t, flags[2].Value.Set("Feb 2, 2022"))
+	assert.Equal(t, []time.Time{
+		time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC),
+	}, cfg.Days)
+	assert.Equal(t, "[Feb 1, 2022,Feb 2, 2022]", flags[2].Value.String())
+
+	require.NoError(t, flags[3].Value.Set("1m"))
+	assert.Equal(t, time.Minute, cfg.Timeout)
+
+	require.NoError(t, flags[4].Value.Set("10.0.0.1"))
+	assert.Equal(t, net.ParseIP("10.0.0.1"), cfg.Addr)
+
+	assert.Equal(t, "url", flags[5].Value.Type())
+	require.NoError(t, flags[5].Value.Set("http://proxy:3128"))
+	assert.Equal(t, "proxy:3128", cfg.Proxy.Host)
+	assert.Error(t, flags[5].Value.Set("http://[::1"))
+
+	require.NoError(t, flags[6].Value.Set("https://mirror.example.com/pub"))
+	assert.Equal(t, "/pub", cfg.Mirror.Path)
+
+	assert.Equal(t, "urlSlice", flags[7].Value.Type())
+	require.NoError(t, flags[7].Value.Set("tcp://a:1"))
+	require.NoError(t, flags[7].Value.Set("tcp://b:2"))
+	require.Len(t, cfg.Peers, 2)
+	assert.Equal(t, "b:2", cfg.Peers[1].Host)
+	assert.Equal(t, "[tcp://a:1,tcp://b:2]", flags[7].Value.String())
+}
+
+func TestSize_Set(t *testing.T) {
+	var size Size
+
+	assert.Equal(t, "0B", size.String())
+	assert.Equal(t, "size", size.Type())
+
+	tests := []struct {
+		in     string
+		out    uint64
+		str    string
+		expErr string
+	}{
+		{in: "512", out: 512, str: "512B"},
+		{in: "10MB", out: 10_000_000, str: "10MB"},
+		{in: "10 mb", out: 10_000_000, str: "10MB"},
+		{in: "1.5GiB", out: 3 << 29, str: "1536MiB"},
+		{in: "2k", out: 2000, str: "2KB"},
+		{in: "4Ki", out: 4096, str: "4KiB"},
+		{in: "1e3", out: 1000, str: "1KB"},
+		{in: "1.5", out: 1, str: "1B"},
+		{in: "-1MB", expErr: `invalid size "-1MB", use a number of bytes with an optional unit (eg. 10MB, 1.5GiB)`},
+		{in: "10XB", expErr: `invalid size "10XB", use a number of bytes with an optional unit (eg. 10MB, 1.5GiB)`},
+		{in: "MB", expErr: `invalid size "MB", use a number of bytes with an optional unit (eg. 10MB, 1.5GiB)`},
+		{in: "100EiB", expErr: `invalid size "100EiB", value out of range`},
+	}
+
+	for _, test := range tests {
+		size = 0
+		err := size.Set(test.in)
+		if test.expErr != "" {
+			assert.EqualError(t, err, test.expErr, test.in)
+			assert.Equal(t, Size(0), size, test.in)
+
+			continue
+		}
+
+		assert.NoError(t, err, test.in)
+		assert.Equal(t, test.out, size.Get(), test.in)
+		assert.Equal(t, test.str, size.String(), test.in)
+	}
+
+	assert.Equal(t, "1234B", Size(1234).String())
+}
+
+func TestSize_Tag(t *testing.T) {
+	type bytes uint64
+
+	cfg := &struct {
+		Limit  uint64 `long:"limit" type:"size"`
+		Buffer int64  `long:"buffer" type:"size"`
+		Cache  bytes  `long:"cache" type:"size"`
+		Name   string `long:"name" type:"size"`
+	}{Limit: 1 << 20}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+	require.Len(t, flags, 4)
+
+	assert.Equal(t, "size", flags[0].Value.Type())
+	assert.Equal(t, "1MiB", flags[0].DefValue)
+	assert.Equal(t, "size", flags[1].Value.Type())
+	assert.Equal(t, "size", flags[2].Value.Type())
+	assert.Equal(t, "string", flags[3].Value.Type(), "only integer fields can be sizes")
+
+	require.NoError(t, flags[0].Value.Set("2GB"))
+	require.NoError(t, flags[1].Value.Set("64KiB"))
+	require.NoError(t, flags[2].Value.Set("1k"))
+	assert.Equal(t, uint64(2_000_000_000), cfg.Limit)
+	assert.Equal(t, int64(65536), cfg.Buffer)
+	assert.Equal(t, bytes(1000), cfg.Cache)
+	assert.Equal(t, "64KiB", flags[1].Value.String())
+}
+
+func TestConvertedValue(t *testing.T) {
+	cfg := &struct {
+		Port   int `long:"port"`
+		Scheme int `long:"scheme" choices:"80 443"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	err = flags[0].Value.Set("http")
+
+	var convErr *ConvertError
+	require.ErrorAs(t, err, &convErr)
+	assert.Equal(t, "port", convErr.Field)
+	assert.Equal(t, "http", convErr.Word)
+	assert.Equal(t, reflect.TypeOf(0), convErr.TargetType)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = flags[1].Value.Set("8080")
+	require.Error(t, err)
+	assert.False(t, errors.As(err, &convErr), "refused values are not conversion errors")
+}
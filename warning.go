@@ -0,0 +1,103 @@
+package sflags
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// WarningKind identifies the cause of a Warning.
+type WarningKind int
+
+const (
+	// WarningDeprecated is reported when a deprecated flag is set.
+	WarningDeprecated WarningKind = iota
+	// WarningMigrated is reported when an obsolete value is replaced (see the `migrate` tag).
+	WarningMigrated
+	// WarningIgnored is reported when a setting is ignored, like a profile value for a flag
+	// that cannot be set from profiles.
+	WarningIgnored
+	// WarningAmbiguous is reported when short flags cannot be clustered
+	// unambiguously (see AuditShorthands).
+	WarningAmbiguous
+)
+
+// Warning is a problem found while parsing a struct or setting its flags, which
+// does not prevent the program from running: the user should still be told about it.
+type Warning struct {
+	Kind    WarningKind
+	Flag    string // Name of the flag the warning is about, if any.
+	Message string
+}
+
+func (w Warning) String() string { return w.Message }
+
+// WarningHandler sets the function to which warnings are delivered, instead of
+// them being printed on os.Stderr. The handler is called synchronously, when the
+// flag concerned is set. Deprecated flags are only reported when a handler is set,
+// since some generators (eg. pflag and cobra) already print a deprecation notice.
+func WarningHandler(handler func(Warning)) OptFunc {
+	return func(opt *opts) { opt.warn = handler }
+}
+
+// Warnings sets the writer on which warnings are printed, like the ones about
+// obsolete values being migrated (see the `migrate` tag). It is os.Stderr by
+// default, and warnings can be silenced with io.Discard.
+func Warnings(w io.Writer) OptFunc {
+	return WarningHandler(func(warning Warning) {
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	})
+}
+
+// warner returns the warning handler of the options, which prints on os.Stderr if none is set.
+func (o opts) warner() func(Warning) {
+	if o.warn != nil {
+		return o.warn
+	}
+
+	return func(warning Warning) {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+}
+
+// deprecatedValue reports a warning whenever a deprecated flag is set.
+type deprecatedValue struct {
+	Value
+	name string
+	msg  string
+	warn func(Warning)
+}
+
+func (v *deprecatedValue) String() string {
+	if v.Value != nil {
+		return v.Value.String()
+	}
+
+	return ""
+}
+
+func (v *deprecatedValue) IsCumulative() bool {
+	if cumulativeFlag, casted := v.Value.(RepeatableFlag); casted {
+		return cumulativeFlag.IsCumulative()
+	}
+
+	return false
+}
+
+func (v *deprecatedValue) Set(val string) error {
+	msg := fmt.Sprintf("flag %s is deprecated", v.name)
+	if v.msg != "" {
+		msg = fmt.Sprintf("%s, %s", msg, v.msg)
+	}
+
+	v.warn(Warning{Kind: WarningDeprecated, Flag: v.name, Message: msg})
+
+	return v.Value.Set(val)
+}
+
+// deprecateValue wraps the value of a deprecated flag, to report when it is set.
+func deprecateValue(val Value, flag *Flag, opt opts) Value {
+	name := flagName(flag)
+
+	return &deprecatedValue{Value: val, name: name, msg: flag.DeprecatedMsg, warn: opt.warner()}
+}
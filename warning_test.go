@@ -0,0 +1,59 @@
+package sflags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningHandler(t *testing.T) {
+	cfg := &struct {
+		Format  string `long:"format" migrate:"yml=yaml"`
+		Old     string `long:"old" deprecated:"use --format instead"`
+		Older   string `long:"older" deprecated:"true"`
+		Verbose bool   `long:"verbose" negatable:"true"`
+	}{}
+
+	var warnings []Warning
+
+	profiles := map[string]interface{}{
+		"quiet": map[string]string{"no-verbose": "true"},
+	}
+
+	flags, err := ParseStruct(cfg, Profiles(profiles), WarningHandler(func(warning Warning) {
+		warnings = append(warnings, warning)
+	}))
+	require.NoError(t, err)
+
+	flagSet := map[string]*Flag{}
+	for _, flag := range flags {
+		flagSet[flag.Name] = flag
+	}
+
+	require.NoError(t, flagSet["format"].Value.Set("yml"))
+	require.NoError(t, flagSet["old"].Value.Set("json"))
+	require.NoError(t, flagSet["older"].Value.Set("json"))
+	require.NoError(t, flagSet["profile"].Value.Set("quiet"))
+
+	assert.Equal(t, []Warning{
+		{Kind: WarningMigrated, Flag: "format", Message: `value "yml" for flag format is deprecated, use "yaml" instead`},
+		{Kind: WarningDeprecated, Flag: "old", Message: "flag old is deprecated, use --format instead"},
+		{Kind: WarningDeprecated, Flag: "older", Message: "flag older is deprecated"},
+		{Kind: WarningIgnored, Flag: "no-verbose", Message: "profile quiet: ignoring value for flag no-verbose, which cannot be set from profiles"},
+	}, warnings)
+	assert.Equal(t, "yaml", cfg.Format)
+	assert.Equal(t, "json", cfg.Old)
+}
+
+func TestWarningHandler_NoDeprecatedByDefault(t *testing.T) {
+	cfg := &struct {
+		Old string `long:"old" deprecated:"true"`
+	}{}
+
+	flags, err := ParseStruct(cfg)
+	require.NoError(t, err)
+
+	_, wrapped := flags[0].Value.(*deprecatedValue)
+	assert.False(t, wrapped, "generators report deprecated flags themselves")
+}